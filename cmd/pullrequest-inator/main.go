@@ -2,12 +2,26 @@ package main
 
 import (
 	"context"
+	"errors"
 	"log"
+	"net/http"
 	"os"
+	"os/signal"
 	"pullrequest-inator/internal/api"
+	"pullrequest-inator/internal/infrastructure/chat"
+	"pullrequest-inator/internal/infrastructure/email"
+	"pullrequest-inator/internal/infrastructure/github"
+	"pullrequest-inator/internal/infrastructure/gitlab"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/outbound"
 	pg2 "pullrequest-inator/internal/infrastructure/repositories/pg"
+	"pullrequest-inator/internal/infrastructure/secrets"
 	"pullrequest-inator/internal/infrastructure/services"
+	"strconv"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/labstack/echo/v4"
@@ -45,13 +59,126 @@ func main() {
 	statusRepo := pg2.NewStatusRepository(pool)
 	teamRepo := pg2.NewTeamRepository(pool)
 	userRepo := pg2.NewUserRepository(pool)
+	rotationRepo := pg2.NewRotationRepository(pool)
+	unavailabilityRepo := pg2.NewUnavailabilityRepository(pool)
+	overrideRepo := pg2.NewMergeOverrideRepository(pool)
+	externalRepo := pg2.NewExternalPullRequestRepository(pool)
+	syncFailureRepo := pg2.NewReviewerSyncFailureRepository(pool)
+	identityRepo := pg2.NewUserIdentityRepository(pool)
+	subscriptionRepo := pg2.NewWebhookSubscriptionRepository(pool)
+	deliveryRepo := pg2.NewWebhookDeliveryRepository(pool)
+	outboxRepo := pg2.NewOutboxRepository(pool)
+	chatChannelRepo := pg2.NewTeamChatChannelRepository(pool)
+	notificationPrefsRepo := pg2.NewNotificationPreferencesRepository(pool)
+	emailDigestRepo := pg2.NewEmailDigestRepository(pool)
+	slaRepo := pg2.NewTeamReviewSLARepository(pool)
+	slaBreachRepo := pg2.NewReviewSLABreachRepository(pool)
+	reminderRepo := pg2.NewReviewReminderRepository(pool)
+	statsRepo := pg2.NewStatsRepository(pool)
+	transactor := pg2.NewTransactor(pool)
 
-	prService, err := services.NewPullRequestService(userRepo, prRepo, teamRepo, statusRepo)
+	limits := services.ReviewerLimits{
+		Min: envInt("DEFAULT_MIN_REVIEWERS", services.DefaultReviewerLimits.Min),
+		Max: envInt("DEFAULT_MAX_REVIEWERS", services.DefaultReviewerLimits.Max),
+	}
+
+	gitlabUsers, err := gitlab.ParseUserMapping(os.Getenv("GITLAB_USER_MAPPING"))
+	if err != nil {
+		log.Fatalf("Invalid GITLAB_USER_MAPPING value: %v", err)
+	}
+
+	identityService, err := services.NewIdentityService(identityRepo, userRepo, gitlabUsers,
+		envBool("IDENTITY_MATCH_USERNAMES", false))
+	if err != nil {
+		log.Printf("Failed to init identity service: %v", err)
+		return
+	}
+
+	outbox, err := services.NewOutbox(outboxRepo)
+	if err != nil {
+		log.Printf("Failed to init outbox: %v", err)
+		return
+	}
+	clients := providerClients()
+	reviewerSync, err := services.NewReviewerSync(prRepo, externalRepo, syncFailureRepo, identityService,
+		clients, outbox)
+	if err != nil {
+		log.Printf("Failed to init reviewer sync: %v", err)
+		return
+	}
+
+	// Without the key the stored subscription secrets cannot be read, so the
+	// service refuses to start rather than run without webhooks.
+	if os.Getenv("WEBHOOK_SECRET_KEY") == "" {
+		log.Fatalf("WEBHOOK_SECRET_KEY is not set; generate it once with `openssl rand -base64 32` and keep it")
+	}
+	secretBox, err := secrets.NewBox(os.Getenv("WEBHOOK_SECRET_KEY"))
+	if err != nil {
+		log.Fatalf("Invalid WEBHOOK_SECRET_KEY value: %v", err)
+	}
+	// Subscriber and chat webhook URLs come in through the API, so neither may
+	// reach internal addresses.
+	guard := outbound.NewGuard(strings.Split(os.Getenv("WEBHOOK_ALLOWED_HOSTS"), ","))
+	guardedHTTP := &http.Client{
+		Timeout:   10 * time.Second,
+		Transport: &http.Transport{DialContext: guard.DialContext},
+	}
+	subscriptionService, err := services.NewSubscriptionService(subscriptionRepo, deliveryRepo,
+		outbound.NewClient(guardedHTTP), guard, secretBox)
+	if err != nil {
+		log.Printf("Failed to init subscription service: %v", err)
+		return
+	}
+	if err := subscriptionService.SealStoredSecrets(ctx); err != nil {
+		log.Printf("Failed to seal stored webhook secrets: %v", err)
+		return
+	}
+
+	chatNotifier, err := services.NewChatNotifier(prRepo, userRepo, identityRepo, chatChannelRepo,
+		notificationPrefsRepo, chat.NewClient(guardedHTTP))
+	if err != nil {
+		log.Printf("Failed to init chat notifier: %v", err)
+		return
+	}
+	emailNotifier, err := services.NewEmailNotifier(prRepo, userRepo, identityRepo, notificationPrefsRepo,
+		emailDigestRepo, email.NewClient(smtpConfig()), digestConfig())
+	if err != nil {
+		log.Printf("Failed to init email notifier: %v", err)
+		return
+	}
+	notificationService, err := services.NewNotificationService(teamRepo, userRepo, chatChannelRepo,
+		notificationPrefsRepo, guard)
+	if err != nil {
+		log.Printf("Failed to init notification service: %v", err)
+		return
+	}
+
+	sinks := []services.EventSink{reviewerSync, subscriptionService, chatNotifier}
+	emailEnabled := os.Getenv("SMTP_HOST") != ""
+	if emailEnabled {
+		sinks = append(sinks, emailNotifier)
+	}
+	dispatcher, err := services.NewOutboxDispatcher(outboxRepo, sinks, services.OutboxConfig{
+		PollInterval:  envDuration("OUTBOX_POLL_INTERVAL", services.DefaultOutboxConfig.PollInterval),
+		BatchSize:     envInt("OUTBOX_BATCH_SIZE", services.DefaultOutboxConfig.BatchSize),
+		ClaimTimeout:  envDuration("OUTBOX_CLAIM_TIMEOUT", services.DefaultOutboxConfig.ClaimTimeout),
+		RetryDelay:    envDuration("OUTBOX_RETRY_DELAY", services.DefaultOutboxConfig.RetryDelay),
+		MaxRetryDelay: services.DefaultOutboxConfig.MaxRetryDelay,
+		MaxAttempts:   envInt("OUTBOX_MAX_ATTEMPTS", services.DefaultOutboxConfig.MaxAttempts),
+		Retention:     envDuration("OUTBOX_RETENTION", services.DefaultOutboxConfig.Retention),
+	})
+	if err != nil {
+		log.Printf("Failed to init outbox dispatcher: %v", err)
+		return
+	}
+
+	prService, err := services.NewPullRequestService(userRepo, prRepo, teamRepo, statusRepo,
+		rotationRepo, unavailabilityRepo, overrideRepo, reviewerSync, outbox, transactor, limits)
 	if err != nil {
 		log.Printf("Failed to init pullrequest service: %v", err)
 		return
 	}
-	teamService, err := services.NewTeamService(teamRepo, userRepo)
+	teamService, err := services.NewTeamService(teamRepo, userRepo, transactor, prService, outbox, limits)
 	if err != nil {
 		log.Printf("Failed to init team service: %v", err)
 		return
@@ -61,12 +188,57 @@ func main() {
 		log.Printf("Failed to init user service: %v", err)
 		return
 	}
+	unavailabilityService, err := services.NewUnavailabilityService(unavailabilityRepo, userRepo)
+	if err != nil {
+		log.Printf("Failed to init unavailability service: %v", err)
+		return
+	}
+	var gitlabAPI services.GitLabUsers
+	if client, ok := clients[models.ProviderGitLab].(*gitlab.Client); ok {
+		gitlabAPI = client
+	}
+	webhookService, err := services.NewWebhookService(prService, identityService, externalRepo, reviewerSync, transactor,
+		gitlabAPI, services.WebhookConfig{
+			GitHubSecret: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+			GitLabToken:  os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+		})
+	if err != nil {
+		log.Printf("Failed to init webhook service: %v", err)
+		return
+	}
+	reviewSLAService, err := services.NewReviewSLAService(teamRepo, prRepo, slaRepo)
+	if err != nil {
+		log.Printf("Failed to init review SLA service: %v", err)
+		return
+	}
+	slaChecker, err := services.NewSLAChecker(reviewSLAService, slaBreachRepo, prService, outbox, transactor, log.Default(),
+		envDuration("SLA_CHECK_INTERVAL", services.DefaultSLACheckInterval))
+	if err != nil {
+		log.Printf("Failed to init review SLA checker: %v", err)
+		return
+	}
+	reminderScheduler, err := services.NewReminderScheduler(reminderRepo, pg2.NewAdvisoryLock(pool, "review-reminders"),
+		outbox, transactor, log.Default(), services.ReminderConfig{
+			After:    envDuration("REMINDER_AFTER", services.DefaultReminderConfig.After),
+			Interval: envDuration("REMINDER_INTERVAL", services.DefaultReminderConfig.Interval),
+		})
+	if err != nil {
+		log.Printf("Failed to init reminder scheduler: %v", err)
+		return
+	}
+	statsService, err := services.NewStatsService(statsRepo, teamRepo, userRepo)
+	if err != nil {
+		log.Printf("Failed to init stats service: %v", err)
+		return
+	}
 
 	e := echo.New()
 	e.Use(middleware.Logger())
 	e.Use(middleware.Recover())
 
-	server, err := api.NewServer(prService, teamService, userService)
+	server, err := api.NewServer(prService, teamService, userService, unavailabilityService,
+		identityService, webhookService, subscriptionService, notificationService, emailNotifier,
+		reviewSLAService, statsService)
 	if err != nil {
 		log.Printf("Failed to init server: %v", err)
 		return
@@ -74,8 +246,135 @@ func main() {
 
 	api.RegisterHandlers(e, server)
 
-	if err := e.Start(port); err != nil {
-		log.Printf("Failed to start server: %v", err)
-		return
+	runCtx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	var workers sync.WaitGroup
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		dispatcher.Run(runCtx)
+	}()
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		slaChecker.Run(runCtx)
+	}()
+	workers.Add(1)
+	go func() {
+		defer workers.Done()
+		reminderScheduler.Run(runCtx)
+	}()
+	if emailEnabled {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			emailNotifier.RunDigests(runCtx)
+		}()
+	}
+
+	go func() {
+		if err := e.Start(port); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Failed to start server: %v", err)
+			stop()
+		}
+	}()
+
+	<-runCtx.Done()
+	shutdownCtx, cancel := context.WithTimeout(ctx, 10*time.Second)
+	defer cancel()
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Failed to shut down server: %v", err)
+	}
+	workers.Wait()
+}
+
+// providerClients returns the clients for reviewer write-back to the
+// providers that have API credentials configured.
+func providerClients() map[string]services.ProviderClient {
+	httpClient := &http.Client{Timeout: 10 * time.Second}
+	clients := make(map[string]services.ProviderClient)
+	if token := os.Getenv("GITHUB_TOKEN"); token != "" {
+		clients[models.ProviderGitHub] = github.NewClient(os.Getenv("GITHUB_API_URL"), token, httpClient)
+	}
+	if apiURL, token := os.Getenv("GITLAB_API_URL"), os.Getenv("GITLAB_API_TOKEN"); apiURL != "" && token != "" {
+		clients[models.ProviderGitLab] = gitlab.NewClient(apiURL, token, httpClient)
+	}
+	return clients
+}
+
+// smtpConfig reads the SMTP settings. Without SMTP_HOST no emails are sent.
+func smtpConfig() email.Config {
+	tlsMode := strings.ToLower(strings.TrimSpace(os.Getenv("SMTP_TLS")))
+	switch tlsMode {
+	case "":
+		tlsMode = email.TLSStartTLS
+	case email.TLSNone, email.TLSStartTLS, email.TLSImplicit:
+	default:
+		log.Fatalf("Invalid SMTP_TLS value %q: want none, starttls or tls", tlsMode)
+	}
+
+	return email.Config{
+		Host:     strings.TrimSpace(os.Getenv("SMTP_HOST")),
+		Port:     envInt("SMTP_PORT", 587),
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		From:     os.Getenv("SMTP_FROM"),
+		TLS:      tlsMode,
+	}
+}
+
+func digestConfig() services.DigestConfig {
+	config := services.DefaultDigestConfig
+	if raw := strings.TrimSpace(os.Getenv("EMAIL_DIGEST_TIME")); raw != "" {
+		at, err := time.Parse("15:04", raw)
+		if err != nil {
+			log.Fatalf("Invalid EMAIL_DIGEST_TIME value %q: %v", raw, err)
+		}
+		config.At = time.Duration(at.Hour())*time.Hour + time.Duration(at.Minute())*time.Minute
+	}
+	if raw := strings.TrimSpace(os.Getenv("EMAIL_DIGEST_TIMEZONE")); raw != "" {
+		location, err := time.LoadLocation(raw)
+		if err != nil {
+			log.Fatalf("Invalid EMAIL_DIGEST_TIMEZONE value %q: %v", raw, err)
+		}
+		config.Location = location
+	}
+	return config
+}
+
+func envDuration(name string, fallback time.Duration) time.Duration {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return fallback
+	}
+	v, err := time.ParseDuration(raw)
+	if err != nil {
+		log.Fatalf("Invalid %s value %q: %v", name, raw, err)
+	}
+	return v
+}
+
+func envBool(name string, fallback bool) bool {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.ParseBool(raw)
+	if err != nil {
+		log.Fatalf("Invalid %s value %q: %v", name, raw, err)
+	}
+	return v
+}
+
+func envInt(name string, fallback int) int {
+	raw := strings.TrimSpace(os.Getenv(name))
+	if raw == "" {
+		return fallback
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		log.Fatalf("Invalid %s value %q: %v", name, raw, err)
 	}
+	return v
 }
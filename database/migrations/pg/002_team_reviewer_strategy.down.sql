@@ -0,0 +1,2 @@
+ALTER TABLE teams DROP CONSTRAINT IF EXISTS teams_reviewer_strategy_check;
+ALTER TABLE teams DROP COLUMN IF EXISTS reviewer_strategy;
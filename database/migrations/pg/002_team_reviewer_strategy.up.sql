@@ -0,0 +1,6 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS reviewer_strategy VARCHAR(32) NOT NULL DEFAULT 'least_loaded';
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_reviewer_strategy_check
+        CHECK (reviewer_strategy IN ('random', 'round_robin', 'least_loaded', 'weighted'));
@@ -0,0 +1,9 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS min_reviewers INTEGER,
+    ADD COLUMN IF NOT EXISTS max_reviewers INTEGER;
+
+ALTER TABLE teams
+    ADD CONSTRAINT teams_reviewer_limits_check
+        CHECK ((min_reviewers IS NULL OR min_reviewers >= 0)
+            AND (max_reviewers IS NULL OR max_reviewers >= 1)
+            AND (min_reviewers IS NULL OR max_reviewers IS NULL OR min_reviewers <= max_reviewers));
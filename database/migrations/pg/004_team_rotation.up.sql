@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS team_rotation
+(
+    team_id      BIGINT PRIMARY KEY,
+    last_user_id BIGINT,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS team_backups
+(
+    team_id        BIGINT  NOT NULL,
+    backup_team_id BIGINT  NOT NULL,
+    position       INTEGER NOT NULL,
+    PRIMARY KEY (team_id, backup_team_id),
+    UNIQUE (team_id, position),
+    CHECK (team_id <> backup_team_id),
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (backup_team_id) REFERENCES teams (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
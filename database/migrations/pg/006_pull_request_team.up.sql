@@ -0,0 +1,9 @@
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS team_id BIGINT REFERENCES teams (id)
+        ON DELETE SET NULL ON UPDATE CASCADE;
+
+UPDATE pull_requests pr
+SET team_id = (SELECT MIN(tu.team_id) FROM team_user tu WHERE tu.user_id = pr.author_id)
+WHERE pr.team_id IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_team_id ON pull_requests (team_id);
@@ -0,0 +1,18 @@
+CREATE TABLE IF NOT EXISTS user_unavailability
+(
+    id           BIGSERIAL PRIMARY KEY,
+    user_id      BIGINT                   NOT NULL,
+    starts_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    reason       VARCHAR(255)             NOT NULL DEFAULT '',
+    source       VARCHAR(16)              NOT NULL DEFAULT 'manual',
+    external_uid VARCHAR(255),
+    created_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT user_unavailability_period_check CHECK (ends_at > starts_at),
+    CONSTRAINT user_unavailability_source_check CHECK (source IN ('manual', 'ics')),
+    UNIQUE (user_id, external_uid)
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_unavailability_user_id_ends_at ON user_unavailability (user_id, ends_at);
@@ -0,0 +1,7 @@
+INSERT INTO pull_request_statuses (name)
+VALUES ('DRAFT'),
+       ('CLOSED')
+ON CONFLICT (name) DO NOTHING;
+
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS closed_at TIMESTAMP WITH TIME ZONE;
@@ -0,0 +1,5 @@
+ALTER TABLE pull_request_reviewers
+    DROP CONSTRAINT IF EXISTS pull_request_reviewers_decided_at_check,
+    DROP CONSTRAINT IF EXISTS pull_request_reviewers_decision_check,
+    DROP COLUMN IF EXISTS decided_at,
+    DROP COLUMN IF EXISTS decision;
@@ -0,0 +1,7 @@
+ALTER TABLE pull_request_reviewers
+    ADD COLUMN IF NOT EXISTS decision   VARCHAR(32),
+    ADD COLUMN IF NOT EXISTS decided_at TIMESTAMP WITH TIME ZONE,
+    ADD CONSTRAINT pull_request_reviewers_decision_check
+        CHECK (decision IN ('APPROVED', 'CHANGES_REQUESTED', 'COMMENTED')),
+    ADD CONSTRAINT pull_request_reviewers_decided_at_check
+        CHECK ((decision IS NULL) = (decided_at IS NULL));
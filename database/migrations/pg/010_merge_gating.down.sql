@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS merge_overrides;
+
+ALTER TABLE teams
+    DROP CONSTRAINT IF EXISTS teams_required_approvals_check,
+    DROP COLUMN IF EXISTS required_approvals;
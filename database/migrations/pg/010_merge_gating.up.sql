@@ -0,0 +1,19 @@
+ALTER TABLE teams
+    ADD COLUMN IF NOT EXISTS required_approvals INTEGER NOT NULL DEFAULT 0,
+    ADD CONSTRAINT teams_required_approvals_check CHECK (required_approvals >= 0);
+
+CREATE TABLE IF NOT EXISTS merge_overrides
+(
+    id               BIGSERIAL PRIMARY KEY,
+    pull_request_id  BIGINT                   NOT NULL,
+    forced_by        BIGINT,
+    reason           VARCHAR(255)             NOT NULL DEFAULT '',
+    unmet_conditions TEXT[]                   NOT NULL,
+    created_at       TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (forced_by) REFERENCES users (id)
+        ON DELETE SET NULL ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_merge_overrides_pull_request_id ON merge_overrides (pull_request_id);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS external_pull_requests
+(
+    pull_request_id BIGINT PRIMARY KEY,
+    provider        VARCHAR(16)              NOT NULL,
+    repository      VARCHAR(255)             NOT NULL,
+    number          INTEGER                  NOT NULL,
+    url             VARCHAR(512)             NOT NULL DEFAULT '',
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    UNIQUE (provider, repository, number)
+);
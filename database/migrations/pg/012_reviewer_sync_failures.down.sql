@@ -0,0 +1 @@
+DROP TABLE IF EXISTS reviewer_sync_failures;
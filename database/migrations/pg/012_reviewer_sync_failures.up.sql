@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS reviewer_sync_failures
+(
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id BIGINT                   NOT NULL,
+    provider        VARCHAR(16)              NOT NULL,
+    reviewers       TEXT[]                   NOT NULL,
+    removed         TEXT[]                   NOT NULL,
+    attempts        INTEGER                  NOT NULL,
+    error           TEXT                     NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_sync_failures_pull_request_id ON reviewer_sync_failures (pull_request_id);
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS user_identities
+(
+    id          BIGSERIAL PRIMARY KEY,
+    user_id     BIGINT                   NOT NULL,
+    provider    VARCHAR(16)              NOT NULL,
+    external_id VARCHAR(255)             NOT NULL,
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT user_identities_provider_check
+        CHECK (provider IN ('github', 'gitlab', 'slack', 'mattermost', 'email')),
+    CONSTRAINT user_identities_user_provider_key UNIQUE (user_id, provider),
+    CONSTRAINT user_identities_external_id_key UNIQUE (provider, external_id)
+);
@@ -0,0 +1,29 @@
+CREATE TABLE IF NOT EXISTS webhook_subscriptions
+(
+    id         BIGSERIAL PRIMARY KEY,
+    url        VARCHAR(2048)            NOT NULL,
+    secret     VARCHAR(255)             NOT NULL,
+    events     TEXT[]                   NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries
+(
+    id              BIGSERIAL PRIMARY KEY,
+    subscription_id BIGINT                   NOT NULL,
+    event_type      VARCHAR(64)              NOT NULL,
+    payload         JSONB                    NOT NULL,
+    status          VARCHAR(16)              NOT NULL DEFAULT 'pending',
+    attempts        INTEGER                  NOT NULL DEFAULT 0,
+    response_status INTEGER,
+    last_error      TEXT,
+    created_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at      TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    delivered_at    TIMESTAMP WITH TIME ZONE,
+    FOREIGN KEY (subscription_id) REFERENCES webhook_subscriptions (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT webhook_deliveries_status_check
+        CHECK (status IN ('pending', 'delivered', 'failed'))
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription_id ON webhook_deliveries (subscription_id);
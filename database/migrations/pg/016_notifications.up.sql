@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS team_chat_channels
+(
+    team_id     BIGINT PRIMARY KEY,
+    provider    VARCHAR(16)              NOT NULL,
+    webhook_url VARCHAR(2048)            NOT NULL,
+    channel     VARCHAR(255)             NOT NULL DEFAULT '',
+    created_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at  TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT team_chat_channels_provider_check
+        CHECK (provider IN ('slack', 'mattermost'))
+);
+
+CREATE TABLE IF NOT EXISTS notification_preferences
+(
+    user_id      BIGINT PRIMARY KEY,
+    chat_enabled BOOLEAN                  NOT NULL DEFAULT TRUE,
+    updated_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
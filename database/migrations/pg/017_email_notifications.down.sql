@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS email_digests;
+
+ALTER TABLE notification_preferences
+    DROP COLUMN IF EXISTS email_enabled;
@@ -0,0 +1,12 @@
+ALTER TABLE notification_preferences
+    ADD COLUMN IF NOT EXISTS email_enabled BOOLEAN NOT NULL DEFAULT TRUE;
+
+CREATE TABLE IF NOT EXISTS email_digests
+(
+    user_id     BIGINT                   NOT NULL,
+    digest_date DATE                     NOT NULL,
+    sent_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, digest_date),
+    FOREIGN KEY (user_id) REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
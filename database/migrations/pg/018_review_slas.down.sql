@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS review_sla_breaches;
+DROP TABLE IF EXISTS team_review_slas;
@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS team_review_slas
+(
+    team_id                BIGINT PRIMARY KEY,
+    first_decision_seconds INTEGER                  NOT NULL,
+    work_start_minutes     INTEGER                  NOT NULL DEFAULT 540,
+    work_end_minutes       INTEGER                  NOT NULL DEFAULT 1080,
+    work_days              INTEGER[]                NOT NULL DEFAULT '{1,2,3,4,5}',
+    timezone               VARCHAR(64)              NOT NULL DEFAULT 'UTC',
+    auto_reassign          BOOLEAN                  NOT NULL DEFAULT FALSE,
+    created_at             TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at             TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (team_id) REFERENCES teams (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    CONSTRAINT team_review_slas_hours_check CHECK (first_decision_seconds > 0),
+    CONSTRAINT team_review_slas_work_hours_check
+        CHECK (work_start_minutes >= 0 AND work_start_minutes < work_end_minutes AND work_end_minutes <= 1440)
+);
+
+CREATE TABLE IF NOT EXISTS review_sla_breaches
+(
+    pull_request_id BIGINT                   NOT NULL,
+    reviewer_id     BIGINT                   NOT NULL,
+    assigned_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    breached_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (pull_request_id, reviewer_id, assigned_at),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS review_reminders
+(
+    pull_request_id BIGINT                   NOT NULL,
+    reviewer_id     BIGINT                   NOT NULL,
+    assigned_at     TIMESTAMP WITH TIME ZONE NOT NULL,
+    reminders       INTEGER                  NOT NULL DEFAULT 1,
+    reminded_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (pull_request_id, reviewer_id, assigned_at),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (reviewer_id) REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS reviewer_reassignments;
+DROP INDEX IF EXISTS idx_pull_requests_merged_at;
+ALTER TABLE pull_requests
+    DROP COLUMN IF EXISTS first_reviewed_at;
@@ -0,0 +1,28 @@
+ALTER TABLE pull_requests
+    ADD COLUMN IF NOT EXISTS first_reviewed_at TIMESTAMP WITH TIME ZONE;
+
+UPDATE pull_requests pr
+SET first_reviewed_at = (SELECT MIN(prr.decided_at)
+                         FROM pull_request_reviewers prr
+                         WHERE prr.pull_request_id = pr.id)
+WHERE pr.first_reviewed_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_pull_requests_merged_at ON pull_requests (merged_at);
+
+CREATE TABLE IF NOT EXISTS reviewer_reassignments
+(
+    id              BIGSERIAL PRIMARY KEY,
+    pull_request_id BIGINT                   NOT NULL,
+    old_reviewer_id BIGINT                   NOT NULL,
+    new_reviewer_id BIGINT                   NOT NULL,
+    reassigned_at   TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    FOREIGN KEY (pull_request_id) REFERENCES pull_requests (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (old_reviewer_id) REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE,
+    FOREIGN KEY (new_reviewer_id) REFERENCES users (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_reviewer_reassignments_pr_id ON reviewer_reassignments (pull_request_id);
+CREATE INDEX IF NOT EXISTS idx_reviewer_reassignments_reassigned_at ON reviewer_reassignments (reassigned_at);
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS idx_outbox_processed_at;
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (available_at, id) WHERE processed_at IS NULL;
+DROP TABLE IF EXISTS outbox_deliveries;
+ALTER TABLE outbox
+    DROP COLUMN IF EXISTS failed_at;
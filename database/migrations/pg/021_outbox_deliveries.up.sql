@@ -0,0 +1,17 @@
+ALTER TABLE outbox
+    ADD COLUMN IF NOT EXISTS failed_at TIMESTAMP WITH TIME ZONE;
+
+CREATE TABLE IF NOT EXISTS outbox_deliveries
+(
+    message_id   BIGINT                   NOT NULL,
+    sink         VARCHAR(64)              NOT NULL,
+    delivered_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (message_id, sink),
+    FOREIGN KEY (message_id) REFERENCES outbox (id)
+        ON DELETE CASCADE ON UPDATE CASCADE
+);
+
+DROP INDEX IF EXISTS idx_outbox_pending;
+CREATE INDEX IF NOT EXISTS idx_outbox_pending ON outbox (available_at, id)
+    WHERE processed_at IS NULL AND failed_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_processed_at ON outbox (processed_at) WHERE processed_at IS NOT NULL;
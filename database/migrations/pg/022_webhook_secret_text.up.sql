@@ -0,0 +1,2 @@
+ALTER TABLE webhook_subscriptions
+    ALTER COLUMN secret TYPE TEXT;
@@ -17,30 +17,168 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+// Defines values for ChatProvider.
+const (
+	ChatProviderMattermost ChatProvider = "mattermost"
+	ChatProviderSlack      ChatProvider = "slack"
 )
 
 // Defines values for ErrorResponseErrorCode.
 const (
-	NOCANDIDATE ErrorResponseErrorCode = "NO_CANDIDATE"
-	NOTASSIGNED ErrorResponseErrorCode = "NOT_ASSIGNED"
-	NOTFOUND    ErrorResponseErrorCode = "NOT_FOUND"
-	PREXISTS    ErrorResponseErrorCode = "PR_EXISTS"
-	PRMERGED    ErrorResponseErrorCode = "PR_MERGED"
-	TEAMEXISTS  ErrorResponseErrorCode = "TEAM_EXISTS"
+	EMAILNOTCONFIGURED  ErrorResponseErrorCode = "EMAIL_NOT_CONFIGURED"
+	FORCEDBYREQUIRED    ErrorResponseErrorCode = "FORCED_BY_REQUIRED"
+	IDENTITYTAKEN       ErrorResponseErrorCode = "IDENTITY_TAKEN"
+	INVALIDCALENDAR     ErrorResponseErrorCode = "INVALID_CALENDAR"
+	INVALIDCHATCHANNEL  ErrorResponseErrorCode = "INVALID_CHAT_CHANNEL"
+	INVALIDDECISION     ErrorResponseErrorCode = "INVALID_DECISION"
+	INVALIDIDENTITY     ErrorResponseErrorCode = "INVALID_IDENTITY"
+	INVALIDPAYLOAD      ErrorResponseErrorCode = "INVALID_PAYLOAD"
+	INVALIDPERIOD       ErrorResponseErrorCode = "INVALID_PERIOD"
+	INVALIDSETTINGS     ErrorResponseErrorCode = "INVALID_SETTINGS"
+	INVALIDSIGNATURE    ErrorResponseErrorCode = "INVALID_SIGNATURE"
+	INVALIDSLA          ErrorResponseErrorCode = "INVALID_SLA"
+	INVALIDSUBSCRIPTION ErrorResponseErrorCode = "INVALID_SUBSCRIPTION"
+	INVALIDTRANSITION   ErrorResponseErrorCode = "INVALID_TRANSITION"
+	MERGEBLOCKED        ErrorResponseErrorCode = "MERGE_BLOCKED"
+	NOCANDIDATE         ErrorResponseErrorCode = "NO_CANDIDATE"
+	NOTACCEPTABLE       ErrorResponseErrorCode = "NOT_ACCEPTABLE"
+	NOTASSIGNED         ErrorResponseErrorCode = "NOT_ASSIGNED"
+	NOTENOUGHREVIEWERS  ErrorResponseErrorCode = "NOT_ENOUGH_REVIEWERS"
+	NOTFOUND            ErrorResponseErrorCode = "NOT_FOUND"
+	NOTTEAMMEMBER       ErrorResponseErrorCode = "NOT_TEAM_MEMBER"
+	PREXISTS            ErrorResponseErrorCode = "PR_EXISTS"
+	PRMERGED            ErrorResponseErrorCode = "PR_MERGED"
+	TEAMAMBIGUOUS       ErrorResponseErrorCode = "TEAM_AMBIGUOUS"
+	TEAMEXISTS          ErrorResponseErrorCode = "TEAM_EXISTS"
+	USERNOTINTEAM       ErrorResponseErrorCode = "USER_NOT_IN_TEAM"
+)
+
+// Defines values for EventType.
+const (
+	PullRequestClosed             EventType = "pull_request.closed"
+	PullRequestConvertedToDraft   EventType = "pull_request.converted_to_draft"
+	PullRequestCreated            EventType = "pull_request.created"
+	PullRequestMerged             EventType = "pull_request.merged"
+	PullRequestReady              EventType = "pull_request.ready"
+	PullRequestRenamed            EventType = "pull_request.renamed"
+	PullRequestReopened           EventType = "pull_request.reopened"
+	PullRequestReviewOverdue      EventType = "pull_request.review_overdue"
+	PullRequestReviewReminder     EventType = "pull_request.review_reminder"
+	PullRequestReviewerReassigned EventType = "pull_request.reviewer_reassigned"
+	PullRequestReviewersAssigned  EventType = "pull_request.reviewers_assigned"
+	TeamCreated                   EventType = "team.created"
+	TeamMembersDeactivated        EventType = "team.members_deactivated"
+	TeamSettingsUpdated           EventType = "team.settings_updated"
+)
+
+// Defines values for IdentityProvider.
+const (
+	IdentityProviderEmail      IdentityProvider = "email"
+	IdentityProviderGithub     IdentityProvider = "github"
+	IdentityProviderGitlab     IdentityProvider = "gitlab"
+	IdentityProviderMattermost IdentityProvider = "mattermost"
+	IdentityProviderSlack      IdentityProvider = "slack"
+)
+
+// Defines values for MergeabilityStatus.
+const (
+	MergeabilityStatusCLOSED MergeabilityStatus = "CLOSED"
+	MergeabilityStatusDRAFT  MergeabilityStatus = "DRAFT"
+	MergeabilityStatusMERGED MergeabilityStatus = "MERGED"
+	MergeabilityStatusOPEN   MergeabilityStatus = "OPEN"
+)
+
+// Defines values for OverdueReviewSeverity.
+const (
+	Critical OverdueReviewSeverity = "critical"
+	Major    OverdueReviewSeverity = "major"
+	Minor    OverdueReviewSeverity = "minor"
 )
 
 // Defines values for PullRequestStatus.
 const (
+	PullRequestStatusCLOSED PullRequestStatus = "CLOSED"
+	PullRequestStatusDRAFT  PullRequestStatus = "DRAFT"
 	PullRequestStatusMERGED PullRequestStatus = "MERGED"
 	PullRequestStatusOPEN   PullRequestStatus = "OPEN"
 )
 
 // Defines values for PullRequestShortStatus.
 const (
+	PullRequestShortStatusCLOSED PullRequestShortStatus = "CLOSED"
+	PullRequestShortStatusDRAFT  PullRequestShortStatus = "DRAFT"
 	PullRequestShortStatusMERGED PullRequestShortStatus = "MERGED"
 	PullRequestShortStatusOPEN   PullRequestShortStatus = "OPEN"
 )
 
+// Defines values for ReviewDecision.
+const (
+	APPROVED         ReviewDecision = "APPROVED"
+	CHANGESREQUESTED ReviewDecision = "CHANGES_REQUESTED"
+	COMMENTED        ReviewDecision = "COMMENTED"
+)
+
+// Defines values for ReviewerStrategy.
+const (
+	LeastLoaded ReviewerStrategy = "least_loaded"
+	Random      ReviewerStrategy = "random"
+	RoundRobin  ReviewerStrategy = "round_robin"
+	Weighted    ReviewerStrategy = "weighted"
+)
+
+// Defines values for UnavailabilitySource.
+const (
+	Ics    UnavailabilitySource = "ics"
+	Manual UnavailabilitySource = "manual"
+)
+
+// Defines values for WebhookDeliveryStatus.
+const (
+	Delivered WebhookDeliveryStatus = "delivered"
+	Failed    WebhookDeliveryStatus = "failed"
+	Pending   WebhookDeliveryStatus = "pending"
+)
+
+// Defines values for WebhookResultOutcome.
+const (
+	Ignored   WebhookResultOutcome = "ignored"
+	Processed WebhookResultOutcome = "processed"
+)
+
+// CalendarImportResult defines model for CalendarImportResult.
+type CalendarImportResult struct {
+	// Imported Число импортированных или обновлённых периодов
+	Imported int `json:"imported"`
+
+	// Removed Число ранее импортированных периодов, которых больше нет в календаре
+	Removed int    `json:"removed"`
+	UserId  string `json:"user_id"`
+}
+
+// ChatProvider Чат, формат входящего вебхука которого используется
+type ChatProvider string
+
+// DailyThroughput defines model for DailyThroughput.
+type DailyThroughput struct {
+	// Date День по UTC
+	Date   openapi_types.Date `json:"date"`
+	Merged int                `json:"merged"`
+	Opened int                `json:"opened"`
+}
+
+// DeactivationReport defines model for DeactivationReport.
+type DeactivationReport struct {
+	DeactivatedUsers []string              `json:"deactivated_users"`
+	Reassigned       []ReviewerReplacement `json:"reassigned"`
+	TeamName         string                `json:"team_name"`
+
+	// Unassigned Открытые ревью, которые не удалось переназначить
+	Unassigned []UnassignedReview `json:"unassigned"`
+}
+
 // ErrorResponse defines model for ErrorResponse.
 type ErrorResponse struct {
 	Error struct {
@@ -52,24 +190,152 @@ type ErrorResponse struct {
 // ErrorResponseErrorCode defines model for ErrorResponse.Error.Code.
 type ErrorResponseErrorCode string
 
+// EventType Тип доменного события
+type EventType string
+
+// FallbackReviewer defines model for FallbackReviewer.
+type FallbackReviewer struct {
+	// TeamName Резервная команда, из которой назначен ревьювер
+	TeamName string `json:"team_name"`
+	UserId   string `json:"user_id"`
+}
+
+// IdentityProvider Внешняя система, которой принадлежит учётная запись
+type IdentityProvider string
+
+// LatencyStats defines model for LatencyStats.
+type LatencyStats struct {
+	// Count Сколько PR учтено
+	Count int `json:"count"`
+
+	// P50Hours Медиана в часах; нет, если count равен 0
+	P50Hours *float64 `json:"p50_hours,omitempty"`
+
+	// P90Hours 90-й перцентиль в часах
+	P90Hours *float64 `json:"p90_hours,omitempty"`
+
+	// P99Hours 99-й перцентиль в часах
+	P99Hours *float64 `json:"p99_hours,omitempty"`
+}
+
+// MergeOverride defines model for MergeOverride.
+type MergeOverride struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ForcedBy Пользователь, выполнивший принудительное слияние
+	ForcedBy *string `json:"forced_by,omitempty"`
+	Reason   string  `json:"reason"`
+
+	// UnmetConditions Условия, не выполненные на момент слияния
+	UnmetConditions []string `json:"unmet_conditions"`
+}
+
+// Mergeability defines model for Mergeability.
+type Mergeability struct {
+	Approvals int `json:"approvals"`
+
+	// ChangesRequestedBy Ревьюверы, запросившие изменения
+	ChangesRequestedBy []string `json:"changes_requested_by"`
+
+	// Mergeable PR можно слить без принудительного слияния
+	Mergeable bool `json:"mergeable"`
+
+	// Overrides Принудительные слияния PR
+	Overrides         []MergeOverride    `json:"overrides"`
+	PullRequestId     string             `json:"pull_request_id"`
+	RequiredApprovals int                `json:"required_approvals"`
+	Status            MergeabilityStatus `json:"status"`
+
+	// UnmetConditions Невыполненные условия слияния
+	UnmetConditions []string `json:"unmet_conditions"`
+}
+
+// MergeabilityStatus defines model for Mergeability.Status.
+type MergeabilityStatus string
+
+// NotificationPreferences defines model for NotificationPreferences.
+type NotificationPreferences struct {
+	// ChatEnabled Упоминать пользователя в уведомлениях в чате команды
+	ChatEnabled bool `json:"chat_enabled"`
+
+	// EmailEnabled Отправлять пользователю письма о назначениях и ежедневную сводку
+	EmailEnabled bool   `json:"email_enabled"`
+	UserId       string `json:"user_id"`
+}
+
+// OverdueReview defines model for OverdueReview.
+type OverdueReview struct {
+	// AgeHours Сколько часов прошло с назначения
+	AgeHours        float64   `json:"age_hours"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	ReviewerId      string    `json:"reviewer_id"`
+
+	// Severity Степень просрочки — minor после срока, major после двух сроков, critical после трёх
+	Severity OverdueReviewSeverity `json:"severity"`
+
+	// SlaHours Срок ревью команды в рабочих часах
+	SlaHours float64 `json:"sla_hours"`
+	TeamName string  `json:"team_name"`
+
+	// WorkingHours Сколько рабочих часов прошло с назначения
+	WorkingHours float64 `json:"working_hours"`
+}
+
+// OverdueReviewSeverity Степень просрочки — minor после срока, major после двух сроков, critical после трёх
+type OverdueReviewSeverity string
+
 // PullRequest defines model for PullRequest.
 type PullRequest struct {
-	// AssignedReviewers user_id назначенных ревьюверов (0..2)
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	// AssignedReviewers user_id назначенных ревьюверов (от min_reviewers до max_reviewers команды автора)
+	AssignedReviewers []string   `json:"assigned_reviewers"`
+	AuthorId          string     `json:"author_id"`
+	ClosedAt          *time.Time `json:"closedAt"`
+	CreatedAt         *time.Time `json:"createdAt"`
+
+	// FallbackReviewers Ревьюверы, назначенные из резервных команд
+	FallbackReviewers *[]FallbackReviewer `json:"fallback_reviewers,omitempty"`
+	MergedAt          *time.Time          `json:"mergedAt"`
+	PullRequestId     string              `json:"pull_request_id"`
+	PullRequestName   string              `json:"pull_request_name"`
+
+	// Reviews Решения назначенных ревьюверов
+	Reviews *[]Review         `json:"reviews,omitempty"`
+	Status  PullRequestStatus `json:"status"`
+
+	// TeamName Команда, из которой назначаются ревьюверы; отсутствует, если команда не определена
+	TeamName *string `json:"team_name,omitempty"`
 }
 
 // PullRequestStatus defines model for PullRequest.Status.
 type PullRequestStatus string
 
+// PullRequestExport Строка выгрузки PR; в CSV список ревьюверов разделён пробелами
+type PullRequestExport struct {
+	AuthorId        string     `json:"author_id"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+	CreatedAt       time.Time  `json:"created_at"`
+	FirstReviewedAt *time.Time `json:"first_reviewed_at,omitempty"`
+	MergedAt        *time.Time `json:"merged_at,omitempty"`
+	PullRequestId   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+
+	// Reassignments Сколько раз ревьюверы PR переназначались
+	Reassignments int `json:"reassignments"`
+
+	// Reviewers Текущие ревьюверы
+	Reviewers []string `json:"reviewers"`
+	Status    string   `json:"status"`
+	TeamName  *string  `json:"team_name,omitempty"`
+}
+
 // PullRequestShort defines model for PullRequestShort.
 type PullRequestShort struct {
-	AuthorId        string                 `json:"author_id"`
+	AuthorId string `json:"author_id"`
+
+	// AwaitingAction PR открыт, а пользователь ещё не одобрил его и не запросил изменения
+	AwaitingAction  bool                   `json:"awaiting_action"`
 	PullRequestId   string                 `json:"pull_request_id"`
 	PullRequestName string                 `json:"pull_request_name"`
 	Status          PullRequestShortStatus `json:"status"`
@@ -78,6 +344,70 @@ type PullRequestShort struct {
 // PullRequestShortStatus defines model for PullRequestShort.Status.
 type PullRequestShortStatus string
 
+// ReassignmentStats defines model for ReassignmentStats.
+type ReassignmentStats struct {
+	// PullRequests Сколько PR затронуто переназначениями
+	PullRequests int `json:"pull_requests"`
+
+	// Total Сколько раз ревью переназначались за период
+	Total int `json:"total"`
+}
+
+// Review defines model for Review.
+type Review struct {
+	AssignedAt *time.Time `json:"assigned_at"`
+	DecidedAt  *time.Time `json:"decided_at"`
+
+	// Decision Решение ревьювера
+	Decision *ReviewDecision `json:"decision,omitempty"`
+	UserId   string          `json:"user_id"`
+}
+
+// ReviewAssignmentExport Строка выгрузки назначений ревью. У заменённого ревьювера есть reassigned_at и replaced_by, а время назначения и решение не сохраняются.
+type ReviewAssignmentExport struct {
+	AssignedAt    *time.Time `json:"assigned_at,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	Decision      *string    `json:"decision,omitempty"`
+	PullRequestId string     `json:"pull_request_id"`
+	ReassignedAt  *time.Time `json:"reassigned_at,omitempty"`
+
+	// ReplacedBy Ревьювер, назначенный вместо этого
+	ReplacedBy *string `json:"replaced_by,omitempty"`
+	ReviewerId string  `json:"reviewer_id"`
+
+	// Status Статус PR
+	Status   string  `json:"status"`
+	TeamName *string `json:"team_name,omitempty"`
+}
+
+// ReviewDecision Решение ревьювера
+type ReviewDecision string
+
+// ReviewerLoad defines model for ReviewerLoad.
+type ReviewerLoad struct {
+	// AssignedReviews Все ревью PR команды, назначенные ревьюверу
+	AssignedReviews int `json:"assigned_reviews"`
+
+	// AverageOpenReviewAgeHours Средний возраст ожидающих ревью в часах; нет, если их нет
+	AverageOpenReviewAgeHours *float64 `json:"average_open_review_age_hours,omitempty"`
+	IsActive                  bool     `json:"is_active"`
+
+	// MergedReviews Ревью слитых PR
+	MergedReviews int `json:"merged_reviews"`
+
+	// OpenReviews Ревью открытых PR, ещё ожидающие решения
+	OpenReviews int    `json:"open_reviews"`
+	ReviewerId  string `json:"reviewer_id"`
+	Username    string `json:"username"`
+}
+
+// ReviewerReplacement defines model for ReviewerReplacement.
+type ReviewerReplacement struct {
+	NewUserId     string `json:"new_user_id"`
+	OldUserId     string `json:"old_user_id"`
+	PullRequestId string `json:"pull_request_id"`
+}
+
 // ReviewerStats defines model for ReviewerStats.
 type ReviewerStats struct {
 	AssignedCount int    `json:"assigned_count"`
@@ -85,12 +415,52 @@ type ReviewerStats struct {
 	Username      string `json:"username"`
 }
 
+// ReviewerStrategy Стратегия выбора ревьюверов
+type ReviewerStrategy string
+
+// ReviewerTeamLoad defines model for ReviewerTeamLoad.
+type ReviewerTeamLoad struct {
+	AssignedReviews int `json:"assigned_reviews"`
+
+	// AssignmentShare Доля ревью команды, назначенных ревьюверу
+	AssignmentShare           float64  `json:"assignment_share"`
+	AverageOpenReviewAgeHours *float64 `json:"average_open_review_age_hours,omitempty"`
+	MergedReviews             int      `json:"merged_reviews"`
+	OpenReviews               int      `json:"open_reviews"`
+	TeamName                  string   `json:"team_name"`
+}
+
+// ReviewerWorkload defines model for ReviewerWorkload.
+type ReviewerWorkload struct {
+	AssignedReviews int                `json:"assigned_reviews"`
+	MergedReviews   int                `json:"merged_reviews"`
+	OpenReviews     int                `json:"open_reviews"`
+	ReviewerId      string             `json:"reviewer_id"`
+	Teams           []ReviewerTeamLoad `json:"teams"`
+	Username        string             `json:"username"`
+}
+
 // StatsResponse defines model for StatsResponse.
 type StatsResponse struct {
-	MergedPullRequests int             `json:"merged_pull_requests"`
-	OpenPullRequests   int             `json:"open_pull_requests"`
-	ReviewerStats      []ReviewerStats `json:"reviewer_stats"`
-	TotalPullRequests  int             `json:"total_pull_requests"`
+	ClosedPullRequests int               `json:"closed_pull_requests"`
+	DraftPullRequests  int               `json:"draft_pull_requests"`
+	From               *time.Time        `json:"from,omitempty"`
+	MergedPullRequests int               `json:"merged_pull_requests"`
+	OpenPullRequests   int               `json:"open_pull_requests"`
+	Reassignments      ReassignmentStats `json:"reassignments"`
+
+	// ReviewerStats Ревью, назначенные за период
+	ReviewerStats []ReviewerStats `json:"reviewer_stats"`
+	TeamName      *string         `json:"team_name,omitempty"`
+
+	// Throughput Открытые и слитые PR по дням периода
+	Throughput        []DailyThroughput `json:"throughput"`
+	TimeToFirstReview LatencyStats      `json:"time_to_first_review"`
+	TimeToMerge       LatencyStats      `json:"time_to_merge"`
+	To                *time.Time        `json:"to,omitempty"`
+
+	// TotalPullRequests PR, созданные за период
+	TotalPullRequests int `json:"total_pull_requests"`
 }
 
 // Team defines model for Team.
@@ -99,6 +469,17 @@ type Team struct {
 	TeamName string       `json:"team_name"`
 }
 
+// TeamChatChannel defines model for TeamChatChannel.
+type TeamChatChannel struct {
+	// Channel Канал, в который публикуются уведомления; пусто — канал, заданный в самом вебхуке
+	Channel string `json:"channel"`
+
+	// Provider Чат, формат входящего вебхука которого используется
+	Provider  ChatProvider `json:"provider"`
+	TeamName  string       `json:"team_name"`
+	UpdatedAt time.Time    `json:"updated_at"`
+}
+
 // TeamMember defines model for TeamMember.
 type TeamMember struct {
 	IsActive bool   `json:"is_active"`
@@ -106,29 +487,212 @@ type TeamMember struct {
 	Username string `json:"username"`
 }
 
+// TeamReviewSLA defines model for TeamReviewSLA.
+type TeamReviewSLA struct {
+	// AutoReassign Переназначать просроченные ревью на другого кандидата
+	AutoReassign bool `json:"auto_reassign"`
+
+	// FirstDecisionHours За сколько рабочих часов ревьювер должен принять первое решение
+	FirstDecisionHours float64 `json:"first_decision_hours"`
+	TeamName           string  `json:"team_name"`
+
+	// Timezone Часовой пояс рабочих часов из базы IANA
+	Timezone  string    `json:"timezone"`
+	UpdatedAt time.Time `json:"updated_at"`
+
+	// WorkDays Рабочие дни недели по ISO 8601, 1 — понедельник
+	WorkDays []int `json:"work_days"`
+
+	// WorkEnd Конец рабочего дня, HH:MM
+	WorkEnd string `json:"work_end"`
+
+	// WorkStart Начало рабочего дня, HH:MM
+	WorkStart string `json:"work_start"`
+}
+
+// TeamSettings defines model for TeamSettings.
+type TeamSettings struct {
+	// BackupTeams Резервные команды в порядке приоритета, из которых назначаются ревьюверы, если в команде автора не хватает кандидатов
+	BackupTeams []string `json:"backup_teams"`
+
+	// MaxReviewers Максимальное число ревьюверов на PR
+	MaxReviewers int `json:"max_reviewers"`
+
+	// MinReviewers Минимальное число ревьюверов на PR
+	MinReviewers int `json:"min_reviewers"`
+
+	// RequiredApprovals Число одобрений, необходимое для слияния PR
+	RequiredApprovals int `json:"required_approvals"`
+
+	// ReviewerStrategy Стратегия выбора ревьюверов
+	ReviewerStrategy ReviewerStrategy `json:"reviewer_strategy"`
+	TeamName         string           `json:"team_name"`
+}
+
+// TeamWorkload defines model for TeamWorkload.
+type TeamWorkload struct {
+	// AssignmentsGini Коэффициент Джини назначенных ревью среди активных ревьюверов и тех, кому ревью назначались: 0 — ревью распределены поровну, ближе к 1 — почти все достались одному
+	AssignmentsGini float64        `json:"assignments_gini"`
+	Reviewers       []ReviewerLoad `json:"reviewers"`
+	TeamName        string         `json:"team_name"`
+}
+
+// UnassignedReview defines model for UnassignedReview.
+type UnassignedReview struct {
+	PullRequestId string `json:"pull_request_id"`
+
+	// UserId Деактивированный ревьювер, для которого не нашлось замены
+	UserId string `json:"user_id"`
+}
+
+// Unavailability defines model for Unavailability.
+type Unavailability struct {
+	// EndsAt Конец периода (не включительно)
+	EndsAt time.Time `json:"ends_at"`
+	Id     string    `json:"id"`
+	Reason string    `json:"reason"`
+
+	// Source Создан вручную или импортирован из календаря
+	Source   UnavailabilitySource `json:"source"`
+	StartsAt time.Time            `json:"starts_at"`
+	UserId   string               `json:"user_id"`
+}
+
+// UnavailabilitySource Создан вручную или импортирован из календаря
+type UnavailabilitySource string
+
 // User defines model for User.
 type User struct {
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
+	IsActive bool `json:"is_active"`
+
+	// Teams Имена всех команд пользователя
+	Teams    []string `json:"teams"`
+	UserId   string   `json:"user_id"`
+	Username string   `json:"username"`
+}
+
+// UserIdentity defines model for UserIdentity.
+type UserIdentity struct {
+	CreatedAt time.Time `json:"created_at"`
+
+	// ExternalId Логин GitHub/GitLab, ID пользователя в чате или email
+	ExternalId string `json:"external_id"`
+
+	// Provider Внешняя система, которой принадлежит учётная запись
+	Provider IdentityProvider `json:"provider"`
+	UserId   string           `json:"user_id"`
+}
+
+// WebhookDelivery defines model for WebhookDelivery.
+type WebhookDelivery struct {
+	Attempts    int        `json:"attempts"`
+	CreatedAt   time.Time  `json:"created_at"`
+	DeliveredAt *time.Time `json:"delivered_at,omitempty"`
+
+	// Event Тип доменного события
+	Event EventType `json:"event"`
+
+	// Id Идентификатор доставки, передаётся в заголовке X-Inator-Delivery
+	Id string `json:"id"`
+
+	// LastError Ошибка последней неудачной попытки
+	LastError *string `json:"last_error,omitempty"`
+
+	// ResponseStatus HTTP-статус последнего ответа подписчика
+	ResponseStatus *int `json:"response_status,omitempty"`
+
+	// Status pending — доставка ещё повторяется, failed — попытки исчерпаны
+	Status         WebhookDeliveryStatus `json:"status"`
+	SubscriptionId string                `json:"subscription_id"`
+	UpdatedAt      time.Time             `json:"updated_at"`
+}
+
+// WebhookDeliveryStatus pending — доставка ещё повторяется, failed — попытки исчерпаны
+type WebhookDeliveryStatus string
+
+// WebhookResult defines model for WebhookResult.
+type WebhookResult struct {
+	// Action Действие из события
+	Action *string `json:"action,omitempty"`
+
+	// Event Тип события провайдера
+	Event string `json:"event"`
+
+	// Outcome Событие применено к PR или пропущено
+	Outcome WebhookResultOutcome `json:"outcome"`
+
+	// PullRequestId Идентификатор PR, соответствующего внешнему PR
+	PullRequestId *string `json:"pull_request_id,omitempty"`
+
+	// Reason Причина, по которой событие пропущено
+	Reason *string `json:"reason,omitempty"`
+}
+
+// WebhookResultOutcome Событие применено к PR или пропущено
+type WebhookResultOutcome string
+
+// WebhookSubscription defines model for WebhookSubscription.
+type WebhookSubscription struct {
+	CreatedAt time.Time   `json:"created_at"`
+	Events    []EventType `json:"events"`
+	Id        string      `json:"id"`
+	Url       string      `json:"url"`
 }
 
+// PullRequestIdQuery defines model for PullRequestIdQuery.
+type PullRequestIdQuery = string
+
 // TeamNameQuery defines model for TeamNameQuery.
 type TeamNameQuery = string
 
 // UserIdQuery defines model for UserIdQuery.
 type UserIdQuery = string
 
+// PostPullRequestCloseJSONBody defines parameters for PostPullRequestClose.
+type PostPullRequestCloseJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
+}
+
 // PostPullRequestCreateJSONBody defines parameters for PostPullRequestCreate.
 type PostPullRequestCreateJSONBody struct {
-	AuthorId        string `json:"author_id"`
+	AuthorId string `json:"author_id"`
+
+	// Draft Создать PR в статусе DRAFT, ревьюверы назначаются при переводе в OPEN
+	Draft           *bool  `json:"draft,omitempty"`
 	PullRequestId   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
+
+	// TeamName Команда, из которой назначаются ревьюверы. Обязательна, если автор состоит в нескольких командах
+	TeamName *string `json:"team_name,omitempty"`
 }
 
 // PostPullRequestMergeJSONBody defines parameters for PostPullRequestMerge.
 type PostPullRequestMergeJSONBody struct {
+	// Force Слить PR, даже если условия слияния не выполнены. Слияние записывается в журнал
+	Force *bool `json:"force,omitempty"`
+
+	// ForcedBy Пользователь, выполняющий принудительное слияние. Обязателен при force
+	ForcedBy      *string `json:"forced_by,omitempty"`
+	PullRequestId string  `json:"pull_request_id"`
+
+	// Reason Причина принудительного слияния
+	Reason *string `json:"reason,omitempty"`
+}
+
+// GetPullRequestMergeabilityParams defines parameters for GetPullRequestMergeability.
+type GetPullRequestMergeabilityParams struct {
+	// PullRequestId Идентификатор PR
+	PullRequestId PullRequestIdQuery `form:"pull_request_id" json:"pull_request_id"`
+}
+
+// GetPullRequestOverdueParams defines parameters for GetPullRequestOverdue.
+type GetPullRequestOverdueParams struct {
+	// TeamName Только ревью этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+}
+
+// PostPullRequestReadyJSONBody defines parameters for PostPullRequestReady.
+type PostPullRequestReadyJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
@@ -138,117 +702,742 @@ type PostPullRequestReassignJSONBody struct {
 	PullRequestId string `json:"pull_request_id"`
 }
 
-// GetTeamGetParams defines parameters for GetTeamGet.
-type GetTeamGetParams struct {
-	// TeamName Уникальное имя команды
-	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+// PostPullRequestReopenJSONBody defines parameters for PostPullRequestReopen.
+type PostPullRequestReopenJSONBody struct {
+	PullRequestId string `json:"pull_request_id"`
 }
 
-// GetUsersGetReviewParams defines parameters for GetUsersGetReview.
-type GetUsersGetReviewParams struct {
-	// UserId Идентификатор пользователя
-	UserId UserIdQuery `form:"user_id" json:"user_id"`
+// PostPullRequestReviewJSONBody defines parameters for PostPullRequestReview.
+type PostPullRequestReviewJSONBody struct {
+	// Decision Решение ревьювера
+	Decision      ReviewDecision `json:"decision"`
+	PullRequestId string         `json:"pull_request_id"`
+	UserId        string         `json:"user_id"`
 }
 
-// PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
-type PostUsersSetIsActiveJSONBody struct {
-	IsActive bool   `json:"is_active"`
-	UserId   string `json:"user_id"`
+// GetStatsParams defines parameters for GetStats.
+type GetStatsParams struct {
+	// From Начало периода включительно
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Конец периода, не включая его
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
+
+	// TeamName Только PR этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
 }
 
-// PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
-type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
+// GetStatsExportAssignmentsParams defines parameters for GetStatsExportAssignments.
+type GetStatsExportAssignmentsParams struct {
+	// From Начало периода включительно
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
 
-// PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
-type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
+	// To Конец периода, не включая его
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
 
-// PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
-type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
+	// TeamName Только PR этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
+}
 
-// PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
-type PostTeamAddJSONRequestBody = Team
+// GetStatsExportPullRequestsParams defines parameters for GetStatsExportPullRequests.
+type GetStatsExportPullRequestsParams struct {
+	// From Начало периода включительно
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
 
-// PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
-type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+	// To Конец периода, не включая его
+	To *time.Time `form:"to,omitempty" json:"to,omitempty"`
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Проверка доступности сервиса (Liveness Probe)
-	// (GET /health)
-	GetHealth(ctx echo.Context) error
-	// Создать PR и автоматически назначить до 2 ревьюверов из команды автора
-	// (POST /pullRequest/create)
-	PostPullRequestCreate(ctx echo.Context) error
-	// Пометить PR как MERGED (идемпотентная операция)
-	// (POST /pullRequest/merge)
-	PostPullRequestMerge(ctx echo.Context) error
-	// Переназначить конкретного ревьювера на другого из его команды
-	// (POST /pullRequest/reassign)
-	PostPullRequestReassign(ctx echo.Context) error
-	// Получить общую статистику по PR и нагрузке ревьюверов
-	// (GET /stats)
-	GetStats(ctx echo.Context) error
-	// Создать команду с участниками (создаёт/обновляет пользователей)
-	// (POST /team/add)
-	PostTeamAdd(ctx echo.Context) error
-	// Получить команду с участниками
-	// (GET /team/get)
-	GetTeamGet(ctx echo.Context, params GetTeamGetParams) error
-	// Получить PR'ы, где пользователь назначен ревьювером
-	// (GET /users/getReview)
-	GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewParams) error
-	// Установить флаг активности пользователя
-	// (POST /users/setIsActive)
-	PostUsersSetIsActive(ctx echo.Context) error
+	// TeamName Только PR этой команды
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
 }
 
-// ServerInterfaceWrapper converts echo contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler ServerInterface
+// GetStatsTeamsParams defines parameters for GetStatsTeams.
+type GetStatsTeamsParams struct {
+	// TeamName Только эта команда
+	TeamName *string `form:"team_name,omitempty" json:"team_name,omitempty"`
 }
 
-// GetHealth converts echo context to params.
-func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
-	var err error
+// PostTeamDeactivateJSONBody defines parameters for PostTeamDeactivate.
+type PostTeamDeactivateJSONBody struct {
+	TeamName string `json:"team_name"`
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetHealth(ctx)
-	return err
+	// UserIds Участники для деактивации. Если не указаны, деактивируется вся команда
+	UserIds *[]string `json:"user_ids,omitempty"`
 }
 
-// PostPullRequestCreate converts echo context to params.
-func (w *ServerInterfaceWrapper) PostPullRequestCreate(ctx echo.Context) error {
-	var err error
+// GetTeamGetParams defines parameters for GetTeamGet.
+type GetTeamGetParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostPullRequestCreate(ctx)
-	return err
+// GetTeamNotificationsParams defines parameters for GetTeamNotifications.
+type GetTeamNotificationsParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
 }
 
-// PostPullRequestMerge converts echo context to params.
-func (w *ServerInterfaceWrapper) PostPullRequestMerge(ctx echo.Context) error {
-	var err error
+// PostTeamNotificationsJSONBody defines parameters for PostTeamNotifications.
+type PostTeamNotificationsJSONBody struct {
+	// Channel Канал вместо заданного в вебхуке
+	Channel *string `json:"channel,omitempty"`
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostPullRequestMerge(ctx)
-	return err
+	// Provider Чат, формат входящего вебхука которого используется
+	Provider ChatProvider `json:"provider"`
+	TeamName string       `json:"team_name"`
+
+	// WebhookUrl URL входящего вебхука
+	WebhookUrl string `json:"webhook_url"`
 }
 
-// PostPullRequestReassign converts echo context to params.
-func (w *ServerInterfaceWrapper) PostPullRequestReassign(ctx echo.Context) error {
-	var err error
+// PostTeamNotificationsDeleteJSONBody defines parameters for PostTeamNotificationsDelete.
+type PostTeamNotificationsDeleteJSONBody struct {
+	TeamName string `json:"team_name"`
+}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostPullRequestReassign(ctx)
-	return err
+// GetTeamSettingsParams defines parameters for GetTeamSettings.
+type GetTeamSettingsParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// PostTeamSettingsJSONBody defines parameters for PostTeamSettings.
+type PostTeamSettingsJSONBody struct {
+	BackupTeams *[]string `json:"backup_teams,omitempty"`
+
+	// MaxReviewers null возвращает значение по умолчанию сервиса
+	MaxReviewers *int `json:"max_reviewers"`
+
+	// MinReviewers null возвращает значение по умолчанию сервиса
+	MinReviewers      *int `json:"min_reviewers"`
+	RequiredApprovals *int `json:"required_approvals,omitempty"`
+
+	// ReviewerStrategy Стратегия выбора ревьюверов
+	ReviewerStrategy *ReviewerStrategy `json:"reviewer_strategy,omitempty"`
+	TeamName         string            `json:"team_name"`
+}
+
+// GetTeamSlaParams defines parameters for GetTeamSla.
+type GetTeamSlaParams struct {
+	// TeamName Уникальное имя команды
+	TeamName TeamNameQuery `form:"team_name" json:"team_name"`
+}
+
+// PostTeamSlaJSONBody defines parameters for PostTeamSla.
+type PostTeamSlaJSONBody struct {
+	AutoReassign *bool `json:"auto_reassign,omitempty"`
+
+	// FirstDecisionHours Может быть дробным; не меньше секунды
+	FirstDecisionHours float64 `json:"first_decision_hours"`
+	TeamName           string  `json:"team_name"`
+	Timezone           *string `json:"timezone,omitempty"`
+	WorkDays           *[]int  `json:"work_days,omitempty"`
+	WorkEnd            *string `json:"work_end,omitempty"`
+	WorkStart          *string `json:"work_start,omitempty"`
+}
+
+// PostTeamSlaDeleteJSONBody defines parameters for PostTeamSlaDelete.
+type PostTeamSlaDeleteJSONBody struct {
+	TeamName string `json:"team_name"`
+}
+
+// GetUsersGetReviewParams defines parameters for GetUsersGetReview.
+type GetUsersGetReviewParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// GetUsersIdentitiesParams defines parameters for GetUsersIdentities.
+type GetUsersIdentitiesParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// PostUsersIdentitiesJSONBody defines parameters for PostUsersIdentities.
+type PostUsersIdentitiesJSONBody struct {
+	ExternalId string `json:"external_id"`
+
+	// Provider Внешняя система, которой принадлежит учётная запись
+	Provider IdentityProvider `json:"provider"`
+	UserId   string           `json:"user_id"`
+}
+
+// PostUsersIdentitiesDeleteJSONBody defines parameters for PostUsersIdentitiesDelete.
+type PostUsersIdentitiesDeleteJSONBody struct {
+	// Provider Внешняя система, которой принадлежит учётная запись
+	Provider IdentityProvider `json:"provider"`
+	UserId   string           `json:"user_id"`
+}
+
+// GetUsersIdentitiesLookupParams defines parameters for GetUsersIdentitiesLookup.
+type GetUsersIdentitiesLookupParams struct {
+	// Provider Внешняя система
+	Provider IdentityProvider `form:"provider" json:"provider"`
+
+	// ExternalId Идентификатор во внешней системе
+	ExternalId string `form:"external_id" json:"external_id"`
+}
+
+// GetUsersNotificationsParams defines parameters for GetUsersNotifications.
+type GetUsersNotificationsParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// PostUsersNotificationsJSONBody defines parameters for PostUsersNotifications.
+type PostUsersNotificationsJSONBody struct {
+	ChatEnabled  *bool  `json:"chat_enabled,omitempty"`
+	EmailEnabled *bool  `json:"email_enabled,omitempty"`
+	UserId       string `json:"user_id"`
+}
+
+// PostUsersNotificationsDigestJSONBody defines parameters for PostUsersNotificationsDigest.
+type PostUsersNotificationsDigestJSONBody struct {
+	UserId *string `json:"user_id,omitempty"`
+}
+
+// PostUsersSetIsActiveJSONBody defines parameters for PostUsersSetIsActive.
+type PostUsersSetIsActiveJSONBody struct {
+	IsActive bool `json:"is_active"`
+
+	// ReassignOpenReviews При деактивации переназначить открытые ревью пользователя на других кандидатов
+	ReassignOpenReviews *bool  `json:"reassign_open_reviews,omitempty"`
+	UserId              string `json:"user_id"`
+}
+
+// GetUsersUnavailabilityParams defines parameters for GetUsersUnavailability.
+type GetUsersUnavailabilityParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// PostUsersUnavailabilityJSONBody defines parameters for PostUsersUnavailability.
+type PostUsersUnavailabilityJSONBody struct {
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   *string   `json:"reason,omitempty"`
+	StartsAt time.Time `json:"starts_at"`
+	UserId   string    `json:"user_id"`
+}
+
+// PostUsersUnavailabilityDeleteJSONBody defines parameters for PostUsersUnavailabilityDelete.
+type PostUsersUnavailabilityDeleteJSONBody struct {
+	Id     string `json:"id"`
+	UserId string `json:"user_id"`
+}
+
+// PostUsersUnavailabilityImportParams defines parameters for PostUsersUnavailabilityImport.
+type PostUsersUnavailabilityImportParams struct {
+	// UserId Идентификатор пользователя
+	UserId UserIdQuery `form:"user_id" json:"user_id"`
+}
+
+// GetWebhooksDeliveriesParams defines parameters for GetWebhooksDeliveries.
+type GetWebhooksDeliveriesParams struct {
+	// SubscriptionId Идентификатор подписки
+	SubscriptionId string `form:"subscription_id" json:"subscription_id"`
+}
+
+// PostWebhooksDeliveriesRedeliverJSONBody defines parameters for PostWebhooksDeliveriesRedeliver.
+type PostWebhooksDeliveriesRedeliverJSONBody struct {
+	DeliveryId string `json:"delivery_id"`
+}
+
+// PostWebhooksGithubJSONBody defines parameters for PostWebhooksGithub.
+type PostWebhooksGithubJSONBody = map[string]interface{}
+
+// PostWebhooksGithubParams defines parameters for PostWebhooksGithub.
+type PostWebhooksGithubParams struct {
+	// XGitHubEvent Тип события
+	XGitHubEvent string `json:"X-GitHub-Event"`
+
+	// XHubSignature256 HMAC-SHA256 тела запроса в виде sha256=<hex>
+	XHubSignature256 *string `json:"X-Hub-Signature-256,omitempty"`
+}
+
+// PostWebhooksGitlabJSONBody defines parameters for PostWebhooksGitlab.
+type PostWebhooksGitlabJSONBody = map[string]interface{}
+
+// PostWebhooksGitlabParams defines parameters for PostWebhooksGitlab.
+type PostWebhooksGitlabParams struct {
+	// XGitlabEvent Тип события
+	XGitlabEvent string `json:"X-Gitlab-Event"`
+
+	// XGitlabToken Секретный токен вебхука
+	XGitlabToken *string `json:"X-Gitlab-Token,omitempty"`
+}
+
+// PostWebhooksSubscriptionsJSONBody defines parameters for PostWebhooksSubscriptions.
+type PostWebhooksSubscriptionsJSONBody struct {
+	Events *[]EventType `json:"events,omitempty"`
+	Secret string       `json:"secret"`
+	Url    string       `json:"url"`
+}
+
+// PostWebhooksSubscriptionsDeleteJSONBody defines parameters for PostWebhooksSubscriptionsDelete.
+type PostWebhooksSubscriptionsDeleteJSONBody struct {
+	SubscriptionId string `json:"subscription_id"`
+}
+
+// PostPullRequestCloseJSONRequestBody defines body for PostPullRequestClose for application/json ContentType.
+type PostPullRequestCloseJSONRequestBody PostPullRequestCloseJSONBody
+
+// PostPullRequestCreateJSONRequestBody defines body for PostPullRequestCreate for application/json ContentType.
+type PostPullRequestCreateJSONRequestBody PostPullRequestCreateJSONBody
+
+// PostPullRequestMergeJSONRequestBody defines body for PostPullRequestMerge for application/json ContentType.
+type PostPullRequestMergeJSONRequestBody PostPullRequestMergeJSONBody
+
+// PostPullRequestReadyJSONRequestBody defines body for PostPullRequestReady for application/json ContentType.
+type PostPullRequestReadyJSONRequestBody PostPullRequestReadyJSONBody
+
+// PostPullRequestReassignJSONRequestBody defines body for PostPullRequestReassign for application/json ContentType.
+type PostPullRequestReassignJSONRequestBody PostPullRequestReassignJSONBody
+
+// PostPullRequestReopenJSONRequestBody defines body for PostPullRequestReopen for application/json ContentType.
+type PostPullRequestReopenJSONRequestBody PostPullRequestReopenJSONBody
+
+// PostPullRequestReviewJSONRequestBody defines body for PostPullRequestReview for application/json ContentType.
+type PostPullRequestReviewJSONRequestBody PostPullRequestReviewJSONBody
+
+// PostTeamAddJSONRequestBody defines body for PostTeamAdd for application/json ContentType.
+type PostTeamAddJSONRequestBody = Team
+
+// PostTeamDeactivateJSONRequestBody defines body for PostTeamDeactivate for application/json ContentType.
+type PostTeamDeactivateJSONRequestBody PostTeamDeactivateJSONBody
+
+// PostTeamNotificationsJSONRequestBody defines body for PostTeamNotifications for application/json ContentType.
+type PostTeamNotificationsJSONRequestBody PostTeamNotificationsJSONBody
+
+// PostTeamNotificationsDeleteJSONRequestBody defines body for PostTeamNotificationsDelete for application/json ContentType.
+type PostTeamNotificationsDeleteJSONRequestBody PostTeamNotificationsDeleteJSONBody
+
+// PostTeamSettingsJSONRequestBody defines body for PostTeamSettings for application/json ContentType.
+type PostTeamSettingsJSONRequestBody PostTeamSettingsJSONBody
+
+// PostTeamSlaJSONRequestBody defines body for PostTeamSla for application/json ContentType.
+type PostTeamSlaJSONRequestBody PostTeamSlaJSONBody
+
+// PostTeamSlaDeleteJSONRequestBody defines body for PostTeamSlaDelete for application/json ContentType.
+type PostTeamSlaDeleteJSONRequestBody PostTeamSlaDeleteJSONBody
+
+// PostUsersIdentitiesJSONRequestBody defines body for PostUsersIdentities for application/json ContentType.
+type PostUsersIdentitiesJSONRequestBody PostUsersIdentitiesJSONBody
+
+// PostUsersIdentitiesDeleteJSONRequestBody defines body for PostUsersIdentitiesDelete for application/json ContentType.
+type PostUsersIdentitiesDeleteJSONRequestBody PostUsersIdentitiesDeleteJSONBody
+
+// PostUsersNotificationsJSONRequestBody defines body for PostUsersNotifications for application/json ContentType.
+type PostUsersNotificationsJSONRequestBody PostUsersNotificationsJSONBody
+
+// PostUsersNotificationsDigestJSONRequestBody defines body for PostUsersNotificationsDigest for application/json ContentType.
+type PostUsersNotificationsDigestJSONRequestBody PostUsersNotificationsDigestJSONBody
+
+// PostUsersSetIsActiveJSONRequestBody defines body for PostUsersSetIsActive for application/json ContentType.
+type PostUsersSetIsActiveJSONRequestBody PostUsersSetIsActiveJSONBody
+
+// PostUsersUnavailabilityJSONRequestBody defines body for PostUsersUnavailability for application/json ContentType.
+type PostUsersUnavailabilityJSONRequestBody PostUsersUnavailabilityJSONBody
+
+// PostUsersUnavailabilityDeleteJSONRequestBody defines body for PostUsersUnavailabilityDelete for application/json ContentType.
+type PostUsersUnavailabilityDeleteJSONRequestBody PostUsersUnavailabilityDeleteJSONBody
+
+// PostWebhooksDeliveriesRedeliverJSONRequestBody defines body for PostWebhooksDeliveriesRedeliver for application/json ContentType.
+type PostWebhooksDeliveriesRedeliverJSONRequestBody PostWebhooksDeliveriesRedeliverJSONBody
+
+// PostWebhooksGithubJSONRequestBody defines body for PostWebhooksGithub for application/json ContentType.
+type PostWebhooksGithubJSONRequestBody = PostWebhooksGithubJSONBody
+
+// PostWebhooksGitlabJSONRequestBody defines body for PostWebhooksGitlab for application/json ContentType.
+type PostWebhooksGitlabJSONRequestBody = PostWebhooksGitlabJSONBody
+
+// PostWebhooksSubscriptionsJSONRequestBody defines body for PostWebhooksSubscriptions for application/json ContentType.
+type PostWebhooksSubscriptionsJSONRequestBody PostWebhooksSubscriptionsJSONBody
+
+// PostWebhooksSubscriptionsDeleteJSONRequestBody defines body for PostWebhooksSubscriptionsDelete for application/json ContentType.
+type PostWebhooksSubscriptionsDeleteJSONRequestBody PostWebhooksSubscriptionsDeleteJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Проверка доступности сервиса (Liveness Probe)
+	// (GET /health)
+	GetHealth(ctx echo.Context) error
+	// Закрыть PR без слияния (DRAFT или OPEN -> CLOSED)
+	// (POST /pullRequest/close)
+	PostPullRequestClose(ctx echo.Context) error
+	// Создать PR и автоматически назначить ревьюверов из команды автора
+	// (POST /pullRequest/create)
+	PostPullRequestCreate(ctx echo.Context) error
+	// Пометить PR как MERGED (идемпотентная операция)
+	// (POST /pullRequest/merge)
+	PostPullRequestMerge(ctx echo.Context) error
+	// Проверить, можно ли слить PR, не выполняя слияние
+	// (GET /pullRequest/mergeability)
+	GetPullRequestMergeability(ctx echo.Context, params GetPullRequestMergeabilityParams) error
+	// Просроченные ревью (сначала самые просроченные)
+	// (GET /pullRequest/overdue)
+	GetPullRequestOverdue(ctx echo.Context, params GetPullRequestOverdueParams) error
+	// Перевести DRAFT PR в OPEN и назначить ревьюверов
+	// (POST /pullRequest/ready)
+	PostPullRequestReady(ctx echo.Context) error
+	// Переназначить конкретного ревьювера на другого из его команды
+	// (POST /pullRequest/reassign)
+	PostPullRequestReassign(ctx echo.Context) error
+	// Переоткрыть закрытый PR (CLOSED -> OPEN)
+	// (POST /pullRequest/reopen)
+	PostPullRequestReopen(ctx echo.Context) error
+	// Оставить решение ревьювера по PR
+	// (POST /pullRequest/review)
+	PostPullRequestReview(ctx echo.Context) error
+	// Получить общую статистику по PR и нагрузке ревьюверов
+	// (GET /stats)
+	GetStats(ctx echo.Context, params GetStatsParams) error
+	// Выгрузить назначения ревью в CSV или NDJSON
+	// (GET /stats/export/assignments)
+	GetStatsExportAssignments(ctx echo.Context, params GetStatsExportAssignmentsParams) error
+	// Выгрузить PR в CSV или NDJSON
+	// (GET /stats/export/pullRequests)
+	GetStatsExportPullRequests(ctx echo.Context, params GetStatsExportPullRequestsParams) error
+	// Получить нагрузку ревьювера по командам
+	// (GET /stats/reviewers/{id})
+	GetStatsReviewersId(ctx echo.Context, id string) error
+	// Получить нагрузку ревьюверов по командам
+	// (GET /stats/teams)
+	GetStatsTeams(ctx echo.Context, params GetStatsTeamsParams) error
+	// Создать команду с участниками (создаёт/обновляет пользователей)
+	// (POST /team/add)
+	PostTeamAdd(ctx echo.Context) error
+	// Деактивировать участников команды и переназначить их открытые ревью
+	// (POST /team/deactivate)
+	PostTeamDeactivate(ctx echo.Context) error
+	// Получить команду с участниками
+	// (GET /team/get)
+	GetTeamGet(ctx echo.Context, params GetTeamGetParams) error
+	// Получить канал уведомлений команды в чате
+	// (GET /team/notifications)
+	GetTeamNotifications(ctx echo.Context, params GetTeamNotificationsParams) error
+	// Настроить канал уведомлений команды в чате (заменяет прежний)
+	// (POST /team/notifications)
+	PostTeamNotifications(ctx echo.Context) error
+	// Отключить уведомления команды в чате
+	// (POST /team/notifications/delete)
+	PostTeamNotificationsDelete(ctx echo.Context) error
+	// Получить настройки назначения ревьюверов команды
+	// (GET /team/settings)
+	GetTeamSettings(ctx echo.Context, params GetTeamSettingsParams) error
+	// Изменить настройки назначения ревьюверов команды
+	// (POST /team/settings)
+	PostTeamSettings(ctx echo.Context) error
+	// Получить срок ревью команды
+	// (GET /team/sla)
+	GetTeamSla(ctx echo.Context, params GetTeamSlaParams) error
+	// Задать срок ревью команды (заменяет прежний)
+	// (POST /team/sla)
+	PostTeamSla(ctx echo.Context) error
+	// Отменить срок ревью команды
+	// (POST /team/sla/delete)
+	PostTeamSlaDelete(ctx echo.Context) error
+	// Получить PR'ы, где пользователь назначен ревьювером
+	// (GET /users/getReview)
+	GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewParams) error
+	// Получить внешние учётные записи пользователя
+	// (GET /users/identities)
+	GetUsersIdentities(ctx echo.Context, params GetUsersIdentitiesParams) error
+	// Привязать внешнюю учётную запись (заменяет прежнюю для этой системы)
+	// (POST /users/identities)
+	PostUsersIdentities(ctx echo.Context) error
+	// Отвязать внешнюю учётную запись
+	// (POST /users/identities/delete)
+	PostUsersIdentitiesDelete(ctx echo.Context) error
+	// Найти пользователя по внешней учётной записи
+	// (GET /users/identities/lookup)
+	GetUsersIdentitiesLookup(ctx echo.Context, params GetUsersIdentitiesLookupParams) error
+	// Получить настройки уведомлений пользователя
+	// (GET /users/notifications)
+	GetUsersNotifications(ctx echo.Context, params GetUsersNotificationsParams) error
+	// Изменить настройки уведомлений пользователя
+	// (POST /users/notifications)
+	PostUsersNotifications(ctx echo.Context) error
+	// Отправить ежедневную сводку ревью по почте
+	// (POST /users/notifications/digest)
+	PostUsersNotificationsDigest(ctx echo.Context) error
+	// Установить флаг активности пользователя
+	// (POST /users/setIsActive)
+	PostUsersSetIsActive(ctx echo.Context) error
+	// Получить периоды недоступности пользователя
+	// (GET /users/unavailability)
+	GetUsersUnavailability(ctx echo.Context, params GetUsersUnavailabilityParams) error
+	// Добавить период недоступности (на это время пользователь не назначается ревьювером)
+	// (POST /users/unavailability)
+	PostUsersUnavailability(ctx echo.Context) error
+	// Удалить период недоступности
+	// (POST /users/unavailability/delete)
+	PostUsersUnavailabilityDelete(ctx echo.Context) error
+	// Синхронизировать периоды недоступности из экспорта календаря (ICS)
+	// (POST /users/unavailability/import)
+	PostUsersUnavailabilityImport(ctx echo.Context, params PostUsersUnavailabilityImportParams) error
+	// Журнал доставок подписки (сначала новые)
+	// (GET /webhooks/deliveries)
+	GetWebhooksDeliveries(ctx echo.Context, params GetWebhooksDeliveriesParams) error
+	// Повторно отправить доставку
+	// (POST /webhooks/deliveries/redeliver)
+	PostWebhooksDeliveriesRedeliver(ctx echo.Context) error
+	// Принять событие вебхука GitHub
+	// (POST /webhooks/github)
+	PostWebhooksGithub(ctx echo.Context, params PostWebhooksGithubParams) error
+	// Принять событие вебхука GitLab
+	// (POST /webhooks/gitlab)
+	PostWebhooksGitlab(ctx echo.Context, params PostWebhooksGitlabParams) error
+	// Получить подписки на события
+	// (GET /webhooks/subscriptions)
+	GetWebhooksSubscriptions(ctx echo.Context) error
+	// Подписать URL на события
+	// (POST /webhooks/subscriptions)
+	PostWebhooksSubscriptions(ctx echo.Context) error
+	// Удалить подписку вместе с журналом доставок
+	// (POST /webhooks/subscriptions/delete)
+	PostWebhooksSubscriptionsDelete(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// GetHealth converts echo context to params.
+func (w *ServerInterfaceWrapper) GetHealth(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetHealth(ctx)
+	return err
+}
+
+// PostPullRequestClose converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestClose(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestClose(ctx)
+	return err
+}
+
+// PostPullRequestCreate converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestCreate(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestCreate(ctx)
+	return err
+}
+
+// PostPullRequestMerge converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestMerge(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestMerge(ctx)
+	return err
+}
+
+// GetPullRequestMergeability converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPullRequestMergeability(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestMergeabilityParams
+	// ------------- Required query parameter "pull_request_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "pull_request_id", ctx.QueryParams(), &params.PullRequestId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter pull_request_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPullRequestMergeability(ctx, params)
+	return err
+}
+
+// GetPullRequestOverdue converts echo context to params.
+func (w *ServerInterfaceWrapper) GetPullRequestOverdue(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetPullRequestOverdueParams
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetPullRequestOverdue(ctx, params)
+	return err
+}
+
+// PostPullRequestReady converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestReady(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestReady(ctx)
+	return err
+}
+
+// PostPullRequestReassign converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestReassign(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestReassign(ctx)
+	return err
+}
+
+// PostPullRequestReopen converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestReopen(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestReopen(ctx)
+	return err
+}
+
+// PostPullRequestReview converts echo context to params.
+func (w *ServerInterfaceWrapper) PostPullRequestReview(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostPullRequestReview(ctx)
+	return err
 }
 
 // GetStats converts echo context to params.
 func (w *ServerInterfaceWrapper) GetStats(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetStats(ctx, params)
+	return err
+}
+
+// GetStatsExportAssignments converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStatsExportAssignments(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsExportAssignmentsParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetStatsExportAssignments(ctx, params)
+	return err
+}
+
+// GetStatsExportPullRequests converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStatsExportPullRequests(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsExportPullRequestsParams
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", ctx.QueryParams(), &params.From)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter from: %s", err))
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", ctx.QueryParams(), &params.To)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter to: %s", err))
+	}
+
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetStatsExportPullRequests(ctx, params)
+	return err
+}
+
+// GetStatsReviewersId converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStatsReviewersId(ctx echo.Context) error {
+	var err error
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetStatsReviewersId(ctx, id)
+	return err
+}
+
+// GetStatsTeams converts echo context to params.
+func (w *ServerInterfaceWrapper) GetStatsTeams(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetStatsTeamsParams
+	// ------------- Optional query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetStats(ctx)
+	err = w.Handler.GetStatsTeams(ctx, params)
 	return err
 }
 
@@ -261,6 +1450,15 @@ func (w *ServerInterfaceWrapper) PostTeamAdd(ctx echo.Context) error {
 	return err
 }
 
+// PostTeamDeactivate converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamDeactivate(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamDeactivate(ctx)
+	return err
+}
+
 // GetTeamGet converts echo context to params.
 func (w *ServerInterfaceWrapper) GetTeamGet(ctx echo.Context) error {
 	var err error
@@ -279,6 +1477,105 @@ func (w *ServerInterfaceWrapper) GetTeamGet(ctx echo.Context) error {
 	return err
 }
 
+// GetTeamNotifications converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTeamNotifications(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamNotificationsParams
+	// ------------- Required query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTeamNotifications(ctx, params)
+	return err
+}
+
+// PostTeamNotifications converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamNotifications(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamNotifications(ctx)
+	return err
+}
+
+// PostTeamNotificationsDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamNotificationsDelete(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamNotificationsDelete(ctx)
+	return err
+}
+
+// GetTeamSettings converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTeamSettings(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamSettingsParams
+	// ------------- Required query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTeamSettings(ctx, params)
+	return err
+}
+
+// PostTeamSettings converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamSettings(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamSettings(ctx)
+	return err
+}
+
+// GetTeamSla converts echo context to params.
+func (w *ServerInterfaceWrapper) GetTeamSla(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTeamSlaParams
+	// ------------- Required query parameter "team_name" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "team_name", ctx.QueryParams(), &params.TeamName)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter team_name: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetTeamSla(ctx, params)
+	return err
+}
+
+// PostTeamSla converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamSla(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamSla(ctx)
+	return err
+}
+
+// PostTeamSlaDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostTeamSlaDelete(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostTeamSlaDelete(ctx)
+	return err
+}
+
 // GetUsersGetReview converts echo context to params.
 func (w *ServerInterfaceWrapper) GetUsersGetReview(ctx echo.Context) error {
 	var err error
@@ -297,6 +1594,103 @@ func (w *ServerInterfaceWrapper) GetUsersGetReview(ctx echo.Context) error {
 	return err
 }
 
+// GetUsersIdentities converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersIdentities(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdentitiesParams
+	// ------------- Required query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersIdentities(ctx, params)
+	return err
+}
+
+// PostUsersIdentities converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersIdentities(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersIdentities(ctx)
+	return err
+}
+
+// PostUsersIdentitiesDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersIdentitiesDelete(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersIdentitiesDelete(ctx)
+	return err
+}
+
+// GetUsersIdentitiesLookup converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersIdentitiesLookup(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdentitiesLookupParams
+	// ------------- Required query parameter "provider" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "provider", ctx.QueryParams(), &params.Provider)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter provider: %s", err))
+	}
+
+	// ------------- Required query parameter "external_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "external_id", ctx.QueryParams(), &params.ExternalId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter external_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersIdentitiesLookup(ctx, params)
+	return err
+}
+
+// GetUsersNotifications converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersNotifications(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersNotificationsParams
+	// ------------- Required query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersNotifications(ctx, params)
+	return err
+}
+
+// PostUsersNotifications converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersNotifications(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersNotifications(ctx)
+	return err
+}
+
+// PostUsersNotificationsDigest converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersNotificationsDigest(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersNotificationsDigest(ctx)
+	return err
+}
+
 // PostUsersSetIsActive converts echo context to params.
 func (w *ServerInterfaceWrapper) PostUsersSetIsActive(ctx echo.Context) error {
 	var err error
@@ -306,6 +1700,206 @@ func (w *ServerInterfaceWrapper) PostUsersSetIsActive(ctx echo.Context) error {
 	return err
 }
 
+// GetUsersUnavailability converts echo context to params.
+func (w *ServerInterfaceWrapper) GetUsersUnavailability(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersUnavailabilityParams
+	// ------------- Required query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetUsersUnavailability(ctx, params)
+	return err
+}
+
+// PostUsersUnavailability converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersUnavailability(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersUnavailability(ctx)
+	return err
+}
+
+// PostUsersUnavailabilityDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersUnavailabilityDelete(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersUnavailabilityDelete(ctx)
+	return err
+}
+
+// PostUsersUnavailabilityImport converts echo context to params.
+func (w *ServerInterfaceWrapper) PostUsersUnavailabilityImport(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUsersUnavailabilityImportParams
+	// ------------- Required query parameter "user_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "user_id", ctx.QueryParams(), &params.UserId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter user_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostUsersUnavailabilityImport(ctx, params)
+	return err
+}
+
+// GetWebhooksDeliveries converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooksDeliveries(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetWebhooksDeliveriesParams
+	// ------------- Required query parameter "subscription_id" -------------
+
+	err = runtime.BindQueryParameter("form", true, true, "subscription_id", ctx.QueryParams(), &params.SubscriptionId)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter subscription_id: %s", err))
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooksDeliveries(ctx, params)
+	return err
+}
+
+// PostWebhooksDeliveriesRedeliver converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksDeliveriesRedeliver(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksDeliveriesRedeliver(ctx)
+	return err
+}
+
+// PostWebhooksGithub converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksGithub(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostWebhooksGithubParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-GitHub-Event" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-GitHub-Event")]; found {
+		var XGitHubEvent string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-GitHub-Event, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-GitHub-Event", valueList[0], &XGitHubEvent, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-GitHub-Event: %s", err))
+		}
+
+		params.XGitHubEvent = XGitHubEvent
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-GitHub-Event is required, but not found"))
+	}
+	// ------------- Optional header parameter "X-Hub-Signature-256" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Hub-Signature-256")]; found {
+		var XHubSignature256 string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Hub-Signature-256, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Hub-Signature-256", valueList[0], &XHubSignature256, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Hub-Signature-256: %s", err))
+		}
+
+		params.XHubSignature256 = &XHubSignature256
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksGithub(ctx, params)
+	return err
+}
+
+// PostWebhooksGitlab converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksGitlab(ctx echo.Context) error {
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostWebhooksGitlabParams
+
+	headers := ctx.Request().Header
+	// ------------- Required header parameter "X-Gitlab-Event" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Gitlab-Event")]; found {
+		var XGitlabEvent string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Gitlab-Event, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Gitlab-Event", valueList[0], &XGitlabEvent, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: true})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Gitlab-Event: %s", err))
+		}
+
+		params.XGitlabEvent = XGitlabEvent
+	} else {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Header parameter X-Gitlab-Event is required, but not found"))
+	}
+	// ------------- Optional header parameter "X-Gitlab-Token" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-Gitlab-Token")]; found {
+		var XGitlabToken string
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-Gitlab-Token, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-Gitlab-Token", valueList[0], &XGitlabToken, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-Gitlab-Token: %s", err))
+		}
+
+		params.XGitlabToken = &XGitlabToken
+	}
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksGitlab(ctx, params)
+	return err
+}
+
+// GetWebhooksSubscriptions converts echo context to params.
+func (w *ServerInterfaceWrapper) GetWebhooksSubscriptions(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.GetWebhooksSubscriptions(ctx)
+	return err
+}
+
+// PostWebhooksSubscriptions converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksSubscriptions(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksSubscriptions(ctx)
+	return err
+}
+
+// PostWebhooksSubscriptionsDelete converts echo context to params.
+func (w *ServerInterfaceWrapper) PostWebhooksSubscriptionsDelete(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostWebhooksSubscriptionsDelete(ctx)
+	return err
+}
+
 // This is a simple interface which specifies echo.Route addition functions which
 // are present on both echo.Echo and echo.Group, since we want to allow using
 // either of them for path registration
@@ -335,61 +1929,287 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	}
 
 	router.GET(baseURL+"/health", wrapper.GetHealth)
+	router.POST(baseURL+"/pullRequest/close", wrapper.PostPullRequestClose)
 	router.POST(baseURL+"/pullRequest/create", wrapper.PostPullRequestCreate)
 	router.POST(baseURL+"/pullRequest/merge", wrapper.PostPullRequestMerge)
+	router.GET(baseURL+"/pullRequest/mergeability", wrapper.GetPullRequestMergeability)
+	router.GET(baseURL+"/pullRequest/overdue", wrapper.GetPullRequestOverdue)
+	router.POST(baseURL+"/pullRequest/ready", wrapper.PostPullRequestReady)
 	router.POST(baseURL+"/pullRequest/reassign", wrapper.PostPullRequestReassign)
+	router.POST(baseURL+"/pullRequest/reopen", wrapper.PostPullRequestReopen)
+	router.POST(baseURL+"/pullRequest/review", wrapper.PostPullRequestReview)
 	router.GET(baseURL+"/stats", wrapper.GetStats)
+	router.GET(baseURL+"/stats/export/assignments", wrapper.GetStatsExportAssignments)
+	router.GET(baseURL+"/stats/export/pullRequests", wrapper.GetStatsExportPullRequests)
+	router.GET(baseURL+"/stats/reviewers/:id", wrapper.GetStatsReviewersId)
+	router.GET(baseURL+"/stats/teams", wrapper.GetStatsTeams)
 	router.POST(baseURL+"/team/add", wrapper.PostTeamAdd)
+	router.POST(baseURL+"/team/deactivate", wrapper.PostTeamDeactivate)
 	router.GET(baseURL+"/team/get", wrapper.GetTeamGet)
+	router.GET(baseURL+"/team/notifications", wrapper.GetTeamNotifications)
+	router.POST(baseURL+"/team/notifications", wrapper.PostTeamNotifications)
+	router.POST(baseURL+"/team/notifications/delete", wrapper.PostTeamNotificationsDelete)
+	router.GET(baseURL+"/team/settings", wrapper.GetTeamSettings)
+	router.POST(baseURL+"/team/settings", wrapper.PostTeamSettings)
+	router.GET(baseURL+"/team/sla", wrapper.GetTeamSla)
+	router.POST(baseURL+"/team/sla", wrapper.PostTeamSla)
+	router.POST(baseURL+"/team/sla/delete", wrapper.PostTeamSlaDelete)
 	router.GET(baseURL+"/users/getReview", wrapper.GetUsersGetReview)
+	router.GET(baseURL+"/users/identities", wrapper.GetUsersIdentities)
+	router.POST(baseURL+"/users/identities", wrapper.PostUsersIdentities)
+	router.POST(baseURL+"/users/identities/delete", wrapper.PostUsersIdentitiesDelete)
+	router.GET(baseURL+"/users/identities/lookup", wrapper.GetUsersIdentitiesLookup)
+	router.GET(baseURL+"/users/notifications", wrapper.GetUsersNotifications)
+	router.POST(baseURL+"/users/notifications", wrapper.PostUsersNotifications)
+	router.POST(baseURL+"/users/notifications/digest", wrapper.PostUsersNotificationsDigest)
 	router.POST(baseURL+"/users/setIsActive", wrapper.PostUsersSetIsActive)
+	router.GET(baseURL+"/users/unavailability", wrapper.GetUsersUnavailability)
+	router.POST(baseURL+"/users/unavailability", wrapper.PostUsersUnavailability)
+	router.POST(baseURL+"/users/unavailability/delete", wrapper.PostUsersUnavailabilityDelete)
+	router.POST(baseURL+"/users/unavailability/import", wrapper.PostUsersUnavailabilityImport)
+	router.GET(baseURL+"/webhooks/deliveries", wrapper.GetWebhooksDeliveries)
+	router.POST(baseURL+"/webhooks/deliveries/redeliver", wrapper.PostWebhooksDeliveriesRedeliver)
+	router.POST(baseURL+"/webhooks/github", wrapper.PostWebhooksGithub)
+	router.POST(baseURL+"/webhooks/gitlab", wrapper.PostWebhooksGitlab)
+	router.GET(baseURL+"/webhooks/subscriptions", wrapper.GetWebhooksSubscriptions)
+	router.POST(baseURL+"/webhooks/subscriptions", wrapper.PostWebhooksSubscriptions)
+	router.POST(baseURL+"/webhooks/subscriptions/delete", wrapper.PostWebhooksSubscriptionsDelete)
 
 }
 
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/9xa724bxxF/lcW2QByAFinZLlB+Y2zFFVrLLCUDRQVBWPHW0iXkHX23p0YQCFhi2jiV",
-	"ETX9VARIjCAvQMliResP9Qqzb1TM7h3vjtw7UaYsF/kinI77Z2Z25je/mb0dWnebLdfhjvBpeYe2mMea",
-	"XHBP/bfMWXORNfmfA+5t4wuL+3XPbgnbdWiZwi9wAX04hS6cyddwAQPoEejDuTwgcAoDOIcuXMCx3KcF",
-	"auOMF2qhAnVYk9MyFZw119RzgXr8RWB73KJl4QW8QP36Jm8y3FRst3CwLzzb2aDtdoE+87m3YGVJ9R84",
-	"hh5cyD3oy6+1fHIPBvIlgUsYKFFPYABH6nUPzuRBhniBz70127qWcO3oR2XAec9zvRr3W67jc3zBv2LN",
-	"VkM/4m/4UHctXGLx6fLa50+fLT6iBdrkvs828K3HfTfw6pw4riDP3cCxlAVantvinrC5n1oq/VovvEO5",
-	"EzRpeYUuz1eerM3/ZWFpeYkWaLWWen4yX3s8j3ujHJWlpYXHi+G/aw8ri48WHlWW58NftZSrhVHlE3Kb",
-	"Ti024ooWLR4fr+Wuf8HrYmy81nB8WIFWg0ajxl8E3BfjFmC+b2843Frz+JbN/xa6ddpfwlMmcAFdOMG/",
-	"8hv0H7iQ+/LvRL6EHhzJ1/I7OIKefImeQ+6UZmbmPkW3EbzpG9QdCso8j23j/ywQm65yJ9PouseZ4FZF",
-	"6fDc9ZpM0DK1mOB3ha3iwwkaDbaOrqNd0GB7b2O6FVpBo7HmaVtmCZoao+PEMMoXTAR+0veeVucXaYGG",
-	"XjbuOyPnPSqKaeOkTYdbFkxnfoXfLG26nsl5ck/s12Ask11qodWWBAtTgjmi6m7giIQ+tiP4Bveokk0v",
-	"kWUWjLgMa4xollwpMa8wKoZJEaVAEnvTiuhwWUtayjer47a4M8m4obB+ZLohOvzW489pmf6mGGfbYpgm",
-	"immDG5BDuII1rhZgxHSmWUZdCmZTjOljsjESBJNpm+sh1E5kAVzliZpjVH9IEq50lySfiITIEjvccEx4",
-	"219jdWFvJbdbd90GZ07ku9P7dcwsEj4d72ySGTnPtaXNs90H1SV5Enl64WK289xV29gCkxOt1kgUE6Si",
-	"4rzJHUGWuLdl1zm5s8x9QZaZ/2WBfM4aDTJXmnuAuXiLe75O6bMzpZlSFLqsZdMyvTdTmrmHyMjEprJc",
-	"cZOzhtjExw2ugAztypAULFi0TB9z8Qc9AtXVKKImzpVKmlw5gmsEZK1Ww66rqcUvfJRgJ8EQ0weWAPuI",
-	"CtKnf6QmiDeYaoTp/qwIyRH05S7ylC4cwkDuQRd6ck8t4QfNJkOSTOGNpi5qxil0CRzDQO7KPdmBS6Tu",
-	"+Ax9InfjNaFL7vzJ3uIO931S9dx1jmYWbMPHcw+ts4r7FFtxNi1qLqNUd32DZauuLxLZ96Eerp2K++Iz",
-	"19qewMAJJp1I1DSYpYbcTFve3dlSadaYGsu0YlnE58yrb9J2IfPobocPTJnbzSGWrl/aYx49ez2Dt7ws",
-	"cr1CgzlEgnsoyE2eS0yTNDtq5xyUFi8v4ySLholCrVrDyBjACRxjTYuHeb90/1o4kCdPuk40hfq/4EgX",
-	"scVkZY1xfIFFN9Yu78K6d19L9/vrneloOZosD+NytFojtkVYw+PM2ib8KxupQvosptIT7dyB/0KPyF3Z",
-	"kd9CT+HSkeyYMO3n6ETknnxNqjUCfQJdbSk0kWoBfINrwCn+lKzw+moOoiCZMxd50IeTkT7GcHVE2wQW",
-	"JvzJNyCiIlgTA+ITNXoKPMwOs7yguRK/rkCm90Oe0u0gT1wcU6QLd2dLd+fuL8/Ole/dLz/43V9vDJvC",
-	"ku320QmOFECpaBnIA9WY65NInFtGK5RnFJbG+IiKq54K0Sh6T6ELp6HQ5A701cxzuFSsRrf0MHgPCAzg",
-	"UsVpV/4D+vLg08lj0ePaeyYOx1o0YYqIdBuxr4ZeOZfrczn+g2vlMfepA7mQ2uLjhzXS9uDBBycUqEOr",
-	"wercWltHDw0e0JuL4pHFc3qQyNEH8BYG40mpS6/sBHk0vdPqJGXEG7V6b6wB2scQPpL7um2OEY3yfRQ0",
-	"6cMZJnBz//61CW2uSYESPSElegxUP+o94ARx51zB0IHmDohLu3AGPTJsnm+xRpBFp4aDYjpVZ47jChJh",
-	"EnEdomUg1Zo2heM+ZI5lW2FFlZZL7o2UcapjrWAUyWFfUyO0VZ5oIx3+WDrHJbpwJ6FLqTq8HslDbIdg",
-	"nR8JKiph/I4I+ib30A7lPpyNNd9NjOw8X4nUrUXyAiVsJdi+ukOJQIYIl4hN2w8tfXMUFn6ErnwpO/JV",
-	"HETHOtkNLxXgUpXrR+jXJExlhviTB+NZc3xoyGSRqF7AKf6s8mQWiChbEzhGGXGIGqa5bk8/j17d5WTW",
-	"Yaszq4eiu5pTtlDyziPd5jV2SeReWAqoFofqfsiO3FV2f4WWioClExq+i/Dx4AaFvNpp/g0XsiP3wsNF",
-	"iEGWI19BHw61xFFjRmcCA5vS8ofeMIBD+a3syO+I0jqlv+wohaOCCX3prfKGEzjFystQDCW8AA1u+8Ku",
-	"Rz6AGFBklpXPqJY5a1YsaxoWNewrr6Qan/o2a0iJNDOI+5e00rDrnLYL+ZPm0pM+c9dpezXVQaUttt1U",
-	"F+UTg8XyEB5vuAMjwsb7xzbJOqt/ycM76SyqFMk6gaEmYSs/pNofya6Mjtv71yWfo6kkfU0eZ5Kh3h+w",
-	"/zGq3fv2QlIY3iFylyhw6KoVoo82zqFP7sQGlN/LvSICR8hCz+SBphhG1gU9eJcsu/AEU4gQZoSsxIDj",
-	"H3OhWvLxNycrZvvFQ4rpb1LQH6crPf5vIuj6mDLiOj/Bofwn9OAUzyydw2+9XflDfo9yggQ2oQNneCAa",
-	"3UcX1DdJeY74DIc+Ho68rj8mv0Wa3htHbnlXdj5szbs64q0T9gcnv9od+8rCcMGb3czIvGlMC7M62V3Z",
-	"pbrTGsApqdY+0R3erO/BrnDOau0TuV8g8Ba9ObcqnaioiRxYeWLKgX0uFvzK8II3m12pqUuJ0VPQrASg",
-	"PWcNn0/uI+99d5550FfdHd9wHyoIL9nHTWCC7CuhPsdU0U55waOu/CcjRT8lsvb3usyEd5meefv54M3k",
-	"jZt06P2iy5dQOR1+8ms4w5qFQBeTHfThKHGDnfOR51igtYfvdqKPPnUWwcQevtCDEy9SZXDifXghnniT",
-	"KJXaq+3/BQAA//9EswtjbCsAAA==",
+	"H4sIAAAAAAAC/+x9+3LbVprnq6CwWzV2FSRRsp10lJo/aImxOdGtKSqXcbtYEAlL6JCABgAVe1yusqS4",
+	"k6zd0SSb3e6amSSdzlbtv7QsxrQu1CsAr7BPsnW+c8E5wDkgSNGWnfE/iUyCOLfvdr7L77uv193WlutY",
+	"TuDrs/f1LdMzW1ZgefCvlXazWbH+pW35Qbnx+7bl3UOfNiy/7tlbge06+qwe/jU8DLvhabQb9qIvwl54",
+	"FHai3bAfPdRWKrqh2+ihf4HfGrpjtix9Vt9qN5s1D7+4Zjd0Q0f/sD2roc8GXtsydL++abVMNFpwbwv9",
+	"xA8829nQHzww9KpltpbMlqWa0C/hKZ5GeBw9CU/DftjVwl54Eu1r4VHYD0/CTngaHkaPFbMLLLNVg7+H",
+	"m9eab3mjbFN4FvZhqs/DfngAH3fD42hfMb22b3nDbtoD+iUc65zZtJyG6ZVbW64XVCy/3Qzg8D13y/IC",
+	"24KnbPjWakjW8n/DXrQTHod92Fc0/+ghLOwhXkF4Gp5Gj6NH6OvjsKeF/fApHMRBeBx9G397Fnajh2Ev",
+	"7IeH6EvdoBO3ncDasDz9AVpky90eMIvoIYzZDbsDJ5QY0gCSwAeBH3iKDyP6Cr3sNOxGu1p4oGFyQgcY",
+	"Hoad6GHYlU6Wno2UPuLjusUdItvmeKm32avd9T9a9QC9eW7TDFY8d9tuWJ50LzrRrqFFX6CFIAqHaUeP",
+	"0Dqj/ejrsBs+Q8d1EHbDp9GjaA8tiFt72Mffox1l5BjtoeVHO0CKltNuoZn7TbP+mW7oLTMILK/l+gE3",
+	"X7paQ5837ea96qbntjc2t9oS8mqYgSVZyPfAJE+AKbS16pxu6Hdcr2UG+iz+iWSsluVtWPymcyfiblmO",
+	"/LvEiZCXk+fZS2VnMW+Z9cDeNtGcKxY6Psny6DNWo4YOGz60A6vlS4iDjWF6nnkPU73p+/YGmTr73X/3",
+	"rDv6rP7fpmKpPUUYe6pibdvW55ZXsbaaZt1qWU4ge3Ms3WTzaDv8uImz+THaDY+AT3ajx2EXsV03PIie",
+	"RN+IbEQ4R4v2ELMgDo12oieU9xAPdcLn6L/Rl2Ev2o2e6Ea+Ja6x2eHFpteXOFRelKdPRNhmYe2yUy95",
+	"nutVLH/LdXzYPOuu2dpq4j/Rd+iPuttAv1partY+WF5bmgdC8n1zA33qWb7b9uqW5riBdsdtOw2YsEg4",
+	"7FXix/jF9xkbVkvFxVrpk/JqdVU39JWK8PdiqXKjhMZG8yiurpZvLJF/1uaKS/Pl+WK1pBvCLMtLHxUX",
+	"yvO11VK1Wl66sUq+Li0tr924WauUPiqXPi5V6Mcw+mJp8XoJqXj4V3HxevnG2vIaemRttVSpoefKS/Ao",
+	"9/6VUqW8zA84V1woLc0XK9xH1UpxabVcLS8vcR/Ol+bKq/gjWF7t+sLy3IewrA+WK3Ol+dr1T2uV0u/X",
+	"ypWSsKDyjaVida1S4idR/HRhucg/VZ4vLVXL1U/RR+TPWrX4YYmfwera9dW5SnklMbG5m8Uq+s/SUmlB",
+	"N/TSYrG8AIufW176oHxjLTGdhSI9l7m50kq1eH2hJBWgjGwGqRKgjPj5NOkmnscEJqXwbcsJqvBpivf/",
+	"HvbCMw2U5gmw8ClRGdEO0u5IJoQ9QVHwRt5k3bNMrOSEjz3LbNxLflh3nW0LqcRa4NYannknSP8M8bTk",
+	"bVgC+jWOq6VP1DxL9QiR+8k5NV1f9jqmLiTD1Nxty2u0LcW3ntWyHaTNsUzmtgj+6VtBYDsbfq291eA/",
+	"b1mtdbRETpxJ6ecDs9lcN+ufUbWQFimCJkgc99/CbvgcxPUBCGrRdA47BjK0nosGxAtNkOzd8JTTEMjy",
+	"iB7KtPcINlM8cxkZlxuWE9jBvQxz6Tuw7L4KT6P9aB+RcC/aAbP7BJaWWNUZWIxobYdgA/6KtJYW7UVf",
+	"Rt9Gu3R/noed8Axe9ITjgg072Gyv6wb6o2miPyQGlKFbLdNuSs9xwQwsp35vNTADP32GdbftBJIF/hwe",
+	"ESvuKOxrKxU8213g3L7UdN26Vqhtum3Pl7ztP8NueBj24PA7yByOvgw70U7YiR69T4xkQwu7YI33NJgT",
+	"tskPgAoKghHnttebnBnntFvrZAbvKWfwXmECDgLRUPQneo9C6xNnk3eg95QDvTe+gVJyGh2VjGAXkchZ",
+	"3rY8z25YkjPGgqFmwkEL1vBEYLekJvEd16tbjdq67Cb6k/S6+cRAF4bH2PqHG/RB9FXY4+gfzLkefZxc",
+	"rPGZR/vwi65sKkjUuo6EvZHF1bKCWt11GjaanIz0fsFXvPAADWJgu1KYZxff7bDN2dHCE6qhEItyk4v2",
+	"eStzgAGeODqyBMmEDf50lGdrrttNO7iXPlpza8tzt82mL7+81DdNZ8PyqeZQHejfRCEbPTaINEICLNph",
+	"Rwm34+dEf3eH3hRyJzLXmxKNsVLBW/8rogu68buIbZ4iVZJFRM/CfvqkyNDrrtu0TAcN7hIGkVHJT/KX",
+	"41uK8GrilMpz2xDZUrIdSTeWbAspGdUGHLUfmEHb5y38+Urxg6pu6MsrJWb0IktybmF5tTQv1RU5+OmH",
+	"sKtgn2iPZ7Wx8U7a10eWypOTwXGCdM8UvCDlyJhQZAy55Ab2HbsOl/cVz7pjeZZTt2SqddMMapaD5teQ",
+	"ehnPQNKAYYApXeHHA82xB7oQm8/HlPmiR0yr7CLuTHon00wAdkLGrNAV/Yyo3uNoP2te32jUXEFjasj7",
+	"kzDf6BR7Ghg9aPqIYA4Qo0XfIAo5AD/aUbQnnewIlp2w6cnlyo5zGdvYxB+QFrAbltKqEW0kotv7yNmH",
+	"BedX2Le4I92XfJYGvWQMpbvziBXhGaU3h113FG/xrW3LI4opuTlAJWfMG4dVCfz3y/Ao7Gn/7+H3Wst2",
+	"XA/oC6RFV8NPIN+iobXMP4pfhofhQbQXPYqfAgds3bMDu242hffsRg+jb8HMouIQxgKz+Y/wf/ozqSD0",
+	"m6b62PHg3N0kwXjAk4iFnsJie9EjShy5LcxsJ9vnrveZ7WzkJUzpVMZJpwMldprajESUJKYzkegNjgOT",
+	"C+ePiaNFGZdzgSgJj9Px6DQke0okTHqLcFggeU+F7b2EboGIxuMXgwNEa5l3+Y8SxNMJD8jlsXN5KOvK",
+	"bAebrpJXsQeiqJYjTrvZxLYZjgal34At1fO84g5xKmRttdQclWw7MUfx3jNfAxwHv6V5rbWUv0Nlv55r",
+	"A8YtmxX7F31F+XcYis27VSrn+Zis0Cy/0r8P6UTqRN/gCFRqwdHj9zX0u2gn2oP/7iLtknBFiE4rcnvs",
+	"g9zsQjwWm2KdtBoeSSbGHMxZuRLxNEDEle7SmFJaKVP9ii/Bz6KH0V74HBTySuV9pLnmVj/SIIjXi3YS",
+	"eo6TbaBUnuMtQBFZokvgsnYcdpBdqycjE3nk01CGzkiODdvzA7qVw/0Uc/+FmWKYDlo002Kwzn8uoXnk",
+	"ypPG0TrhMfM9yoLoanH997CLLPjoa/AQpIccSofFAiRbLLw0botXKtBX8gAGcODqpjSom80B5uemjVz2",
+	"NbOO91bmI+nHMVRDCzuqy9kTJMO+jr5lIusQoizIy3Gs0WB+j3wr+nqO5V6e9PVsfMQ9BrUxRnmbOAbZ",
+	"UVc4YlB41vnx/DwednQMRD6j+/Fu2JdzKjkSImLTvBq4gdkcXkIMEAswPSH9ZVILf1JPz0BK5JBoA2av",
+	"HeJF9aN95F+IbRS4mNB4SIe4aZH+iR6R3JxePJEe/RqH/2Md/wdHsiHJiD7sjpE4HvkZK1wCOe7kA+3A",
+	"hlW3G2N4h08ExWBzbZ4+PYpjRb0/RcYHo1kdadoJX3BUOamFv2ABhX3y3/KR46Rh0gHDDXxWcXy2ZgaI",
+	"ZDyc1YK8flhuHsDPT2QWMiZJeD81pMNumiajfUp4k0B5w5PJKGSRSQYjGSDCXuUfl9vRwZc4xRXuBcoo",
+	"O8Gnhk70z9EuPlz5gAO8UUyLpAkQROtetIPd9+O1LVK2QzbHzHPnpb65SUwpuGZQBVlcWaksf4R14s3i",
+	"0o3SKiSvlFar+LPlxcXSUlVxw6J33AXXbAz0h8h29LtoR5gg6C/Bj6G8s6cMxD2pFjO3LQ+5ftwti/pP",
+	"apnOWHIlwwIEHMvP0ZYhytIgrtSDzMtvoq+xtonnPigeDc/jT/N572wfbIdtnpo4u4ncIzIv8GRqLA6G",
+	"ruwrFelOcTuU/TLediQvNJiZmNghclCxHyHzWqDiSKQ+8nGV6AFkv+P3MrHS1D4aacJVM6GY5phiAcf6",
+	"vKZWkobuNhuZ3w8WuwOlCj+EIUwoa1UKY5TtDMv3uJDDTEwjeyGeGVgb95T2BL7pPMPKGlkVT7HPVOXZ",
+	"ooLTM52G29IN3UMJlDXPXbcd3dCblukHtaZrNiBw9Lllb2yqUqPoHFEhQX4RKpFxzHaq+ZumJ09n7kMI",
+	"UBlsMPI6+KK9fMJroODN8Y60hBssttJPDKGU40dHkBKSg8iizI9d77PmOU59PJsziFvRjvhD534zipa4",
+	"ZsbA/yMcDV6H7DxA1PH51InwO/Ynpq7h6a2EFNE8D97x3NbQ7sIc74VtyfFcygeYfaRJFwVPNT7VEypr",
+	"QR1zSfoBhosaUBU1dGVBINSCDKgsCHuc6RR2idezjxwQp8h1Iiwg7ORdQrIoRbYIu2WhvGPezzzotUKK",
+	"JvcOoKChf+zmJ1FwhtQGeKqQlQihAOTu72TSQR7fS2I4OftJeULBUoac1RVHkdzdJFcJhJZiGJkcQjIz",
+	"LX5ImnVuCYzesmix+P8QrJGhCukkVNNGRWFzm6bjWE1p/hL9IhWEg0Te8NggpW1x5Q5ke+6FTxHvQVSA",
+	"hd+kCUzvw+Pk9o8yQsIj7t3PIWO6w/kKECF2cIqmWIsmzRzd4hK4s/ZeqI0bXOKE8+mH8JVknBCbosH2",
+	"WxhBdXKEVFKHNuDqmXVnya/f42uJ9KKmmjNWAKiARRYYcVlNhTTlWOKaJslpQmaRzM9AEnsPwev4jFYq",
+	"HpGoLr707vIxXG7DsOygLjal9+Ev6N6xkzPtJmmZ46qYY8iQO40TXVnyHc5s6Ceu5EDy504lQgT7r65j",
+	"KUpCYcKkiAF57aOdjHXhYPxTdETRY61cXCpKyzWGZh+c91NrmPfkFgubDo4wnJIAAYk+9LDmL68ua797",
+	"pzBtaNNY0KC4GXsKMol74ZEkVslfSBJSGaZlOQ1FngLyGP2J3y8SdgMbxNBu3pxdXFSu1g9MqTP9BxaW",
+	"6Q/96gw5JKVzYSrccvkD4UjISLBxLkG2SsqU0jIBJeO0t2rsIpNVYYQtklQWHink3kc5pmGXshZyE0CS",
+	"N4k1iTkkOIEobw4J7yY8EGYQdoVULhI+eERitB1cGJ4UQokknMEJ9XwmmbTwphMeQVT3RIAzAOZlte/p",
+	"9A6QmOBvbNmO3UKek2mZA1BIbpOO3wtPxzF6Qe5+lOXGq7EG4ig4CTThQCN8BpXuMNM+FiPHqRz2fFNi",
+	"9mLsv8p3KyLPj27tpcdOHlCSYBSp8gLnqRh3kBsEzOnahu3YcvEY/Tn6AgFZRH8Ke6TcJvwenNCnYW+w",
+	"UwsnAUNZmQZEjsqsDrLSMdGdEDH9I4PwabSXNBLSYe9ZrQDqgh8YogrJHLDoMZE2aCwUwDc0YgX/CqKJ",
+	"UztQSIfkBQRREE2CAcyF2vugxmCKEFnMoeYFLhzqPq7y94xIhanT5+cmI6ZUUX5mKkWW9Uq+kyBCxASS",
+	"gPQIX6SIxaDcn0a3OCVVYjhlmqVFkNj04xGyALN8+muOuW3aTWXxl+U0fGJGKa0P0c+hXSL1b+ERKuAg",
+	"+ROskuqybuQ0yDIiyYpANEYukKalMM8CBOVRGggpEKHoLwpIFqa5BWQVoYa8ZTptSPew67480R/ZNv5Q",
+	"xmju5AnhgPmhDHZ0RlwaSDZISgj+CFc9ld30V0ytOAViB8lDwWzJRBPKbZe8xHsmXteg+ybGUsJ13OOp",
+	"iLXuBmgOTbmQ+Q8QEb3wVLthBzfb61M37GDBXDe08nx2XRer3cK0juu4z+HLSBWvj1ZHxfkl+IUPLFf9",
+	"2FrfdN3P5q2mvW15kq03g8Bqbak83KMcTAOPNexxbpPAb9ZmxoAWTOblR+WK9ToStz0jTrBDcupbcqUI",
+	"D7AKeQYugD4829U+mSg7ZuB6E2wjJUtoooglg3tJecRRxe5TnHTFCqNIBd4LnM4A8DpI2rIbfngGQBxH",
+	"YU82oEeCLTVVps3NanVlItrh0m1SQ4MeRWr1AN+/8LCHpJTwS7yF0mQD1aBbltOwnQ1sXomb3mHpDWgQ",
+	"cheL9ikqlaHdMe2m1YgdAmz5GpkOOrCzsEO0O4MmwUPqHPEh1Qkvk6uZ9jqbslIunteviLVMYiRK6XyC",
+	"K2XBRIbzgNs6YW0V3JsqaRnsrxe4voLVkKdRX9QMKgWSEX9Pqw+QWfACcSRJlkq91G0HdbelMkPI+2JP",
+	"QZwDjRyGEEUiJgke7wzS3ikmBqMOz61bPoZ7sTcc11MQhcSsHQIakQRlGCvRChbI32FobQSmBDId9xTJ",
+	"b7HVJquKB548BUcJRNDEYptoJ71vyX3JplpKnvRoMkhvlaPtMSn1bRpKzXVlEvRB0uZRsbXXzGsqek2d",
+	"TWmApkUvsJ07EOsL7KBp4fIAeqfT4rRcbdXytu26pV2qWn6gVU3/M0NDxW7aTGHmGjL5ty0P5yPq05OF",
+	"yQKNSJtbtj6rX5ksTF7RDX3LDDZhf6Y2LbMZbKI/NyzYanQIUA5fbuiz+g0ruImfiBUG/HCmUED/q7tO",
+	"QDjb3Npqkkr6qT8SEowxKMXTjaU/g03Tlz+UUpdkq1LMjv2GPd6fTZxy8Aq/3WqZ3j3KBH3iRgCNQlRM",
+	"tBeehaf4bxxuZu9EF60Fe9tyLN/XVjx33ULbHJjIy3lLJ7tzG40ztRXXjExBGBNW7vqSjV1x/YArMZmD",
+	"pzEZWX5w3W3cy7G9HOZcSvzoW97EdKEwrT8wlMdw7sQ2OSmLSKQPRqIcfmmeqq73lt6eQax2Rb/Nl3/M",
+	"6u1pna+R1RFzTEwXJmauVqdnZq9cnb32zj/rRsamSWtd9GKjofmW6dU3Yw08S6tYHmRt9EBLn6OFfGS/",
+	"UiERVEyzfQK609PodAz9auHqUDyaKSwFvEPFfKhHBavt8BRP4r3hjjuJnijFAYxhFOum47iBBoetmRqu",
+	"LdLQ6Wket59jWiWNXWIPM41M9VncuxeeEJNolxXSdQmmTWxIY4d4QjT9BfxaOOvlCVgnGCsn4ba+BMVU",
+	"1HRBFVXaxB/ahcIVi5w8L504qvJlMgqUUn4hhR8/h5RK8eg5ODCD3bIr8zCIYIbvih3AgXhqXQ323pBV",
+	"YipiTNj0jC+LGCIFOe00Ugz3MivxXlXt9aQW/hg+jfbD58wf8oRYmSyexqJnnMwC5DwcIuoKAfdewo9F",
+	"sDbGWyU4mvKafjXKazyqCWjs1SsmPsEM64DC+XRAGmU2VgB46zTbByBdU8NpUpp7Rws2LQ2xwFgVwL8x",
+	"Oo5LyARixmja4AY6pc4YIZL8ylVzPOcpKQQCp7Gjx0PrbCxxW+v2RtvFNj2n1P7tvFxvaEyIMRhptr3o",
+	"umM221KSScEQpyhm3Wq6zoavBa4GcDNmU8PuaNhC667tB4nlAHImhAABZ+LrsMsjTWTNhodkjieyUtHs",
+	"hmY2AfhWIyPC6I4blByUuJjYz+9SWQk4WkUQ6pOhU1lKgqFBEPZES0aTlZNXQD/H60CMZ8F0NezF19h7",
+	"NduhPDhGLsw+CIPZZVR9Q1bNqTJDoaeEBxGYucNiiUIqCGjOQbSctPbSxkasIzFiPzhr8EsTEXQMpaha",
+	"yvMsDKL8tiFLV85lGi6y9NtXeX8FXFOpJUfxJqEoDukhiNtTYyQT3FCOKgpGzs/xcwxpAVzLj8FZ2eWi",
+	"AL9Ge9FDnPsqT0U8LyRrtE9q+4aCZE1balyaoob30xi98Hiw43FU6M/fgkMiBpx6uQ4JfAd+bRwSdDpj",
+	"sAOl2P6xHmJMhaxBeuhIv8MXYBsiMTVWf0Bu1qP3+ZjzL8JF02NJtHKcmZfiwkm2aIhPDA6EHhWkGWmm",
+	"h1titKzxem5+GUHm0/1izq2URyC+YXFOZgw6He0yJYQNsSPqnboE9liXJMLskqgQQY7vE2dBB+XSRfuX",
+	"h1TZXIKRyqOf1Nv0J4bQ8OuWfMPjR6YkDcEe3D6vcOWyP6dVmNe3iITlEKjvmE3fEmChb93OlqeyfNOZ",
+	"5N1ZhqJ8S59Gt8uZWMJIkIk1NmNt/Z6GppublIUzkVEyJEtHe4hhMS3GEVMc1xAtnPBFgtpfE9+wOjID",
+	"bGPwEOLYbNsR7bokz9L2DQkI+nzMQ9tz5OOb5biZh8gyqfi2UEPCsk4xNMqL4VvQqbu6nZvzWIXyLQEp",
+	"+d2rk9cS2MXEfJmeKFypFt6bLRRmC4Vzmy9CDTS2o2JEYgb4y2H5zlwVPJyQ6BwXNHCQujPvPridYQtx",
+	"ldm54sYiyPTgbgEq9AoFZ2fBewzC9SDFR8QdEOMvJ+nslXug/j3b7RR2pOIguxDsUrTDJXh3SDUhPKWq",
+	"IxtCmeJmRHnvvxV4+m389q0LPPMqhCfzXysyi52bb3Jk9icWvMNuxh6OA5IQIYRg87vohpJArII2rxDC",
+	"PziHHHKbMYdymngk8fSKcZUuXpih1Kr2tZcuzBJofWjI8cm3AVCAHHQ+LiaWYzcOdtx5ujhSLvtICVEq",
+	"6wPV/206WAggBW1yy8mpH/AY4fNon8aF4p4vpJcGw97NClWxh2TCHEhfcx3sTGtgeQkhqznTadi0k684",
+	"L3RHFVLtlOGp7ECU0LGUD0DFgScGBKfV6Xz4IBSaaFDk2tomHDfqQ3saPQ6P8dlldTVElu6gaBrXhZVv",
+	"CEvSPWkom0wSOTCDTdsnOz1GlfkDlFjt8bCoXEtP0iKb9A8CKOkMBGOV0kxrRbiLnKLLDfjIsgBgZVAP",
+	"EOHqUtiHRIukvJrV3bKG0avw+Fvr/q11/9a6TykExBu/CfOe97iQUuAYhewFsvYv4QxLlnCJDnwolwKr",
+	"iM4nduDxc4idGEyaRxjO4jxmriPezWCr0dHKhyz/HrUEm0PS/u2KQcFjK3HQ4vhyIXbQ8ojkqhi0nGgS",
+	"hDHCeAgBX3jPFYgWXagoT2J08z0KuCvEGLIXpRZf2+esPZYzpY/XxOsisch8F6cS6KkhLi0DDd/fjPqT",
+	"X4N49cYS98hlbLz5bUPEm39kRbKx2ykTeR4X/61UslUXQxUl8bBUPP1Lgsh5wCWHq/AlD0Twiq52C+Gv",
+	"GlrgXn5faN9wmEoC4nqDxClWeAEGDwx6kh5lUgu/E1/NIbCJlw5cNtyPdsXpY6Qg5W+50Et6mye18H/S",
+	"hifRnzCmDI/fIc85DPtighZuXQzhzr7wBkIiOEgKN+yn1HZBnelRuhV6XVd798qMRmvFs/u8QNsWyanC",
+	"1ZzFMXeEvtphJ18TmKfoFTAfsifUbcNInPTdB6hJsjgo48bAkWgyuDVHKjCL4VIHxWJ5sLXkSSgwVBQB",
+	"WUS6Qiw2X313bmQXFttms4LMkG74TDmlwB3HhP4uNjF6LcPUcijoggL5uUCBnuOY9XS1wJkocmDnGTmO",
+	"87QEtjn9COnZNC2BaL6VRu+/Ig19x7gq+nV3HSwleaybR1JGNA/uN26xbImwF2hR6M+ZB0b62Rnu2Zn4",
+	"2ekHt2Oc3SQSMlnEjKFvXSvQgPs0yhjYeo/9+8rkNPr3e/G/r74jQ0aWvmymILxr5p3Jq8LLZt6d/N1V",
+	"ApUsJCZwhyyFRr6SW1+L4OTSGuSkBEPx6D3ovIhL9vvUutoj0raT37Qcp7tPJQBPmVymtQSci+00+lPY",
+	"4axETgWpNc1rmWRg6Nde6ZZ/h7u/EX2LM5TCPofmwirN0X87skzCY6aTAUvuafQ16bcuU5rYLGLhyLg3",
+	"V3dQMBLRuO0HAK0Vm39TFvQBm0rIPLlF+IPMoOC7CqVNQ9zzXjTajJTiS3QMU6ICTmrh/4E6hxOcFYcb",
+	"ifSih0nLMQnSE+1pxXrd2goILka0BxbOMbALWsg32tL8P60uL6EiANYArSeCAH0jjHCCv9EAHzC2ijhQ",
+	"3SxjBrdfKwpQ5m+tm9+adXN3wmkMJ3EUbfrA2WHdDabq/rZgLiX9SwZbhoEdLwZnexicT8egbiAj9uEY",
+	"Qm85g4ve/sEhXiuDGCYGuqca7RmDs7qYY8igfiXh2xn6raF62xXDMISfTNOftK8CL6nP5YExnLB6q5wv",
+	"RDlfLbwzBlfb3FxppVq8viDGiP32FuIVq6Fh8eHPapRlDE3KleP1w/U0AkpMoS7jRr1f8QHXA6KKtOiL",
+	"WJdRJOUuRVjDsFi/Qq0nU25vnH3zHdc/lFg4p9lGBOlpTsgdK+XcVswW7+RSmTF5PVgUQouD96XODGIf",
+	"dH9r9ojgJHxrkLw1SISQy9C2SCq+ZbDwmNpO8Y0YSs1I9f83WFt/g/iJOLMFDOnYuIhDaUY7NjWw099o",
+	"z2jtq3LrRWq0xE6UuCLCMKaHNUtWKgnafGuIvDVE3hoiF2CIrFRGtDWYoJq6bzceZNWTEbciebzcGKhS",
+	"M/CJM3C+0U8R4GOsOiA/QkyAeKku+3QrzXfTnTOvJRtlzkgd4wQJ/ZbirekurIXJK9cGNkK9Upi8lm9K",
+	"Mi/8g9tph70x1I2ea0SquqzGvryOLOH6lcvRn4bJcH6zva6CK1VoNCJG1MVobngyWFgwXH+5R/V72roC",
+	"AZgcZiTKiogvOJSdqhVM9tGMX2TEDVhGabne00iS8CmpfH9IsVSjJ4oOK5J7nbB73azbSJV0DRii9vbP",
+	"OFAtHNDFxjGTgoxvsVOYnLkmdIFRirs8Yo3rLYFF/YiiNyXiZJO6kmO8K8nxCqnxrojjzZme21RGQTPL",
+	"e4frnSw0QxpU26vqqZRDgsvlxduQ2QUI7354oDiODPGNjn7KbDSys3kRNRUbjfPk8LLWs7fupxmLy6ac",
+	"Fhmm2LTrFrBo1o9yZBpsmfdwACq3RVNllTZjxtIMSG/ei94SJnYGSJ2cG5VHfIh8KrgnO2PJUQW4RAlE",
+	"IVt3GqbQeElSKAtiMRNFT7CC9lCeGsiBDkl8hftSeBL2ADmA/vDbaHcKhdVJQeMxbiiiuFAhjwWf8I8N",
+	"EU4iNCwgrIGoyuiH8/Gz55AP8qwgQtAwScwHM/ogJZnRJJm9TZoFym9wj2EkHgqd2gDNKOxNauH/oojA",
+	"SXxFSHI8TPZ3i/aoUwFaXEX7wjGL3d4HdLJS9rl7JRUCMWngkmXxbOLEMpjcfd2xPq8JyfJisfPA8gF1",
+	"xljbEQZSvWImlfWfH8KIkbbtOhWLuIXTfP9jtIsTRLWwnzx6QjAaH9VIl/32xiL81lZLlRry2JWXAGY4",
+	"I0n/JaMLZ16qJRC6FwwsPDSsi6J9I74oJoV1P7HC6LGSGoitB6irAnKOcM3NktzkAq7y1aHnb1jB0Bhp",
+	"6HdLZssaFzzaa2P7DG8NJpk/fBr9D6jV230D4YmSt4ycpkcWBTpuYN8hK/QH0eKS8PBFUyUCvXOsJhqR",
+	"EMgEvdvzzQ51v2nWP9OVionrmabLQm/DUd3cphnMkYnJD72DAXqRFXJA6klPqHvqtQxNcVDVdO7sKZZ4",
+	"IAPXS5Nr9tol7ddpc0sJCRvM0k3e5YWZnkV7pIvyEXQ2I7Ul0PgM5bXGaQl9TabrpVd3I9M8SLopeXDA",
+	"HgHm5NY5qYV/E4eIHmsAWIEeAgBjMa2CVbgQoH8GCR32NCB2emItMwgsr+X6gXaJWMiL8UfgsO3hKiVF",
+	"JOfy+xRAFp149C19Me0wAloPNqBLypjkB5vcQ7LTSQhrPJS4dHw6k9paZYEAzaGLGnT6ZchIFOOZnDR0",
+	"/0XV06iGBw0d7cxqTdfdQlxvaLGIxDk2Pa1pO59NNN262eR+Enbo+o7DPoG+Jj0fkabHTmfUjp+aRwQI",
+	"/ePS9ZvLyx/WigsLyx+X5ms3l1erqzLHMr2SJaXq6IAPeWXe57gZXg0ay+mbQbDlz05NoY/8SfjlZN1t",
+	"Tfm465s/VS0UClPX0X8++eSTT7IqtJlIvq+We+EBeOthzzDtHrKuFbjlIBwtaqgPF7XueXrZInHM97HN",
+	"vnIK+5JcAhDgAYYCiPb5DonxXAdDEPF+/q24Qy4/8su7GL4MNZbWAmO4G1EYiLmbxSr6z9JSaUG4H9nO",
+	"ttm0G1p90wy0OpvoSyxhxo3eQR2RVg9H0Ar2IbYmyQNAJFhGwp+4SbboXMaqjsmZN8Dw/IE74vPrcu0S",
+	"V1tBHV+wOb/i317ObbBONaymlcftJcjYefyjMfu/RnR0jdk/dFXmLksfULTPlBvkHnJdeH4TtueP3NpI",
+	"ffqefBeGMTkZEfpWENjOxsAL0yp97qLvSohI21s1Gv7Vt5pmgBLBANDcvMtDh8wYutikBxegspJSzwys",
+	"DbTJTcv0gxqKWUKf4nNf2NlmqRIE6Xm/wC7fN/76fppeU3YiOh87VKGQpa9HconIkebIYlBJVUYcwUsT",
+	"2BU5OXlu22nUPHfddnRjWAkrzuR+bu98anJJ4YkAZEhiCgBGdKKv8Y1FS2NBKpLmxb6+OrCX3Wq3gLHQ",
+	"+3EzAewYSzetTzDjK51gIc8EZT0N7gvvkP0mRQD5MtZW6fMDjPkLCLuMSdL9yIUFv40rQU5zCcAx2d2r",
+	"pWq1vHRjVWpzo73U/HgJ4zW6E/hqisW/AeL+r+FzAmr5KsR9bJo0zYFWSdO8cIPEbAduLQabJp1UcGkF",
+	"rQId3HMhsFvWv7oO+rDURgphatH16+7nufy6uGVDrWHeQzswbcwYV4yrxrXb5HM0wqw+/bvZQoE+6gem",
+	"h14HxRbDGTdYfq0uFBVIDlBMlaoFfS1tcdrhgTzDPDiD7J1oJ7XKYcwY1ZZJcJoO4pb8X+K8UXD5odae",
+	"MiChXFzYIXBMXQkM06QGoovzZdEYIJtE2CWTQJeMp+iN0R6drlwtz6KpAqXhkYEStbXqHCzhDJeVhYes",
+	"kK3HpfidIQxmggC1h9CXBje2gOsgcswJnaRpzQYs5blBnOS96BHnbkWO9bDLO9YBEgrWzMf5J0mqKGl6",
+	"Y2isMZ8mSANhVjI3O3fSMqzeJL5zJ8v5imXheXqL82IMm0hjk2LZ3cb5ge/Luj1Kp5FipP+UQXkBcz0F",
+	"Kjl5n2F+xU1fkckI5075Ni5GdNvIWmRWltNGsePBvtd4EyRfcoI6bdtzZmXSuI8FueqlRKTfH8JtK93X",
+	"18GszKdjeIE9TpNxoSi1FjHTa3hW4zUVZQ7YWDkRZSWXwW+A/fgXfEw5Vef5nKp+08ztSl1tmv/VHKiM",
+	"dYiOxJhAvwET7Ue6nvOYaIiKILcQpTNVGEib6gayhh69wZ4c9iKCfj6+xo8JdLZb99Ngyebnpo2uupDB",
+	"5DIlP04o+dv5MbATE85ZYMIVzK9uQmJkWlfmxr+mDxqJyeQqSfmZZCogIlup/AOrg5DWkQ64UKxU/gHS",
+	"d5/hpvQZQMK5mmdQugYCFejablhOYNMjyCTscvzoxVI2P+db9/UYv0B9HbbuBpbnmE1Mh249cOtmIOZS",
+	"bdjBZns9naSbn4DFvcxFvXhj4Gf3xkS53Cxyke134SnBVAQrgiXgRI+pfCUJOK91SeyAeMRB3kVmVX4n",
+	"uUh5gf9F+RY5vDCGvOlw84px+mBesAS+bhVa0GKkQtT2GIHn9HK+JwlyTPrHgx49BqOqQ0GPUZXIiXIt",
+	"4cmkFv4HXE1Rx+7H2g07uNlen7phBwvmOpqQ1TLtJta8uJq1J6Ags6QoMltSh/0MLyx6qL7epuXRyOba",
+	"6JJBLQiEd94fPcWHSgY+zWcUXUZ/LsrB0YzI6aH4Xyog7w0nFXMVdP2SQfGYwg8wCPjYKrzo7bA8X1qq",
+	"lqufSq+IdL81m1/Oy2tG0MF+qlgyxGkGqrwe0jtdgoLxmmMgnL8jDzm5WrX4YUnsxpM6N23darrOho+a",
+	"h5mOG2xanoY4bMwt9ZVETIr40rSshUe8m/CEoNZKtvEbWU9g9q6Enoy+ib6JJfMpQOQKE8q6lMOPSVYu",
+	"A+HiyTJ6fDmnVZrr7p5QB+e/w59H+F+UcB9jUlWGLO1Hu5RiYkn6KkXEL4NMm0zP04/R7ogEn5dem677",
+	"WXtLDUHyV6jB3RVbQKTYmsQw1HYq6sTBZef2xMztXRwwIMCLZySPu4PrcZH9pYXH1HBDMZcb5epC8XoN",
+	"6vYWiysr5aUbGryzS4tLmZ9FY1JzsViduwm/WSoullb/EZwH6G0ouA3b382wHhV4JAlWXsC7OQiahN1h",
+	"AN1AVIAKVBLOKFIjNw3Hvw+MYRCmDnCGNZk4qoRO2PSKifMm3MtHncq3FwmDbViuTYHFvVYCRaJyTyFH",
+	"JYFVmlPrIuSSF7i2Q3lJPJPQhroyJlM05a6Cg5+erwxuvG4elABfsxyUocXIG26UyQ/VmjmLMvilrnjW",
+	"HcuznLqVN0XzdSlxG5+L5DTnGkfzkCiMtrEVCInEQnJu8pps4o9lAecE3ckeGdp2u+i46jAMkDtz743n",
+	"ixy5befnC4V4nmrYGxbmF4VnEdmvtIM0hVaBhIVnWBdF+7EtuwOps4e4kUk24FwePUYcCQZ12xEyNgBG",
+	"BEy9dGIRuAh/pvPAnkmoMsOAC7E/84R1KHuKR8epGA8xlgk4P7Eh9eT91DDRY1bJmbVKNnGxDhIZx18i",
+	"ZAPmKOGLR5De/4pmGINRgH0qZ9FDmswZHsUuzUx/pViog0/6HBIvr3DLlEspAXRuWBYfHpvOmJBvOTLS",
+	"/jk8IoSHm+IRqmHBaUb2pJNoOvc6IWdhnJyRO0qhPclY0ePXWnwhPLkr53OElRaL5QUAbJlbXvqgfGOt",
+	"kmjTiR35Datpb1vePYreUnedO/ZG2xtvr05Bxh0lWFRWICW/5dM3ECnehdJqDJJ9QK73goQUM/P6bFSh",
+	"Wiotv30rKPtFAgAy0Ee1yj19DtbnMEeIpUPT5WoiDuQg+zjhno9fKzNwFGMgRr5jtpsBm0ySlnFBvQKO",
+	"KAN9Rg08k3F1EtImkQZIJU2SPl3nN+B4ZM5XAnk1NKTVzEBIKwG8isS65STGSqFoUtPtgVg3GbTGL+W+",
+	"ojd9Kns5ldl7SeJF06R0epnHNctTjVPB3ZhQpZc0MO9kzP9vnM1xSCGH+9jxg7HgmRz7CjnisLMh9qc/",
+	"fhkLW2MzxktUpRvk8fXkDMrJbg1KO/nJG3RJ+IX4U/HiSILZF+ExgkbVOClHUJuHSyuIlUvbMbdNu2mu",
+	"200SNc103qyJj19w+pnl2W4DD2w5DZ9Lzym8OzF9TegnCiJkWsc6hrcCwL7WDd13214dUVvLdNpmE+eZ",
+	"eUHirYlWtCMn8rC538/NWvzGjykDjUwilwX7U9wdhJhJ4SGmPYCcYXT4JjuoznKucawOqhRPjZ5oMogJ",
+	"lLQ/LKWrCZvNIV+LpXhOkiQWblp53zYCF8SjGGz2rz5xJS2IhxEHD4bkYAGPeKy5KiulSnl5XpqpIi5R",
+	"w9Jn7KjEsnZhiQ5Ip6xfUucNklbfQ1ERd+EU+jWpZdUlnLD3ZwwZdQC2bQZsGT+TdM2YLPv38hAWRv5k",
+	"C5HEz59wwQyA/Lm2WcjKw+XLjjF1QuDiPeDg44spqPgpQX/Z9iye6XC0Owxh2S0ALVY7tv+duYK7yULL",
+	"j0oflZaqWsrkJgWmbKqI2jOmC55o9l5azynJjYgrV9fK8wbLvHoedoi/+avYLZwqDIWfyp3DhsZkHbRt",
+	"gzSJM7gT7sbQvXFwRbB3DNkVEoMWR1/AwR6jLGBGc2wlZNnJ5ptQpPslzXNJm1YaSZFB030m5u2iols0",
+	"VzKZfdoPR7YXlyqVtYUSSgr5oFL6vTZfLC98amgfl0ofov8vLi9Vby58Sj3vn5aKlYVPL1O3/wF6PfYH",
+	"iWnDB9g1xOqDJdtF/EDPMAkf4C+jb0G3xqF0RDClT+aL1RLKVq6U5tYqldLSXGmiPI8rQ+U92Sh0A3Mx",
+	"Il2l0RzLJLRaV6v+c3leAbiYGTAQhWwZs9D5b3UqEY2b5plNy2mYniiKJH0Wx+zawgLCahAMnZa7jf6e",
+	"HjW4P0eWgTetYvnIPykTk8hV8zzag5DTLu4qy7PleFOF54oLpaX5YkWO90emrN2xm9arKSglggO7SHFc",
+	"BblIH0b7F6GvRrS8fobqg0cgP0+B+0QY9CHujQjjNPpzeITaa1ECkGyOdqk8t6qyqwjcpT9FoiUDKqs+",
+	"Jo/Px0+fs2vhIUkLOgp7igwyv73OXviqs8hEK07co1y+FrJhZL+kzpbk+gYag+kN4SaWy//yPWdGHF2U",
+	"myU++VxV2P872oseUuTBQ24BEHJNkBL0eOGuZho2xJB+4zmBkrOaGaY8i/xj2DQHuCH9itNbu9BON9rB",
+	"f5/gzz+ZKDtm4HoTlDjSIB+kFul5tIcrpmTgYtHDpE5Qaek081bY4oa9DA1gkXu5CJl/+FWkF8mnOjQL",
+	"53KQSDT1WWyG0rTIvhA8vgBGFCVBbvREbhniInq0GI97bbSXg+VI4YKayTiRET3RPpm42V6fWLU3HDNo",
+	"e9bEzLV36E0G33r2xRb7BCblIXy6hzLIb65dr1GE8NXSXKVUTV25+FikdgmFsayGoUEfrNod1yMxLQNl",
+	"FmxbyBysBW6t4Zl3AkOzGnZgNeCCgf6Dc7RYKizX7D/sGBpuVo5ejQe5TNN4ScFKfJlAtdokTx5qG8gq",
+	"oUUW3Gy6kJyE2xfjCsdJLfw3emDSWyQHGaRufHXCAI0Sqf+wqCwgfDwLHmQoMyefoOGzY+vDfahDqBGh",
+	"JMbFANAMLWcCvxb+SBYd38KSV+FegknhIZGY6TOHZPcfgh8gVSUKlh2dFSTcDZLKN2jlzqC2pr3wLDFx",
+	"ajhtWiYuESCW0ycTeO8nSttIlAxjOKVqA24uFucmVm8WEaMRoujwvcN3SN7bAS7Q0/xNc+baO//4h3ah",
+	"cKW+ad2FPyz1TFPsPLj96mj66uXnPVB4Ch0zs27oFuz/rJDcgIihHdRdWt5Rt3zfakgzIGbWP/ns99Pb",
+	"S+/WK/nvlISuMi6TPwt+K1pZDYBl/fH68YufLiwX5Y58ogC0LfMe6X/9Um+S2GMXW2QCG8Gap8eEwlS+",
+	"sVSsrlVKmav2KcVrrqcF7meWM/Z+YodCNRy0VdzlmyryFbeivAX78tWbJLGyWqngWfH2SNyqupfRpTF/",
+	"we2YZk2mimdDHYtcb0qyxz0xXVte4gosGD0RqBO8oHx/C6JW81lWTTPLsvqLYJCg6xQojqa5PlFFJJmC",
+	"ROANBlKLRy2p6vKHpaWUIbWIOkBrBPdGu4koH8wpQ8PgoIYGPaKJIUTtoHNbQfBSjRpw2CBaMNffJ64T",
+	"fntfkJloLIT/WPDiRjv4WFgn9T3BwY8bE2NDH30VdjSwA0nXoRMKCpWy/QTjbLESdykiLvsXFOdSHWHr",
+	"883VkzEBBqKE3gz7QRPuZlnLIQyC+S39WXJvIBTAKivJbNBV+jnQwjFcdaNdfKlVTRPdX2lqXGw2dKKv",
+	"khT1JUmme04OSyuulCe18K+Z3ZHos+ewbvmBJeWllzAX1WjO4D+SXeR/R5a1DyTwzWXe4k2KXpxMeqAB",
+	"ftmJls8mVhvE1ADuDVW/mrA+m+ZLsD6b5lisz/Dn+PpG3cG7SFSxBlRC55/s+YBQ+w2Yl8DOnHWZlrJD",
+	"2Jjv3vmnf5lZaFUK5upbG/OtjZl/A/4es+GbZl8m7IO3pqba1Fww85iafGQiVxxpVfjBWN27qbkME6/h",
+	"5zWwk7g4Us4EWDHyNTiZVHheI66vhBqWHI8atV30fPXFEAYzIleWV6sTgpsHZSHQSMYx/Os+Wq+hufV6",
+	"24PuI4GhNczAfDCpSe8WJOwhOm/hzSxzIdrVpA4nZMPRYkc0tG/VPSsgaSXIZXeCWVPhfjLi0cEoAbsq",
+	"2pUYNYYkPEPadCrDmQk/Icaih9vAGYH7ULsT+fQUZq7GwV3sRoQiJOK/jP2sdNNQPeILVt/6DPdU72vX",
+	"8ABnsLJ+ePS+Ft+ZtKt37wpQLFcLv0PG6NWZ9wwxhYg0skn49bkAFWDb40sRgU+K4UXBQPEFKo4bjtLW",
+	"fGjWiUO4mF6jl8IDRmOAscF/iXYp2iXqC/KToOc4TKGDiOHyK+hVqpaeoyZ7wwFB46h0mwDL82usekg0",
+	"HSdBg0LwDnMiMqKv1L0ryNwXe5rW7UkyILQ0xfrCBg7LTAEnM8spvoGrq0j8ygLtZIr3UaupBcvZCDb1",
+	"2ekCagfQsh32gSwTHLchHZCj6TV1NsirT/rmtdBIWu7B8IqrI+R+jzn9aHXt+upcpbxSLS8vZRu4yWW8",
+	"7EyktcqCoYVnnLijeig2dRNIh+lEUZnOpzuLLTnWtzSPolfbYbnSpKUiZcRE6TwUOlKiyzgTnxNkTBJR",
+	"LwrVaYR8mFQSNPeGaI9v7twFs+nXOH8Gpx4ncmgUZPWAfXyfOlIw3PwDg32AE8q4DziUc+Hzm5bZDDb5",
+	"T1YDM7D9wK4Lz7EJPLj94P8PAG9UkaqWPAEA",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
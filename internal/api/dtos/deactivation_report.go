@@ -0,0 +1,24 @@
+package dtos
+
+type DeactivationReport struct {
+	DeactivatedUsers []string              `json:"deactivated_users"`
+	Reassigned       []ReviewerReplacement `json:"reassigned"`
+	TeamName         string                `json:"team_name"`
+	Unassigned       []UnassignedReview    `json:"unassigned"`
+}
+
+type ReviewReassignment struct {
+	Reassigned []ReviewerReplacement `json:"reassigned"`
+	Unassigned []UnassignedReview    `json:"unassigned"`
+}
+
+type ReviewerReplacement struct {
+	NewUserId     string `json:"new_user_id"`
+	OldUserId     string `json:"old_user_id"`
+	PullRequestId string `json:"pull_request_id"`
+}
+
+type UnassignedReview struct {
+	PullRequestId string `json:"pull_request_id"`
+	UserId        string `json:"user_id"`
+}
@@ -0,0 +1,14 @@
+package dtos
+
+import "time"
+
+// Event is the body posted to webhook subscribers.
+type Event struct {
+	Data       any       `json:"data"`
+	OccurredAt time.Time `json:"occurred_at"`
+	Type       string    `json:"type"`
+}
+
+type PullRequestEvent struct {
+	PullRequest PullRequest `json:"pull_request"`
+}
@@ -9,7 +9,7 @@ func ModelToPullRequestDTO(pr *models.PullRequest, statusName string) *PullReque
 	pullRequestID := encoding.EncodeID(pr.ID)
 	authorID := encoding.EncodeID(pr.AuthorID)
 
-	return &PullRequest{
+	dto := &PullRequest{
 		PullRequestId:     pullRequestID,
 		PullRequestName:   pr.Title,
 		AuthorId:          authorID,
@@ -17,8 +17,78 @@ func ModelToPullRequestDTO(pr *models.PullRequest, statusName string) *PullReque
 		AssignedReviewers: idsToStrings(pr.ReviewersIDs),
 		CreatedAt:         &pr.CreatedAt,
 		MergedAt:          pr.MergedAt,
+		ClosedAt:          pr.ClosedAt,
+		Reviews:           reviewsToDTOs(pr),
 	}
+	if pr.TeamName != nil {
+		dto.TeamName = *pr.TeamName
+	}
+	return dto
 }
+
+// reviewsToDTOs lists a review for every current reviewer. Reviewers that
+// were assigned after pr was loaded have no recorded review yet.
+func reviewsToDTOs(pr *models.PullRequest) []Review {
+	recorded := make(map[int64]models.Review, len(pr.Reviews))
+	for _, r := range pr.Reviews {
+		recorded[r.ReviewerID] = r
+	}
+
+	reviews := make([]Review, len(pr.ReviewersIDs))
+	for i, id := range pr.ReviewersIDs {
+		r := recorded[id]
+		reviews[i] = Review{
+			UserId:     encoding.EncodeID(id),
+			AssignedAt: r.AssignedAt,
+			DecidedAt:  r.DecidedAt,
+		}
+		if r.Decision != nil {
+			reviews[i].Decision = ReviewDecision(*r.Decision)
+		}
+	}
+	return reviews
+}
+
+func ModelToPullRequestExportDTO(pr *models.PullRequestExport) *PullRequestExport {
+	export := &PullRequestExport{
+		PullRequestId:   encoding.EncodeID(pr.ID),
+		PullRequestName: pr.Title,
+		AuthorId:        encoding.EncodeID(pr.AuthorID),
+		Status:          pr.Status,
+		Reviewers:       idsToStrings(pr.ReviewerIDs),
+		CreatedAt:       pr.CreatedAt,
+		FirstReviewedAt: pr.FirstReviewedAt,
+		MergedAt:        pr.MergedAt,
+		ClosedAt:        pr.ClosedAt,
+		Reassignments:   pr.Reassignments,
+	}
+	if pr.TeamName != nil {
+		export.TeamName = *pr.TeamName
+	}
+	return export
+}
+
+func ModelToReviewAssignmentExportDTO(a *models.ReviewAssignmentExport) *ReviewAssignmentExport {
+	export := &ReviewAssignmentExport{
+		PullRequestId: encoding.EncodeID(a.PullRequestID),
+		Status:        a.Status,
+		ReviewerId:    encoding.EncodeID(a.ReviewerID),
+		AssignedAt:    a.AssignedAt,
+		DecidedAt:     a.DecidedAt,
+		ReassignedAt:  a.ReassignedAt,
+	}
+	if a.TeamName != nil {
+		export.TeamName = *a.TeamName
+	}
+	if a.Decision != nil {
+		export.Decision = *a.Decision
+	}
+	if a.ReplacedBy != nil {
+		export.ReplacedBy = encoding.EncodeID(*a.ReplacedBy)
+	}
+	return export
+}
+
 func idsToStrings(ids []int64) []string {
 	strings := make([]string, len(ids))
 	for i, id := range ids {
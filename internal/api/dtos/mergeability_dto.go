@@ -0,0 +1,21 @@
+package dtos
+
+import "time"
+
+type Mergeability struct {
+	Approvals          int               `json:"approvals"`
+	ChangesRequestedBy []string          `json:"changes_requested_by"`
+	Mergeable          bool              `json:"mergeable"`
+	Overrides          []MergeOverride   `json:"overrides"`
+	PullRequestId      string            `json:"pull_request_id"`
+	RequiredApprovals  int               `json:"required_approvals"`
+	Status             PullRequestStatus `json:"status"`
+	UnmetConditions    []string          `json:"unmet_conditions"`
+}
+
+type MergeOverride struct {
+	CreatedAt       time.Time `json:"created_at"`
+	ForcedBy        string    `json:"forced_by,omitempty"`
+	Reason          string    `json:"reason"`
+	UnmetConditions []string  `json:"unmet_conditions"`
+}
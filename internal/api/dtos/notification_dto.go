@@ -0,0 +1,26 @@
+package dtos
+
+import "time"
+
+type TeamChatChannel struct {
+	Channel   string    `json:"channel"`
+	Provider  string    `json:"provider"`
+	TeamName  string    `json:"team_name"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type NotificationPreferences struct {
+	ChatEnabled  bool   `json:"chat_enabled"`
+	EmailEnabled bool   `json:"email_enabled"`
+	UserId       string `json:"user_id"`
+}
+
+// NotificationPreferencesUpdate changes only the preferences that are set.
+type NotificationPreferencesUpdate struct {
+	ChatEnabled  *bool
+	EmailEnabled *bool
+}
+
+type DigestResult struct {
+	Sent int `json:"sent"`
+}
@@ -3,19 +3,39 @@ package dtos
 import "time"
 
 type PullRequest struct {
-	AssignedReviewers []string          `json:"assigned_reviewers"`
-	AuthorId          string            `json:"author_id"`
-	CreatedAt         *time.Time        `json:"createdAt"`
-	MergedAt          *time.Time        `json:"mergedAt"`
-	PullRequestId     string            `json:"pull_request_id"`
-	PullRequestName   string            `json:"pull_request_name"`
-	Status            PullRequestStatus `json:"status"`
+	AssignedReviewers []string           `json:"assigned_reviewers"`
+	AuthorId          string             `json:"author_id"`
+	ClosedAt          *time.Time         `json:"closedAt"`
+	CreatedAt         *time.Time         `json:"createdAt"`
+	Draft             bool               `json:"draft,omitempty"`
+	FallbackReviewers []FallbackReviewer `json:"fallback_reviewers,omitempty"`
+	MergedAt          *time.Time         `json:"mergedAt"`
+	PullRequestId     string             `json:"pull_request_id"`
+	PullRequestName   string             `json:"pull_request_name"`
+	Reviews           []Review           `json:"reviews"`
+	Status            PullRequestStatus  `json:"status"`
+	TeamName          string             `json:"team_name,omitempty"`
+}
+
+type ReviewDecision string
+
+type Review struct {
+	AssignedAt *time.Time     `json:"assigned_at"`
+	DecidedAt  *time.Time     `json:"decided_at"`
+	Decision   ReviewDecision `json:"decision,omitempty"`
+	UserId     string         `json:"user_id"`
+}
+
+type FallbackReviewer struct {
+	TeamName string `json:"team_name"`
+	UserId   string `json:"user_id"`
 }
 
 type PullRequestStatus string
 
 type PullRequestShort struct {
 	AuthorId        string            `json:"author_id"`
+	AwaitingAction  bool              `json:"awaiting_action"`
 	PullRequestId   string            `json:"pull_request_id"`
 	PullRequestName string            `json:"pull_request_name"`
 	Status          PullRequestStatus `json:"status"`
@@ -0,0 +1,15 @@
+package dtos
+
+import "time"
+
+// ReviewReminder is the data of a reminder about a review still waiting for
+// the reviewer's decision.
+type ReviewReminder struct {
+	AssignedAt      time.Time `json:"assigned_at"`
+	AuthorId        string    `json:"author_id"`
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	Reminder        int       `json:"reminder"`
+	ReviewerId      string    `json:"reviewer_id"`
+	WaitingHours    float64   `json:"waiting_hours"`
+}
@@ -0,0 +1,38 @@
+package dtos
+
+import "time"
+
+type TeamReviewSLA struct {
+	AutoReassign       bool      `json:"auto_reassign"`
+	FirstDecisionHours float64   `json:"first_decision_hours"`
+	TeamName           string    `json:"team_name"`
+	Timezone           string    `json:"timezone"`
+	UpdatedAt          time.Time `json:"updated_at"`
+	WorkDays           []int     `json:"work_days"`
+	WorkEnd            string    `json:"work_end"`
+	WorkStart          string    `json:"work_start"`
+}
+
+// TeamReviewSLAUpdate replaces a team's SLA; the working hours left unset
+// take their defaults.
+type TeamReviewSLAUpdate struct {
+	TeamName           string
+	FirstDecisionHours float64
+	WorkStart          *string
+	WorkEnd            *string
+	WorkDays           []int
+	Timezone           *string
+	AutoReassign       *bool
+}
+
+type OverdueReview struct {
+	AgeHours        float64   `json:"age_hours"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	ReviewerId      string    `json:"reviewer_id"`
+	Severity        string    `json:"severity"`
+	SlaHours        float64   `json:"sla_hours"`
+	TeamName        string    `json:"team_name"`
+	WorkingHours    float64   `json:"working_hours"`
+}
@@ -1,10 +1,29 @@
 package dtos
 
+import "time"
+
+// StatsFilter narrows the statistics down to a team and to [From, To); the
+// fields left unset do not filter.
+type StatsFilter struct {
+	From     *time.Time
+	To       *time.Time
+	TeamName string
+}
+
 type StatsResponse struct {
-	TotalPullRequests  int             `json:"total_pull_requests"`
-	OpenPullRequests   int             `json:"open_pull_requests"`
-	MergedPullRequests int             `json:"merged_pull_requests"`
-	ReviewerStats      []ReviewerStats `json:"reviewer_stats"`
+	From               *time.Time        `json:"from,omitempty"`
+	To                 *time.Time        `json:"to,omitempty"`
+	TeamName           string            `json:"team_name,omitempty"`
+	TotalPullRequests  int               `json:"total_pull_requests"`
+	DraftPullRequests  int               `json:"draft_pull_requests"`
+	OpenPullRequests   int               `json:"open_pull_requests"`
+	MergedPullRequests int               `json:"merged_pull_requests"`
+	ClosedPullRequests int               `json:"closed_pull_requests"`
+	TimeToFirstReview  LatencyStats      `json:"time_to_first_review"`
+	TimeToMerge        LatencyStats      `json:"time_to_merge"`
+	Reassignments      ReassignmentStats `json:"reassignments"`
+	Throughput         []DailyThroughput `json:"throughput"`
+	ReviewerStats      []ReviewerStats   `json:"reviewer_stats"`
 }
 
 type ReviewerStats struct {
@@ -12,3 +31,55 @@ type ReviewerStats struct {
 	Username      string `json:"username"`
 	AssignedCount int    `json:"assigned_count"`
 }
+
+type LatencyStats struct {
+	Count    int      `json:"count"`
+	P50Hours *float64 `json:"p50_hours,omitempty"`
+	P90Hours *float64 `json:"p90_hours,omitempty"`
+	P99Hours *float64 `json:"p99_hours,omitempty"`
+}
+
+type ReassignmentStats struct {
+	Total        int `json:"total"`
+	PullRequests int `json:"pull_requests"`
+}
+
+type DailyThroughput struct {
+	Date   string `json:"date"`
+	Opened int    `json:"opened"`
+	Merged int    `json:"merged"`
+}
+
+type TeamWorkload struct {
+	AssignmentsGini float64        `json:"assignments_gini"`
+	Reviewers       []ReviewerLoad `json:"reviewers"`
+	TeamName        string         `json:"team_name"`
+}
+
+type ReviewerLoad struct {
+	AssignedReviews           int      `json:"assigned_reviews"`
+	AverageOpenReviewAgeHours *float64 `json:"average_open_review_age_hours,omitempty"`
+	IsActive                  bool     `json:"is_active"`
+	MergedReviews             int      `json:"merged_reviews"`
+	OpenReviews               int      `json:"open_reviews"`
+	ReviewerID                string   `json:"reviewer_id"`
+	Username                  string   `json:"username"`
+}
+
+type ReviewerWorkload struct {
+	AssignedReviews int                `json:"assigned_reviews"`
+	MergedReviews   int                `json:"merged_reviews"`
+	OpenReviews     int                `json:"open_reviews"`
+	ReviewerID      string             `json:"reviewer_id"`
+	Teams           []ReviewerTeamLoad `json:"teams"`
+	Username        string             `json:"username"`
+}
+
+type ReviewerTeamLoad struct {
+	AssignedReviews           int      `json:"assigned_reviews"`
+	AssignmentShare           float64  `json:"assignment_share"`
+	AverageOpenReviewAgeHours *float64 `json:"average_open_review_age_hours,omitempty"`
+	MergedReviews             int      `json:"merged_reviews"`
+	OpenReviews               int      `json:"open_reviews"`
+	TeamName                  string   `json:"team_name"`
+}
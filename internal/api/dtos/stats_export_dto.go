@@ -0,0 +1,29 @@
+package dtos
+
+import "time"
+
+type PullRequestExport struct {
+	PullRequestId   string     `json:"pull_request_id"`
+	PullRequestName string     `json:"pull_request_name"`
+	AuthorId        string     `json:"author_id"`
+	TeamName        string     `json:"team_name,omitempty"`
+	Status          string     `json:"status"`
+	Reviewers       []string   `json:"reviewers"`
+	CreatedAt       time.Time  `json:"created_at"`
+	FirstReviewedAt *time.Time `json:"first_reviewed_at,omitempty"`
+	MergedAt        *time.Time `json:"merged_at,omitempty"`
+	ClosedAt        *time.Time `json:"closed_at,omitempty"`
+	Reassignments   int        `json:"reassignments"`
+}
+
+type ReviewAssignmentExport struct {
+	PullRequestId string     `json:"pull_request_id"`
+	TeamName      string     `json:"team_name,omitempty"`
+	Status        string     `json:"status"`
+	ReviewerId    string     `json:"reviewer_id"`
+	AssignedAt    *time.Time `json:"assigned_at,omitempty"`
+	Decision      string     `json:"decision,omitempty"`
+	DecidedAt     *time.Time `json:"decided_at,omitempty"`
+	ReassignedAt  *time.Time `json:"reassigned_at,omitempty"`
+	ReplacedBy    string     `json:"replaced_by,omitempty"`
+}
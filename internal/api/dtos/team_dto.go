@@ -10,3 +10,26 @@ type TeamMember struct {
 	UserId   string `json:"user_id"`
 	Username string `json:"username"`
 }
+
+type TeamSettings struct {
+	BackupTeams       []string `json:"backup_teams"`
+	MaxReviewers      int      `json:"max_reviewers"`
+	MinReviewers      int      `json:"min_reviewers"`
+	RequiredApprovals int      `json:"required_approvals"`
+	ReviewerStrategy  string   `json:"reviewer_strategy"`
+	TeamName          string   `json:"team_name"`
+}
+
+type TeamSettingsUpdate struct {
+	BackupTeams       *[]string `json:"backup_teams,omitempty"`
+	MaxReviewers      *int      `json:"max_reviewers,omitempty"`
+	MinReviewers      *int      `json:"min_reviewers,omitempty"`
+	RequiredApprovals *int      `json:"required_approvals,omitempty"`
+	ReviewerStrategy  *string   `json:"reviewer_strategy,omitempty"`
+	TeamName          string    `json:"team_name"`
+
+	// ResetMinReviewers and ResetMaxReviewers are set by an explicit null,
+	// which returns the limit to the service default.
+	ResetMaxReviewers bool `json:"-"`
+	ResetMinReviewers bool `json:"-"`
+}
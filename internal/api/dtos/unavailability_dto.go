@@ -0,0 +1,18 @@
+package dtos
+
+import "time"
+
+type Unavailability struct {
+	EndsAt   time.Time `json:"ends_at"`
+	Id       string    `json:"id"`
+	Reason   string    `json:"reason"`
+	Source   string    `json:"source"`
+	StartsAt time.Time `json:"starts_at"`
+	UserId   string    `json:"user_id"`
+}
+
+type CalendarImportResult struct {
+	Imported int    `json:"imported"`
+	Removed  int    `json:"removed"`
+	UserId   string `json:"user_id"`
+}
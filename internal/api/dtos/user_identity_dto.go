@@ -0,0 +1,10 @@
+package dtos
+
+import "time"
+
+type UserIdentity struct {
+	CreatedAt  time.Time `json:"created_at"`
+	ExternalId string    `json:"external_id"`
+	Provider   string    `json:"provider"`
+	UserId     string    `json:"user_id"`
+}
@@ -0,0 +1,9 @@
+package dtos
+
+type WebhookResult struct {
+	Action        string `json:"action,omitempty"`
+	Event         string `json:"event"`
+	Outcome       string `json:"outcome"`
+	PullRequestId string `json:"pull_request_id,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
@@ -0,0 +1,23 @@
+package dtos
+
+import "time"
+
+type WebhookSubscription struct {
+	CreatedAt time.Time `json:"created_at"`
+	Events    []string  `json:"events"`
+	Id        string    `json:"id"`
+	Url       string    `json:"url"`
+}
+
+type WebhookDelivery struct {
+	Attempts       int        `json:"attempts"`
+	CreatedAt      time.Time  `json:"created_at"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	Event          string     `json:"event"`
+	Id             string     `json:"id"`
+	LastError      string     `json:"last_error,omitempty"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	Status         string     `json:"status"`
+	SubscriptionId string     `json:"subscription_id"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"mime"
+	"net/http"
+	"pullrequest-inator/internal/api/dtos"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+const (
+	exportFormatCSV    = "text/csv"
+	exportFormatNDJSON = "application/x-ndjson"
+)
+
+var (
+	pullRequestExportHeader = []string{
+		"pull_request_id", "pull_request_name", "author_id", "team_name", "status", "reviewers", "created_at",
+		"first_reviewed_at", "merged_at", "closed_at", "reassignments",
+	}
+	reviewAssignmentExportHeader = []string{
+		"pull_request_id", "team_name", "status", "reviewer_id", "assigned_at", "decision", "decided_at",
+		"reassigned_at", "replaced_by",
+	}
+)
+
+// negotiateExportFormat picks the export format the Accept header prefers,
+// NDJSON when it accepts both equally or is missing. A media range that names
+// a format exactly outweighs a wildcard one, whatever their order.
+func negotiateExportFormat(accept string) (string, bool) {
+	if strings.TrimSpace(accept) == "" {
+		return exportFormatNDJSON, true
+	}
+
+	best, bestQ, bestSpecificity := "", 0.0, -1
+	for _, format := range []string{exportFormatNDJSON, exportFormatCSV} {
+		q, specificity := acceptQuality(accept, format)
+		if q > bestQ || (q == bestQ && q > 0 && specificity > bestSpecificity) {
+			best, bestQ, bestSpecificity = format, q, specificity
+		}
+	}
+	return best, best != ""
+}
+
+// acceptQuality returns the quality accept gives to format, taken from the
+// most specific media range that matches it, and how specific that range is.
+func acceptQuality(accept, format string) (float64, int) {
+	formatType, _, _ := strings.Cut(format, "/")
+
+	q, specificity := 0.0, -1
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, params, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+
+		s := -1
+		switch {
+		case mediaType == format:
+			s = 2
+		case mediaType == formatType+"/*":
+			s = 1
+		case mediaType == "*/*":
+			s = 0
+		}
+		if s <= specificity {
+			continue
+		}
+
+		rangeQ := 1.0
+		if v, ok := params["q"]; ok {
+			if rangeQ, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		q, specificity = rangeQ, s
+	}
+	return q, specificity
+}
+
+// exportWriter streams export rows to the response as they come, so memory
+// does not grow with the number of rows. The response is committed with the
+// first row, or on close if there are none.
+type exportWriter struct {
+	ctx      echo.Context
+	format   string
+	filename string
+	header   []string
+	csv      *csv.Writer
+	json     *json.Encoder
+}
+
+func newExportWriter(ctx echo.Context, format, name string, header []string) *exportWriter {
+	w := &exportWriter{ctx: ctx, format: format, header: header, filename: name + ".ndjson"}
+	if format == exportFormatCSV {
+		w.filename = name + ".csv"
+	}
+	return w
+}
+
+func (w *exportWriter) start() error {
+	resp := w.ctx.Response()
+	contentType := w.format
+	if w.format == exportFormatCSV {
+		contentType += "; charset=utf-8"
+	}
+	resp.Header().Set(echo.HeaderContentType, contentType)
+	resp.Header().Set(echo.HeaderContentDisposition, mime.FormatMediaType("attachment",
+		map[string]string{"filename": w.filename}))
+	resp.Header().Add(echo.HeaderVary, echo.HeaderAccept)
+	resp.WriteHeader(http.StatusOK)
+
+	if w.format == exportFormatCSV {
+		w.csv = csv.NewWriter(resp)
+		return w.csv.Write(w.header)
+	}
+	w.json = json.NewEncoder(resp)
+	return nil
+}
+
+// write sends v as an NDJSON line or record as a CSV row, by the format.
+func (w *exportWriter) write(v any, record func() []string) error {
+	if !w.ctx.Response().Committed {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	if w.csv != nil {
+		return w.csv.Write(record())
+	}
+	return w.json.Encode(v)
+}
+
+func (w *exportWriter) close() error {
+	if !w.ctx.Response().Committed {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+
+	if w.csv != nil {
+		w.csv.Flush()
+		return w.csv.Error()
+	}
+	return nil
+}
+
+// exportFailed reports an export error. Before the response is committed it
+// is an ordinary error response; after it, the 200 is already out, so the
+// connection is aborted instead, and the client sees a truncated download
+// rather than a complete-looking one.
+func exportFailed(ctx echo.Context, err error) error {
+	if !ctx.Response().Committed {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+	panic(http.ErrAbortHandler)
+}
+
+func pullRequestExportRecord(pr *dtos.PullRequestExport) []string {
+	return []string{
+		pr.PullRequestId,
+		csvText(pr.PullRequestName),
+		pr.AuthorId,
+		csvText(pr.TeamName),
+		pr.Status,
+		strings.Join(pr.Reviewers, " "),
+		formatExportTime(&pr.CreatedAt),
+		formatExportTime(pr.FirstReviewedAt),
+		formatExportTime(pr.MergedAt),
+		formatExportTime(pr.ClosedAt),
+		strconv.Itoa(pr.Reassignments),
+	}
+}
+
+func reviewAssignmentExportRecord(a *dtos.ReviewAssignmentExport) []string {
+	return []string{
+		a.PullRequestId,
+		csvText(a.TeamName),
+		a.Status,
+		a.ReviewerId,
+		formatExportTime(a.AssignedAt),
+		a.Decision,
+		formatExportTime(a.DecidedAt),
+		formatExportTime(a.ReassignedAt),
+		a.ReplacedBy,
+	}
+}
+
+// csvText keeps free text from being read as a formula by spreadsheets,
+// prefixing the cells starting like one with an apostrophe.
+func csvText(s string) string {
+	if s != "" && strings.ContainsRune("=+-@\t\r", rune(s[0])) {
+		return "'" + s
+	}
+	return s
+}
+
+func formatExportTime(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
@@ -1,6 +1,8 @@
 package api
 
 import (
+	"bytes"
+	"encoding/json"
 	"pullrequest-inator/internal/api/dtos"
 )
 
@@ -44,17 +46,102 @@ func FromAPITeamMember(m TeamMember) dtos.TeamMember {
 	}
 }
 
+func ToAPITeamSettings(d dtos.TeamSettings) TeamSettings {
+	return TeamSettings{
+		TeamName:          d.TeamName,
+		ReviewerStrategy:  ReviewerStrategy(d.ReviewerStrategy),
+		MinReviewers:      d.MinReviewers,
+		MaxReviewers:      d.MaxReviewers,
+		RequiredApprovals: d.RequiredApprovals,
+		BackupTeams:       d.BackupTeams,
+	}
+}
+
+// FromAPITeamSettingsUpdate maps the update, fields being the raw request
+// object to tell the limits given as null from the ones left out.
+func FromAPITeamSettingsUpdate(in PostTeamSettingsJSONRequestBody, fields map[string]json.RawMessage) *dtos.TeamSettingsUpdate {
+	update := &dtos.TeamSettingsUpdate{
+		TeamName:          in.TeamName,
+		MinReviewers:      in.MinReviewers,
+		MaxReviewers:      in.MaxReviewers,
+		RequiredApprovals: in.RequiredApprovals,
+		BackupTeams:       in.BackupTeams,
+		ResetMinReviewers: isJSONNull(fields["min_reviewers"]),
+		ResetMaxReviewers: isJSONNull(fields["max_reviewers"]),
+	}
+	if in.ReviewerStrategy != nil {
+		strategy := string(*in.ReviewerStrategy)
+		update.ReviewerStrategy = &strategy
+	}
+	return update
+}
+
+func isJSONNull(v json.RawMessage) bool {
+	return bytes.Equal(bytes.TrimSpace(v), []byte("null"))
+}
+
+func ToAPITeamReviewSLA(d dtos.TeamReviewSLA) TeamReviewSLA {
+	return TeamReviewSLA{
+		TeamName:           d.TeamName,
+		FirstDecisionHours: d.FirstDecisionHours,
+		WorkStart:          d.WorkStart,
+		WorkEnd:            d.WorkEnd,
+		WorkDays:           d.WorkDays,
+		Timezone:           d.Timezone,
+		AutoReassign:       d.AutoReassign,
+		UpdatedAt:          d.UpdatedAt,
+	}
+}
+
+func FromAPITeamReviewSLAUpdate(in PostTeamSlaJSONRequestBody) *dtos.TeamReviewSLAUpdate {
+	update := &dtos.TeamReviewSLAUpdate{
+		TeamName:           in.TeamName,
+		FirstDecisionHours: in.FirstDecisionHours,
+		WorkStart:          in.WorkStart,
+		WorkEnd:            in.WorkEnd,
+		Timezone:           in.Timezone,
+		AutoReassign:       in.AutoReassign,
+	}
+	if in.WorkDays != nil {
+		update.WorkDays = *in.WorkDays
+	}
+	return update
+}
+
+func ToAPIOverdueReview(d dtos.OverdueReview) OverdueReview {
+	return OverdueReview{
+		PullRequestId:   d.PullRequestId,
+		PullRequestName: d.PullRequestName,
+		TeamName:        d.TeamName,
+		ReviewerId:      d.ReviewerId,
+		AssignedAt:      d.AssignedAt,
+		AgeHours:        d.AgeHours,
+		WorkingHours:    d.WorkingHours,
+		SlaHours:        d.SlaHours,
+		Severity:        OverdueReviewSeverity(d.Severity),
+	}
+}
+
+func ToAPITeamChatChannel(d dtos.TeamChatChannel) TeamChatChannel {
+	return TeamChatChannel{
+		TeamName:  d.TeamName,
+		Provider:  ChatProvider(d.Provider),
+		Channel:   d.Channel,
+		UpdatedAt: d.UpdatedAt,
+	}
+}
+
 func ToAPIUser(u dtos.User) User {
 	return User{
 		UserId:   u.UserId,
 		Username: u.Username,
-		TeamName: u.TeamName,
+		Teams:    u.TeamNames,
 		IsActive: u.IsActive,
 	}
 }
 
 func ToAPIPullRequest(d dtos.PullRequest) PullRequest {
-	return PullRequest{
+	pr := PullRequest{
 		PullRequestId:     d.PullRequestId,
 		PullRequestName:   d.PullRequestName,
 		AuthorId:          d.AuthorId,
@@ -62,22 +149,212 @@ func ToAPIPullRequest(d dtos.PullRequest) PullRequest {
 		AssignedReviewers: d.AssignedReviewers,
 		CreatedAt:         d.CreatedAt,
 		MergedAt:          d.MergedAt,
+		ClosedAt:          d.ClosedAt,
+	}
+
+	if d.TeamName != "" {
+		pr.TeamName = &d.TeamName
+	}
+
+	if len(d.FallbackReviewers) > 0 {
+		fallback := make([]FallbackReviewer, len(d.FallbackReviewers))
+		for i, f := range d.FallbackReviewers {
+			fallback[i] = FallbackReviewer{
+				UserId:   f.UserId,
+				TeamName: f.TeamName,
+			}
+		}
+		pr.FallbackReviewers = &fallback
+	}
+
+	if d.Reviews != nil {
+		reviews := ToAPIReviews(d.Reviews)
+		pr.Reviews = &reviews
+	}
+
+	return pr
+}
+
+func ToAPIReviews(list []dtos.Review) []Review {
+	out := make([]Review, len(list))
+	for i, r := range list {
+		out[i] = Review{
+			UserId:     r.UserId,
+			AssignedAt: r.AssignedAt,
+			DecidedAt:  r.DecidedAt,
+		}
+		if r.Decision != "" {
+			decision := ReviewDecision(r.Decision)
+			out[i].Decision = &decision
+		}
 	}
+	return out
 }
 
-func ToAPIPullRequestShort(d dtos.PullRequest) PullRequestShort {
+func ToAPIPullRequestShort(d dtos.PullRequestShort) PullRequestShort {
 	return PullRequestShort{
 		PullRequestId:   d.PullRequestId,
 		PullRequestName: d.PullRequestName,
 		AuthorId:        d.AuthorId,
 		Status:          PullRequestShortStatus(d.Status),
+		AwaitingAction:  d.AwaitingAction,
 	}
 }
 
-func ToAPIPullRequestShortList(list []*dtos.PullRequest) []PullRequestShort {
+func ToAPIPullRequestShortList(list []dtos.PullRequestShort) []PullRequestShort {
 	out := make([]PullRequestShort, len(list))
 	for i, pr := range list {
-		out[i] = ToAPIPullRequestShort(*pr)
+		out[i] = ToAPIPullRequestShort(pr)
 	}
 	return out
 }
+
+func ToAPIDeactivationReport(d dtos.DeactivationReport) DeactivationReport {
+	return DeactivationReport{
+		TeamName:         d.TeamName,
+		DeactivatedUsers: d.DeactivatedUsers,
+		Reassigned:       ToAPIReviewerReplacements(d.Reassigned),
+		Unassigned:       ToAPIUnassignedReviews(d.Unassigned),
+	}
+}
+
+func ToAPIReviewerReplacements(in []dtos.ReviewerReplacement) []ReviewerReplacement {
+	out := make([]ReviewerReplacement, len(in))
+	for i, r := range in {
+		out[i] = ReviewerReplacement{
+			PullRequestId: r.PullRequestId,
+			OldUserId:     r.OldUserId,
+			NewUserId:     r.NewUserId,
+		}
+	}
+	return out
+}
+
+func ToAPIUnassignedReviews(in []dtos.UnassignedReview) []UnassignedReview {
+	out := make([]UnassignedReview, len(in))
+	for i, u := range in {
+		out[i] = UnassignedReview{
+			PullRequestId: u.PullRequestId,
+			UserId:        u.UserId,
+		}
+	}
+	return out
+}
+
+func ToAPIUnavailability(d dtos.Unavailability) Unavailability {
+	return Unavailability{
+		Id:       d.Id,
+		UserId:   d.UserId,
+		StartsAt: d.StartsAt,
+		EndsAt:   d.EndsAt,
+		Reason:   d.Reason,
+		Source:   UnavailabilitySource(d.Source),
+	}
+}
+
+func ToAPIUserIdentity(d dtos.UserIdentity) UserIdentity {
+	return UserIdentity{
+		UserId:     d.UserId,
+		Provider:   IdentityProvider(d.Provider),
+		ExternalId: d.ExternalId,
+		CreatedAt:  d.CreatedAt,
+	}
+}
+
+func ToAPINotificationPreferences(d dtos.NotificationPreferences) NotificationPreferences {
+	return NotificationPreferences{
+		UserId:       d.UserId,
+		ChatEnabled:  d.ChatEnabled,
+		EmailEnabled: d.EmailEnabled,
+	}
+}
+
+func FromAPINotificationPreferencesUpdate(in PostUsersNotificationsJSONRequestBody) *dtos.NotificationPreferencesUpdate {
+	return &dtos.NotificationPreferencesUpdate{
+		ChatEnabled:  in.ChatEnabled,
+		EmailEnabled: in.EmailEnabled,
+	}
+}
+
+func ToAPICalendarImportResult(d dtos.CalendarImportResult) CalendarImportResult {
+	return CalendarImportResult{
+		UserId:   d.UserId,
+		Imported: d.Imported,
+		Removed:  d.Removed,
+	}
+}
+
+func ToAPIMergeability(d dtos.Mergeability) Mergeability {
+	overrides := make([]MergeOverride, len(d.Overrides))
+	for i, o := range d.Overrides {
+		overrides[i] = MergeOverride{
+			CreatedAt:       o.CreatedAt,
+			Reason:          o.Reason,
+			UnmetConditions: o.UnmetConditions,
+		}
+		if o.ForcedBy != "" {
+			forcedBy := o.ForcedBy
+			overrides[i].ForcedBy = &forcedBy
+		}
+	}
+
+	return Mergeability{
+		PullRequestId:      d.PullRequestId,
+		Status:             MergeabilityStatus(d.Status),
+		Mergeable:          d.Mergeable,
+		Approvals:          d.Approvals,
+		RequiredApprovals:  d.RequiredApprovals,
+		ChangesRequestedBy: d.ChangesRequestedBy,
+		UnmetConditions:    d.UnmetConditions,
+		Overrides:          overrides,
+	}
+}
+
+func ToAPIWebhookResult(d dtos.WebhookResult) WebhookResult {
+	result := WebhookResult{
+		Event:   d.Event,
+		Outcome: WebhookResultOutcome(d.Outcome),
+	}
+	if d.Action != "" {
+		result.Action = &d.Action
+	}
+	if d.PullRequestId != "" {
+		result.PullRequestId = &d.PullRequestId
+	}
+	if d.Reason != "" {
+		result.Reason = &d.Reason
+	}
+	return result
+}
+
+func ToAPIWebhookSubscription(d dtos.WebhookSubscription) WebhookSubscription {
+	events := make([]EventType, len(d.Events))
+	for i, event := range d.Events {
+		events[i] = EventType(event)
+	}
+
+	return WebhookSubscription{
+		Id:        d.Id,
+		Url:       d.Url,
+		Events:    events,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
+func ToAPIWebhookDelivery(d dtos.WebhookDelivery) WebhookDelivery {
+	delivery := WebhookDelivery{
+		Id:             d.Id,
+		SubscriptionId: d.SubscriptionId,
+		Event:          EventType(d.Event),
+		Status:         WebhookDeliveryStatus(d.Status),
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.LastError != "" {
+		delivery.LastError = &d.LastError
+	}
+	return delivery
+}
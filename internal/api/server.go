@@ -1,22 +1,38 @@
 package api
 
 import (
+	"encoding/json"
 	"errors"
+	"io"
 	"net/http"
 	"pullrequest-inator/internal/api/dtos"
 	"pullrequest-inator/internal/infrastructure/encoding"
 	"pullrequest-inator/internal/infrastructure/services"
+	"strings"
+	"time"
 
 	"github.com/labstack/echo/v4"
 )
 
 type Server struct {
-	prService   *services.PullRequestService
-	teamService *services.TeamService
-	userService *services.UserService
+	prService             *services.PullRequestService
+	teamService           *services.TeamService
+	userService           *services.UserService
+	unavailabilityService *services.UnavailabilityService
+	identityService       *services.IdentityService
+	webhookService        *services.WebhookService
+	subscriptionService   *services.SubscriptionService
+	notificationService   *services.NotificationService
+	emailNotifier         *services.EmailNotifier
+	reviewSLAService      *services.ReviewSLAService
+	statsService          *services.StatsService
 }
 
-func NewServer(prService *services.PullRequestService, teamService *services.TeamService, userService *services.UserService) (*Server, error) {
+func NewServer(prService *services.PullRequestService, teamService *services.TeamService, userService *services.UserService,
+	unavailabilityService *services.UnavailabilityService, identityService *services.IdentityService,
+	webhookService *services.WebhookService, subscriptionService *services.SubscriptionService,
+	notificationService *services.NotificationService, emailNotifier *services.EmailNotifier,
+	reviewSLAService *services.ReviewSLAService, statsService *services.StatsService) (*Server, error) {
 	if prService == nil {
 		return nil, errors.New("prService is required")
 	}
@@ -26,11 +42,43 @@ func NewServer(prService *services.PullRequestService, teamService *services.Tea
 	if userService == nil {
 		return nil, errors.New("userService is required")
 	}
+	if unavailabilityService == nil {
+		return nil, errors.New("unavailabilityService is required")
+	}
+	if identityService == nil {
+		return nil, errors.New("identityService is required")
+	}
+	if webhookService == nil {
+		return nil, errors.New("webhookService is required")
+	}
+	if subscriptionService == nil {
+		return nil, errors.New("subscriptionService is required")
+	}
+	if notificationService == nil {
+		return nil, errors.New("notificationService is required")
+	}
+	if emailNotifier == nil {
+		return nil, errors.New("emailNotifier is required")
+	}
+	if reviewSLAService == nil {
+		return nil, errors.New("reviewSLAService is required")
+	}
+	if statsService == nil {
+		return nil, errors.New("statsService is required")
+	}
 
 	return &Server{
-		prService:   prService,
-		teamService: teamService,
-		userService: userService,
+		prService:             prService,
+		teamService:           teamService,
+		userService:           userService,
+		unavailabilityService: unavailabilityService,
+		identityService:       identityService,
+		webhookService:        webhookService,
+		subscriptionService:   subscriptionService,
+		notificationService:   notificationService,
+		emailNotifier:         emailNotifier,
+		reviewSLAService:      reviewSLAService,
+		statsService:          statsService,
 	}, nil
 }
 
@@ -51,6 +99,12 @@ func (s *Server) PostPullRequestCreate(ctx echo.Context) error {
 		PullRequestName: input.PullRequestName,
 		AuthorId:        input.AuthorId,
 	}
+	if input.TeamName != nil {
+		dtoReq.TeamName = *input.TeamName
+	}
+	if input.Draft != nil {
+		dtoReq.Draft = *input.Draft
+	}
 
 	pr, err := s.prService.CreatePullRequest(ctx.Request().Context(), dtoReq)
 	if err != nil {
@@ -74,8 +128,19 @@ func (s *Server) PostPullRequestMerge(ctx echo.Context) error {
 		})
 	}
 
+	var override *dtos.MergeOverride
+	if input.Force != nil && *input.Force {
+		override = &dtos.MergeOverride{}
+		if input.ForcedBy != nil {
+			override.ForcedBy = *input.ForcedBy
+		}
+		if input.Reason != nil {
+			override.Reason = *input.Reason
+		}
+	}
+
 	prID := encoding.DecodeID(input.PullRequestId)
-	pr, err := s.prService.MarkAsMerged(ctx.Request().Context(), prID)
+	pr, err := s.prService.MarkAsMerged(ctx.Request().Context(), prID, override)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
@@ -94,6 +159,125 @@ func (s *Server) PostPullRequestMerge(ctx echo.Context) error {
 	})
 }
 
+func (s *Server) GetPullRequestMergeability(ctx echo.Context, params GetPullRequestMergeabilityParams) error {
+	prID := encoding.DecodeID(params.PullRequestId)
+	report, err := s.prService.GetMergeability(ctx.Request().Context(), prID)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIMergeability(*report))
+}
+
+func (s *Server) GetPullRequestOverdue(ctx echo.Context, params GetPullRequestOverdueParams) error {
+	var teamName string
+	if params.TeamName != nil {
+		teamName = *params.TeamName
+	}
+	overdue, err := s.reviewSLAService.ListOverdue(ctx.Request().Context(), teamName)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	reviews := make([]OverdueReview, 0, len(overdue))
+	for _, o := range overdue {
+		reviews = append(reviews, ToAPIOverdueReview(*o))
+	}
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"reviews": reviews,
+	})
+}
+
+func (s *Server) PostPullRequestReady(ctx echo.Context) error {
+	var input PostPullRequestReadyJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	pr, err := s.prService.MarkReady(ctx.Request().Context(), encoding.DecodeID(input.PullRequestId))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"pr": ToAPIPullRequest(*pr),
+	})
+}
+
+func (s *Server) PostPullRequestClose(ctx echo.Context) error {
+	var input PostPullRequestCloseJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	pr, err := s.prService.ClosePullRequest(ctx.Request().Context(), encoding.DecodeID(input.PullRequestId))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"pr": ToAPIPullRequest(*pr),
+	})
+}
+
+func (s *Server) PostPullRequestReopen(ctx echo.Context) error {
+	var input PostPullRequestReopenJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	pr, err := s.prService.ReopenPullRequest(ctx.Request().Context(), encoding.DecodeID(input.PullRequestId))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"pr": ToAPIPullRequest(*pr),
+	})
+}
+
+func (s *Server) PostPullRequestReview(ctx echo.Context) error {
+	var input PostPullRequestReviewJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	prID := encoding.DecodeID(input.PullRequestId)
+	userID := encoding.DecodeID(input.UserId)
+	pr, err := s.prService.SubmitReview(ctx.Request().Context(), prID, userID, string(input.Decision))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"pr": ToAPIPullRequest(*pr),
+	})
+}
+
 func (s *Server) PostPullRequestReassign(ctx echo.Context) error {
 	var input PostPullRequestReassignJSONRequestBody
 	if err := ctx.Bind(&input); err != nil {
@@ -152,6 +336,33 @@ func (s *Server) PostTeamAdd(ctx echo.Context) error {
 	})
 }
 
+func (s *Server) PostTeamDeactivate(ctx echo.Context) error {
+	var input PostTeamDeactivateJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	var userIDs []int64
+	if input.UserIds != nil {
+		for _, id := range *input.UserIds {
+			userIDs = append(userIDs, encoding.DecodeID(id))
+		}
+	}
+
+	report, err := s.prService.DeactivateTeamMembers(ctx.Request().Context(), input.TeamName, userIDs)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIDeactivationReport(*report))
+}
+
 func (s *Server) GetTeamGet(ctx echo.Context, params GetTeamGetParams) error {
 	team, err := s.teamService.GetTeamByName(ctx.Request().Context(), params.TeamName)
 	if err != nil {
@@ -161,6 +372,146 @@ func (s *Server) GetTeamGet(ctx echo.Context, params GetTeamGetParams) error {
 	return ctx.JSON(http.StatusOK, ToAPITeam(*team))
 }
 
+func (s *Server) GetTeamSettings(ctx echo.Context, params GetTeamSettingsParams) error {
+	settings, err := s.teamService.GetTeamSettings(ctx.Request().Context(), params.TeamName)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPITeamSettings(*settings))
+}
+
+func (s *Server) PostTeamSettings(ctx echo.Context) error {
+	// Read into fields as well, as only there an explicit null resetting a
+	// limit differs from the limit left out.
+	var input PostTeamSettingsJSONRequestBody
+	var fields map[string]json.RawMessage
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err == nil {
+		err = json.Unmarshal(body, &input)
+	}
+	if err == nil {
+		err = json.Unmarshal(body, &fields)
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	settings, err := s.teamService.UpdateTeamSettings(ctx.Request().Context(), FromAPITeamSettingsUpdate(input, fields))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPITeamSettings(*settings))
+}
+
+func (s *Server) GetTeamSla(ctx echo.Context, params GetTeamSlaParams) error {
+	sla, err := s.reviewSLAService.GetTeamSLA(ctx.Request().Context(), params.TeamName)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPITeamReviewSLA(*sla))
+}
+
+func (s *Server) PostTeamSla(ctx echo.Context) error {
+	var input PostTeamSlaJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	sla, err := s.reviewSLAService.SetTeamSLA(ctx.Request().Context(), FromAPITeamReviewSLAUpdate(input))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPITeamReviewSLA(*sla))
+}
+
+func (s *Server) PostTeamSlaDelete(ctx echo.Context) error {
+	var input PostTeamSlaDeleteJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	if err := s.reviewSLAService.DeleteTeamSLA(ctx.Request().Context(), input.TeamName); err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) GetTeamNotifications(ctx echo.Context, params GetTeamNotificationsParams) error {
+	channel, err := s.notificationService.GetTeamChannel(ctx.Request().Context(), params.TeamName)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPITeamChatChannel(*channel))
+}
+
+func (s *Server) PostTeamNotifications(ctx echo.Context) error {
+	var input PostTeamNotificationsJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	var channel string
+	if input.Channel != nil {
+		channel = *input.Channel
+	}
+	updated, err := s.notificationService.SetTeamChannel(ctx.Request().Context(), input.TeamName,
+		string(input.Provider), input.WebhookUrl, channel)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPITeamChatChannel(*updated))
+}
+
+func (s *Server) PostTeamNotificationsDelete(ctx echo.Context) error {
+	var input PostTeamNotificationsDeleteJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	if err := s.notificationService.DeleteTeamChannel(ctx.Request().Context(), input.TeamName); err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
 func (s *Server) PostUsersSetIsActive(ctx echo.Context) error {
 	var input PostUsersSetIsActiveJSONRequestBody
 	if err := ctx.Bind(&input); err != nil {
@@ -174,51 +525,509 @@ func (s *Server) PostUsersSetIsActive(ctx echo.Context) error {
 	}
 
 	userID := encoding.DecodeID(input.UserId)
-	updated, err := s.teamService.SetUserActiveByID(ctx.Request().Context(), userID, input.IsActive)
+	reassign := input.ReassignOpenReviews != nil && *input.ReassignOpenReviews
+	updated, reassignment, err := s.teamService.SetUserActiveByID(ctx.Request().Context(), userID, input.IsActive, reassign)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
-	return ctx.JSON(http.StatusOK, map[string]User{
-		"user": ToAPIUser(*updated),
+	if reassignment == nil {
+		return ctx.JSON(http.StatusOK, map[string]User{
+			"user": ToAPIUser(*updated),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"user":       ToAPIUser(*updated),
+		"reassigned": ToAPIReviewerReplacements(reassignment.Reassigned),
+		"unassigned": ToAPIUnassignedReviews(reassignment.Unassigned),
 	})
 }
 
-func (s *Server) GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewParams) error {
-	userID := encoding.DecodeID(params.UserId)
-	resp, err := s.prService.FindPullRequestsByReviewer(ctx.Request().Context(), userID)
+func (s *Server) GetUsersIdentities(ctx echo.Context, params GetUsersIdentitiesParams) error {
+	identities, err := s.identityService.ListIdentities(ctx.Request().Context(), encoding.DecodeID(params.UserId))
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
+	apiIdentities := make([]UserIdentity, len(identities))
+	for i, identity := range identities {
+		apiIdentities[i] = ToAPIUserIdentity(*identity)
+	}
+
 	return ctx.JSON(http.StatusOK, map[string]any{
-		"user_id":       params.UserId,
-		"pull_requests": ToAPIPullRequestShortList(resp),
+		"user_id":    params.UserId,
+		"identities": apiIdentities,
 	})
 }
 
-func (s *Server) GetHealth(ctx echo.Context) error {
-	return ctx.JSON(http.StatusOK, map[string]string{
-		"status": "OK",
-	})
-}
+func (s *Server) PostUsersIdentities(ctx echo.Context) error {
+	var input PostUsersIdentitiesJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
 
-func (s *Server) GetStats(ctx echo.Context) error {
-	stats, err := s.prService.GetStatistics(ctx.Request().Context())
+	identity, err := s.identityService.SetIdentity(ctx.Request().Context(), encoding.DecodeID(input.UserId),
+		string(input.Provider), input.ExternalId)
 	if err != nil {
 		return mapAppErrorToEchoResponse(ctx, err)
 	}
 
-	return ctx.JSON(http.StatusOK, stats)
+	return ctx.JSON(http.StatusCreated, map[string]any{
+		"identity": ToAPIUserIdentity(*identity),
+	})
 }
 
-func mapAppErrorToEchoResponse(ctx echo.Context, err error) error {
-	code := http.StatusInternalServerError
-	msg := "internal server error"
-	apiCode := "INTERNAL"
-
-	switch {
-	case errors.Is(err, services.ErrPRAlreadyExists):
+func (s *Server) PostUsersIdentitiesDelete(ctx echo.Context) error {
+	var input PostUsersIdentitiesDeleteJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	err := s.identityService.DeleteIdentity(ctx.Request().Context(), encoding.DecodeID(input.UserId),
+		string(input.Provider))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) GetUsersIdentitiesLookup(ctx echo.Context, params GetUsersIdentitiesLookupParams) error {
+	identity, err := s.identityService.LookupIdentity(ctx.Request().Context(), string(params.Provider),
+		params.ExternalId)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIUserIdentity(*identity))
+}
+
+func (s *Server) GetUsersNotifications(ctx echo.Context, params GetUsersNotificationsParams) error {
+	prefs, err := s.notificationService.GetPreferences(ctx.Request().Context(), encoding.DecodeID(params.UserId))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPINotificationPreferences(*prefs))
+}
+
+func (s *Server) PostUsersNotifications(ctx echo.Context) error {
+	var input PostUsersNotificationsJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	prefs, err := s.notificationService.UpdatePreferences(ctx.Request().Context(), encoding.DecodeID(input.UserId),
+		FromAPINotificationPreferencesUpdate(input))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPINotificationPreferences(*prefs))
+}
+
+func (s *Server) PostUsersNotificationsDigest(ctx echo.Context) error {
+	var input PostUsersNotificationsDigestJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	var sent int
+	var err error
+	if input.UserId != nil {
+		sent, err = s.emailNotifier.SendDigest(ctx.Request().Context(), encoding.DecodeID(*input.UserId))
+	} else {
+		sent, err = s.emailNotifier.SendDigests(ctx.Request().Context(), nil)
+	}
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, dtos.DigestResult{Sent: sent})
+}
+
+func (s *Server) GetUsersUnavailability(ctx echo.Context, params GetUsersUnavailabilityParams) error {
+	userID := encoding.DecodeID(params.UserId)
+	periods, err := s.unavailabilityService.ListPeriods(ctx.Request().Context(), userID)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	apiPeriods := make([]Unavailability, len(periods))
+	for i, p := range periods {
+		apiPeriods[i] = ToAPIUnavailability(*p)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"user_id": params.UserId,
+		"periods": apiPeriods,
+	})
+}
+
+func (s *Server) PostUsersUnavailability(ctx echo.Context) error {
+	var input PostUsersUnavailabilityJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	var reason string
+	if input.Reason != nil {
+		reason = *input.Reason
+	}
+
+	period, err := s.unavailabilityService.AddPeriod(ctx.Request().Context(), encoding.DecodeID(input.UserId),
+		input.StartsAt, input.EndsAt, reason)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, map[string]any{
+		"unavailability": ToAPIUnavailability(*period),
+	})
+}
+
+func (s *Server) PostUsersUnavailabilityDelete(ctx echo.Context) error {
+	var input PostUsersUnavailabilityDeleteJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	err := s.unavailabilityService.DeletePeriod(ctx.Request().Context(), encoding.DecodeID(input.UserId),
+		encoding.DecodeID(input.Id))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) PostUsersUnavailabilityImport(ctx echo.Context, params PostUsersUnavailabilityImportParams) error {
+	userID := encoding.DecodeID(params.UserId)
+	result, err := s.unavailabilityService.ImportCalendar(ctx.Request().Context(), userID, ctx.Request().Body)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPICalendarImportResult(*result))
+}
+
+func (s *Server) GetUsersGetReview(ctx echo.Context, params GetUsersGetReviewParams) error {
+	userID := encoding.DecodeID(params.UserId)
+	resp, err := s.prService.GetUserReviews(ctx.Request().Context(), userID)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"user_id":       params.UserId,
+		"pull_requests": ToAPIPullRequestShortList(resp.PullRequests),
+	})
+}
+
+// PostWebhooksGithub reads the raw body itself: the signature covers the exact
+// bytes GitHub sent.
+func (s *Server) PostWebhooksGithub(ctx echo.Context, params PostWebhooksGithubParams) error {
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	var signature string
+	if params.XHubSignature256 != nil {
+		signature = *params.XHubSignature256
+	}
+
+	result, err := s.webhookService.HandleGitHub(ctx.Request().Context(), params.XGitHubEvent, signature, body)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIWebhookResult(*result))
+}
+
+func (s *Server) PostWebhooksGitlab(ctx echo.Context, params PostWebhooksGitlabParams) error {
+	body, err := io.ReadAll(ctx.Request().Body)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	var token string
+	if params.XGitlabToken != nil {
+		token = *params.XGitlabToken
+	}
+
+	result, err := s.webhookService.HandleGitLab(ctx.Request().Context(), params.XGitlabEvent, token, body)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, ToAPIWebhookResult(*result))
+}
+
+func (s *Server) GetWebhooksSubscriptions(ctx echo.Context) error {
+	subscriptions, err := s.subscriptionService.ListSubscriptions(ctx.Request().Context())
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	apiSubscriptions := make([]WebhookSubscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		apiSubscriptions[i] = ToAPIWebhookSubscription(*subscription)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"subscriptions": apiSubscriptions,
+	})
+}
+
+func (s *Server) PostWebhooksSubscriptions(ctx echo.Context) error {
+	var input PostWebhooksSubscriptionsJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	var events []string
+	if input.Events != nil {
+		for _, event := range *input.Events {
+			events = append(events, string(event))
+		}
+	}
+
+	subscription, err := s.subscriptionService.CreateSubscription(ctx.Request().Context(), input.Url,
+		input.Secret, events)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusCreated, map[string]any{
+		"subscription": ToAPIWebhookSubscription(*subscription),
+	})
+}
+
+func (s *Server) PostWebhooksSubscriptionsDelete(ctx echo.Context) error {
+	var input PostWebhooksSubscriptionsDeleteJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	err := s.subscriptionService.DeleteSubscription(ctx.Request().Context(), encoding.DecodeID(input.SubscriptionId))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.NoContent(http.StatusNoContent)
+}
+
+func (s *Server) GetWebhooksDeliveries(ctx echo.Context, params GetWebhooksDeliveriesParams) error {
+	deliveries, err := s.subscriptionService.ListDeliveries(ctx.Request().Context(),
+		encoding.DecodeID(params.SubscriptionId))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	apiDeliveries := make([]WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		apiDeliveries[i] = ToAPIWebhookDelivery(*delivery)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"subscription_id": params.SubscriptionId,
+		"deliveries":      apiDeliveries,
+	})
+}
+
+func (s *Server) PostWebhooksDeliveriesRedeliver(ctx echo.Context) error {
+	var input PostWebhooksDeliveriesRedeliverJSONRequestBody
+	if err := ctx.Bind(&input); err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]any{
+			"error": map[string]string{
+				"code":    "INVALID_REQUEST",
+				"message": "invalid request",
+				"details": err.Error(),
+			},
+		})
+	}
+
+	delivery, err := s.subscriptionService.Redeliver(ctx.Request().Context(), encoding.DecodeID(input.DeliveryId))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{
+		"delivery": ToAPIWebhookDelivery(*delivery),
+	})
+}
+
+func (s *Server) GetHealth(ctx echo.Context) error {
+	return ctx.JSON(http.StatusOK, map[string]string{
+		"status": "OK",
+	})
+}
+
+func (s *Server) GetStats(ctx echo.Context, params GetStatsParams) error {
+	filter := statsFilter(params.From, params.To, params.TeamName)
+	stats, err := s.statsService.GetStatistics(ctx.Request().Context(), filter)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, stats)
+}
+
+// GetStatsExportPullRequests and GetStatsExportAssignments stream the rows as
+// they are read. Once the first one is sent the status cannot change, so an
+// error after it aborts the response, see exportFailed.
+func (s *Server) GetStatsExportPullRequests(ctx echo.Context, params GetStatsExportPullRequestsParams) error {
+	format, ok := negotiateExportFormat(strings.Join(ctx.Request().Header.Values(echo.HeaderAccept), ","))
+	if !ok {
+		return exportNotAcceptable(ctx)
+	}
+
+	w := newExportWriter(ctx, format, "pull_requests", pullRequestExportHeader)
+	filter := statsFilter(params.From, params.To, params.TeamName)
+	err := s.statsService.ExportPullRequests(ctx.Request().Context(), filter, func(pr *dtos.PullRequestExport) error {
+		return w.write(pr, func() []string { return pullRequestExportRecord(pr) })
+	})
+	if err == nil {
+		err = w.close()
+	}
+	if err != nil {
+		return exportFailed(ctx, err)
+	}
+	return nil
+}
+
+func (s *Server) GetStatsExportAssignments(ctx echo.Context, params GetStatsExportAssignmentsParams) error {
+	format, ok := negotiateExportFormat(strings.Join(ctx.Request().Header.Values(echo.HeaderAccept), ","))
+	if !ok {
+		return exportNotAcceptable(ctx)
+	}
+
+	w := newExportWriter(ctx, format, "review_assignments", reviewAssignmentExportHeader)
+	filter := statsFilter(params.From, params.To, params.TeamName)
+	err := s.statsService.ExportReviewAssignments(ctx.Request().Context(), filter,
+		func(a *dtos.ReviewAssignmentExport) error {
+			return w.write(a, func() []string { return reviewAssignmentExportRecord(a) })
+		})
+	if err == nil {
+		err = w.close()
+	}
+	if err != nil {
+		return exportFailed(ctx, err)
+	}
+	return nil
+}
+
+func exportNotAcceptable(ctx echo.Context) error {
+	return ctx.JSON(http.StatusNotAcceptable, map[string]any{
+		"error": map[string]string{
+			"code":    "NOT_ACCEPTABLE",
+			"message": "supported formats: " + exportFormatCSV + ", " + exportFormatNDJSON,
+		},
+	})
+}
+
+func statsFilter(from, to *time.Time, teamName *string) *dtos.StatsFilter {
+	filter := &dtos.StatsFilter{From: from, To: to}
+	if teamName != nil {
+		filter.TeamName = *teamName
+	}
+	return filter
+}
+
+func (s *Server) GetStatsReviewersId(ctx echo.Context, id string) error {
+	workload, err := s.statsService.GetReviewerWorkload(ctx.Request().Context(), encoding.DecodeID(id))
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+
+	return ctx.JSON(http.StatusOK, workload)
+}
+
+func (s *Server) GetStatsTeams(ctx echo.Context, params GetStatsTeamsParams) error {
+	var teamName string
+	if params.TeamName != nil {
+		teamName = *params.TeamName
+	}
+	workloads, err := s.statsService.GetTeamWorkloads(ctx.Request().Context(), teamName)
+	if err != nil {
+		return mapAppErrorToEchoResponse(ctx, err)
+	}
+	if workloads == nil {
+		workloads = []*dtos.TeamWorkload{}
+	}
+
+	return ctx.JSON(http.StatusOK, map[string]any{"teams": workloads})
+}
+
+func mapAppErrorToEchoResponse(ctx echo.Context, err error) error {
+	code := http.StatusInternalServerError
+	msg := "internal server error"
+	apiCode := "INTERNAL"
+
+	switch {
+	case errors.Is(err, services.ErrPRAlreadyExists):
 		code = http.StatusConflict
 		msg = "pull request already exists"
 		apiCode = "PR_EXISTS"
@@ -226,10 +1035,30 @@ func mapAppErrorToEchoResponse(ctx echo.Context, err error) error {
 		code = http.StatusConflict
 		msg = "pull request already merged"
 		apiCode = "PR_MERGED"
+	case errors.Is(err, services.ErrInvalidDecision):
+		code = http.StatusBadRequest
+		msg = "unknown review decision"
+		apiCode = "INVALID_DECISION"
+	case errors.Is(err, services.ErrMergeBlocked):
+		code = http.StatusConflict
+		msg = "merge requirements are not met"
+		apiCode = "MERGE_BLOCKED"
+	case errors.Is(err, services.ErrForcedByRequired):
+		code = http.StatusBadRequest
+		msg = "forced_by is required to force a merge"
+		apiCode = "FORCED_BY_REQUIRED"
+	case errors.Is(err, services.ErrInvalidTransition):
+		code = http.StatusConflict
+		msg = "pull request status does not allow this operation"
+		apiCode = "INVALID_TRANSITION"
 	case errors.Is(err, services.ErrNoReviewCandidates):
 		code = http.StatusConflict
 		msg = "no active users to assign as reviewers"
 		apiCode = "NO_CANDIDATE"
+	case errors.Is(err, services.ErrNotEnoughReviewers):
+		code = http.StatusConflict
+		msg = "not enough active users to assign as reviewers"
+		apiCode = "NOT_ENOUGH_REVIEWERS"
 	case errors.Is(err, services.ErrUserNotReviewer):
 		code = http.StatusBadRequest
 		msg = "user is not a reviewer"
@@ -238,14 +1067,79 @@ func mapAppErrorToEchoResponse(ctx echo.Context, err error) error {
 		code = http.StatusNotFound
 		msg = "pull request not found"
 		apiCode = "NOT_FOUND"
-	case errors.Is(err, services.ErrTeamNotFound), errors.Is(err, services.ErrAuthorNotFound):
+	case errors.Is(err, services.ErrTeamNotFound), errors.Is(err, services.ErrAuthorNotFound),
+		errors.Is(err, services.ErrIdentityNotFound):
 		code = http.StatusNotFound
 		msg = err.Error()
 		apiCode = "NOT_FOUND"
+	case errors.Is(err, services.ErrAuthorNotInTeam):
+		code = http.StatusBadRequest
+		msg = "author is not a member of the team"
+		apiCode = "NOT_TEAM_MEMBER"
+	case errors.Is(err, services.ErrAmbiguousTeam):
+		code = http.StatusConflict
+		msg = "author belongs to several teams"
+		apiCode = "TEAM_AMBIGUOUS"
+	case errors.Is(err, services.ErrUserNotInTeam):
+		code = http.StatusBadRequest
+		msg = "user is not a member of the team"
+		apiCode = "USER_NOT_IN_TEAM"
+	case errors.Is(err, services.ErrInvalidPeriod):
+		code = http.StatusBadRequest
+		msg = "invalid unavailability period"
+		apiCode = "INVALID_PERIOD"
+	case errors.Is(err, services.ErrInvalidStatsPeriod):
+		code = http.StatusBadRequest
+		msg = "invalid statistics period"
+		apiCode = "INVALID_PERIOD"
+	case errors.Is(err, services.ErrInvalidCalendar):
+		code = http.StatusBadRequest
+		msg = "invalid calendar file"
+		apiCode = "INVALID_CALENDAR"
+	case errors.Is(err, services.ErrInvalidIdentity):
+		code = http.StatusBadRequest
+		msg = "invalid external identity"
+		apiCode = "INVALID_IDENTITY"
+	case errors.Is(err, services.ErrIdentityTaken):
+		code = http.StatusConflict
+		msg = "external identity belongs to another user"
+		apiCode = "IDENTITY_TAKEN"
+	case errors.Is(err, services.ErrInvalidSignature):
+		code = http.StatusUnauthorized
+		msg = "invalid webhook signature or token"
+		apiCode = "INVALID_SIGNATURE"
+	case errors.Is(err, services.ErrInvalidPayload):
+		code = http.StatusBadRequest
+		msg = "invalid webhook payload"
+		apiCode = "INVALID_PAYLOAD"
+	case errors.Is(err, services.ErrInvalidSubscription):
+		code = http.StatusBadRequest
+		msg = "invalid webhook subscription"
+		apiCode = "INVALID_SUBSCRIPTION"
+	case errors.Is(err, services.ErrInvalidChatChannel):
+		code = http.StatusBadRequest
+		msg = "invalid chat channel"
+		apiCode = "INVALID_CHAT_CHANNEL"
+	case errors.Is(err, services.ErrInvalidSLA):
+		code = http.StatusBadRequest
+		msg = "invalid review SLA"
+		apiCode = "INVALID_SLA"
+	case errors.Is(err, services.ErrEmailNotConfigured):
+		code = http.StatusServiceUnavailable
+		msg = "email delivery is not configured"
+		apiCode = "EMAIL_NOT_CONFIGURED"
 	case errors.Is(err, services.ErrTeamExists):
 		code = http.StatusConflict
 		msg = "team already exists"
 		apiCode = "TEAM_EXISTS"
+	case errors.Is(err, services.ErrNotFound):
+		code = http.StatusNotFound
+		msg = "resource not found"
+		apiCode = "NOT_FOUND"
+	case errors.Is(err, services.ErrInvalidSettings):
+		code = http.StatusBadRequest
+		msg = "invalid team settings"
+		apiCode = "INVALID_SETTINGS"
 	}
 
 	return ctx.JSON(code, map[string]any{
@@ -0,0 +1,509 @@
+package calendar
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrInvalidCalendar = errors.New("invalid calendar")
+
+const (
+	dateLayout     = "20060102"
+	dateTimeLayout = "20060102T150405"
+)
+
+// Event is a single VEVENT of an iCalendar (RFC 5545) file. End is exclusive.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Parse reads the VEVENTs of an iCalendar file. It understands the subset of
+// the format produced by common calendar exports: folded lines, DATE and
+// DATE-TIME values in UTC, with a TZID or floating (read as UTC), DTEND or
+// DURATION, and simple recurrence rules with their exceptions. Cancelled
+// events are skipped and recurring events are expanded to the occurrences
+// starting before until, each with the UID of the event suffixed by its start.
+func Parse(r io.Reader, until time.Time) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("%w: missing BEGIN:VCALENDAR", ErrInvalidCalendar)
+	}
+
+	var events []occurrence
+	overridden := make(map[string]bool)
+	var current *rawEvent
+	depth := 0
+	for i, line := range lines {
+		name, params, value, ok := splitLine(line)
+		if !ok {
+			return nil, fmt.Errorf("%w: malformed line %d", ErrInvalidCalendar, i+1)
+		}
+
+		switch {
+		case current == nil && name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			current, depth = &rawEvent{}, 0
+		case current == nil:
+		case name == "BEGIN":
+			// Components nested in an event, such as VALARM, have properties
+			// of their own that must not be taken for the event's.
+			depth++
+		case name == "END" && depth > 0:
+			depth--
+		case name == "END" && strings.EqualFold(value, "VEVENT"):
+			occurrences, err := current.occurrences(until)
+			if err != nil {
+				return nil, fmt.Errorf("%w: event ending on line %d: %v", ErrInvalidCalendar, i+1, err)
+			}
+			for _, o := range occurrences {
+				if o.replaces != "" {
+					overridden[o.replaces] = true
+				}
+				if o.keep {
+					events = append(events, o)
+				}
+			}
+			current = nil
+		case name == "END":
+			return nil, fmt.Errorf("%w: unexpected END:%s on line %d", ErrInvalidCalendar, value, i+1)
+		case depth == 0:
+			current.set(name, params, value)
+		}
+	}
+
+	if current != nil {
+		return nil, fmt.Errorf("%w: unterminated VEVENT", ErrInvalidCalendar)
+	}
+
+	result := make([]Event, 0, len(events))
+	for _, o := range events {
+		if o.key == "" || !overridden[o.key] {
+			result = append(result, o.Event)
+		}
+	}
+	return result, nil
+}
+
+// occurrence is an event as parsed, before the occurrences overridden by a
+// RECURRENCE-ID elsewhere in the file are dropped. key identifies the
+// occurrence of a recurring event, replaces the one an override stands for.
+type occurrence struct {
+	Event
+	keep          bool
+	key, replaces string
+}
+
+type rawEvent struct {
+	uid, summary, status   string
+	start, end, duration   string
+	rrule, recurrenceID    string
+	startParams, endParams map[string]string
+	recurrenceIDParams     map[string]string
+	exdates                []rawValue
+}
+
+type rawValue struct {
+	value  string
+	params map[string]string
+}
+
+func (e *rawEvent) set(name string, params map[string]string, value string) {
+	switch name {
+	case "UID":
+		e.uid = value
+	case "SUMMARY":
+		e.summary = unescape(value)
+	case "STATUS":
+		e.status = strings.ToUpper(value)
+	case "DTSTART":
+		e.start, e.startParams = value, params
+	case "DTEND":
+		e.end, e.endParams = value, params
+	case "DURATION":
+		e.duration = value
+	case "RRULE":
+		e.rrule = value
+	case "RECURRENCE-ID":
+		e.recurrenceID, e.recurrenceIDParams = value, params
+	case "EXDATE":
+		for _, v := range strings.Split(value, ",") {
+			e.exdates = append(e.exdates, rawValue{value: v, params: params})
+		}
+	}
+}
+
+func (e *rawEvent) occurrences(until time.Time) ([]occurrence, error) {
+	event, keep, err := e.event()
+	if err != nil {
+		return nil, err
+	}
+
+	if e.recurrenceID != "" {
+		id, _, err := parseTime(e.recurrenceID, e.recurrenceIDParams)
+		if err != nil {
+			return nil, fmt.Errorf("RECURRENCE-ID: %w", err)
+		}
+		key := occurrenceKey(event.UID, id)
+		event.UID = occurrenceUID(event.UID, id)
+		return []occurrence{{Event: event, keep: keep, replaces: key}}, nil
+	}
+	if e.rrule == "" || !keep {
+		return []occurrence{{Event: event, keep: keep}}, nil
+	}
+
+	rule, err := parseRule(e.rrule, event.Start)
+	if err != nil {
+		return nil, fmt.Errorf("RRULE: %w", err)
+	}
+	excluded := make(map[int64]bool, len(e.exdates))
+	for _, x := range e.exdates {
+		t, _, err := parseTime(x.value, x.params)
+		if err != nil {
+			return nil, fmt.Errorf("EXDATE: %w", err)
+		}
+		excluded[t.Unix()] = true
+	}
+	starts, err := rule.starts(event.Start, until)
+	if err != nil {
+		return nil, fmt.Errorf("RRULE: %w", err)
+	}
+
+	length := event.End.Sub(event.Start)
+	result := make([]occurrence, 0, len(starts))
+	for _, start := range starts {
+		if excluded[start.Unix()] {
+			continue
+		}
+		result = append(result, occurrence{
+			Event: Event{
+				UID:     occurrenceUID(event.UID, start),
+				Summary: event.Summary,
+				Start:   start,
+				End:     start.Add(length),
+			},
+			keep: true,
+			key:  occurrenceKey(event.UID, start),
+		})
+	}
+	return result, nil
+}
+
+func (e *rawEvent) event() (Event, bool, error) {
+	if e.start == "" {
+		return Event{}, false, errors.New("missing DTSTART")
+	}
+
+	start, allDay, err := parseTime(e.start, e.startParams)
+	if err != nil {
+		return Event{}, false, fmt.Errorf("DTSTART: %w", err)
+	}
+
+	var end time.Time
+	switch {
+	case e.end != "":
+		if end, _, err = parseTime(e.end, e.endParams); err != nil {
+			return Event{}, false, fmt.Errorf("DTEND: %w", err)
+		}
+	case e.duration != "":
+		d, err := parseDuration(e.duration)
+		if err != nil {
+			return Event{}, false, fmt.Errorf("DURATION: %w", err)
+		}
+		end = start.Add(d)
+	case allDay:
+		end = start.AddDate(0, 0, 1)
+	default:
+		end = start
+	}
+
+	uid := e.uid
+	if uid == "" {
+		uid = fmt.Sprintf("%s/%s", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	}
+
+	keep := e.status != "CANCELLED" && end.After(start)
+	return Event{UID: uid, Summary: e.summary, Start: start, End: end}, keep, nil
+}
+
+func occurrenceUID(uid string, start time.Time) string {
+	return uid + "/" + start.UTC().Format(time.RFC3339)
+}
+
+func occurrenceKey(uid string, start time.Time) string {
+	return fmt.Sprintf("%s\x00%d", uid, start.Unix())
+}
+
+// maxRecurrenceSteps bounds the expansion of a recurrence rule, whose period
+// could otherwise be stepped through from any DTSTART in the past.
+const maxRecurrenceSteps = 100000
+
+// recurrence is an RRULE of the supported subset: a FREQ of DAILY, WEEKLY,
+// MONTHLY or YEARLY with INTERVAL, COUNT, UNTIL and, for WEEKLY, BYDAY.
+type recurrence struct {
+	freq     string
+	interval int
+	count    int
+	until    *time.Time
+	byDay    []time.Weekday
+}
+
+var weekdays = map[string]time.Weekday{
+	"MO": time.Monday, "TU": time.Tuesday, "WE": time.Wednesday, "TH": time.Thursday,
+	"FR": time.Friday, "SA": time.Saturday, "SU": time.Sunday,
+}
+
+func parseRule(value string, start time.Time) (*recurrence, error) {
+	rule := &recurrence{interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return nil, fmt.Errorf("malformed rule part %q", part)
+		}
+		v = strings.ToUpper(v)
+
+		var err error
+		switch strings.ToUpper(k) {
+		case "FREQ":
+			rule.freq = v
+		case "INTERVAL":
+			rule.interval, err = strconv.Atoi(v)
+			if err == nil && rule.interval < 1 {
+				err = errors.New("must be positive")
+			}
+		case "COUNT":
+			rule.count, err = strconv.Atoi(v)
+			if err == nil && rule.count < 1 {
+				err = errors.New("must be positive")
+			}
+		case "UNTIL":
+			rule.until, err = parseUntil(v, start)
+		case "BYDAY":
+			for _, day := range strings.Split(v, ",") {
+				wd, ok := weekdays[day]
+				if !ok {
+					return nil, fmt.Errorf("unsupported BYDAY %q", day)
+				}
+				rule.byDay = append(rule.byDay, wd)
+			}
+		case "WKST":
+		default:
+			return nil, fmt.Errorf("unsupported rule part %s", k)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", k, err)
+		}
+	}
+
+	switch rule.freq {
+	case "DAILY", "MONTHLY", "YEARLY":
+		if len(rule.byDay) > 0 {
+			return nil, fmt.Errorf("unsupported BYDAY with FREQ=%s", rule.freq)
+		}
+	case "WEEKLY":
+		// Weeks start on Monday, whatever WKST says.
+		sort.Slice(rule.byDay, func(i, j int) bool {
+			return (rule.byDay[i]+6)%7 < (rule.byDay[j]+6)%7
+		})
+	default:
+		return nil, fmt.Errorf("unsupported FREQ %q", rule.freq)
+	}
+	return rule, nil
+}
+
+// parseUntil reads UNTIL in the time zone of start when it is floating, and
+// as the end of the day when it is a date.
+func parseUntil(value string, start time.Time) (*time.Time, error) {
+	params := map[string]string{}
+	if start.Location() != time.UTC {
+		params["TZID"] = start.Location().String()
+	}
+	until, allDay, err := parseTime(value, params)
+	if err != nil {
+		return nil, err
+	}
+	if allDay {
+		until = time.Date(until.Year(), until.Month(), until.Day(), 23, 59, 59, 0, start.Location())
+	}
+	return &until, nil
+}
+
+// starts returns the starts of the occurrences of the rule for an event
+// starting at start, the first one included, up to before horizon.
+func (r *recurrence) starts(start, horizon time.Time) ([]time.Time, error) {
+	starts := []time.Time{start}
+	y, m, d := start.Date()
+	hour, minute, sec := start.Clock()
+	at := func(years, months, days int) time.Time {
+		return time.Date(y+years, m+time.Month(months), d+days, hour, minute, sec, start.Nanosecond(),
+			start.Location())
+	}
+
+	for step := 1; r.count == 0 || len(starts) < r.count; step++ {
+		if step > maxRecurrenceSteps {
+			return nil, errors.New("too many occurrences")
+		}
+
+		var candidates []time.Time
+		switch {
+		case r.freq == "DAILY":
+			candidates = []time.Time{at(0, 0, step*r.interval)}
+		case r.freq == "WEEKLY" && len(r.byDay) == 0:
+			candidates = []time.Time{at(0, 0, 7*step*r.interval)}
+		case r.freq == "WEEKLY":
+			monday := -int(start.Weekday()+6) % 7
+			for _, wd := range r.byDay {
+				candidates = append(candidates, at(0, 0, monday+7*(step-1)*r.interval+int(wd+6)%7))
+			}
+		case r.freq == "MONTHLY":
+			candidates = []time.Time{at(0, step*r.interval, 0)}
+		case r.freq == "YEARLY":
+			candidates = []time.Time{at(step*r.interval, 0, 0)}
+		}
+		// Months too short for the day are skipped rather than rolled over.
+		if (r.freq == "MONTHLY" || r.freq == "YEARLY") && candidates[0].Day() != d {
+			continue
+		}
+
+		for _, c := range candidates {
+			if !c.After(start) {
+				continue
+			}
+			if (r.until != nil && c.After(*r.until)) || !c.Before(horizon) {
+				return starts, nil
+			}
+			starts = append(starts, c)
+			if r.count > 0 && len(starts) == r.count {
+				break
+			}
+		}
+	}
+	return starts, nil
+}
+
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// splitLine splits a content line "NAME;PARAM=VALUE:value" into its parts.
+func splitLine(line string) (string, map[string]string, string, bool) {
+	colon := strings.IndexByte(line, ':')
+	if colon <= 0 {
+		return "", nil, "", false
+	}
+
+	parts := strings.Split(line[:colon], ";")
+	params := make(map[string]string, len(parts)-1)
+	for _, p := range parts[1:] {
+		k, v, ok := strings.Cut(p, "=")
+		if !ok {
+			return "", nil, "", false
+		}
+		params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+	}
+
+	return strings.ToUpper(parts[0]), params, line[colon+1:], true
+}
+
+func parseTime(value string, params map[string]string) (time.Time, bool, error) {
+	if params["VALUE"] == "DATE" || len(value) == len(dateLayout) {
+		t, err := time.Parse(dateLayout, value)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(dateTimeLayout, strings.TrimSuffix(value, "Z"))
+		return t, false, err
+	}
+
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		l, err := time.LoadLocation(tzid)
+		if err != nil {
+			return time.Time{}, false, fmt.Errorf("unknown time zone %q", tzid)
+		}
+		loc = l
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, loc)
+	return t, false, err
+}
+
+// parseDuration parses the RFC 5545 duration format, e.g. P1D, PT2H30M, P2W.
+func parseDuration(value string) (time.Duration, error) {
+	v := strings.TrimPrefix(value, "+")
+	if !strings.HasPrefix(v, "P") {
+		return 0, fmt.Errorf("unsupported duration %q", value)
+	}
+	v = v[1:]
+
+	var d time.Duration
+	inTime := false
+	num := 0
+	digits := false
+	for _, c := range v {
+		switch {
+		case c >= '0' && c <= '9':
+			num = num*10 + int(c-'0')
+			digits = true
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		}
+		if !digits {
+			return 0, fmt.Errorf("unsupported duration %q", value)
+		}
+
+		switch {
+		case c == 'W' && !inTime:
+			d += time.Duration(num) * 7 * 24 * time.Hour
+		case c == 'D' && !inTime:
+			d += time.Duration(num) * 24 * time.Hour
+		case c == 'H' && inTime:
+			d += time.Duration(num) * time.Hour
+		case c == 'M' && inTime:
+			d += time.Duration(num) * time.Minute
+		case c == 'S' && inTime:
+			d += time.Duration(num) * time.Second
+		default:
+			return 0, fmt.Errorf("unsupported duration %q", value)
+		}
+		num, digits = 0, false
+	}
+	if digits {
+		return 0, fmt.Errorf("unsupported duration %q", value)
+	}
+
+	return d, nil
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
@@ -0,0 +1,101 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"pullrequest-inator/internal/infrastructure/remote"
+	"strings"
+)
+
+// Message is the body of an incoming webhook request. Slack and Mattermost
+// take the same fields and differ in the markup of Text; an empty Channel
+// posts to the channel the webhook was created for.
+type Message struct {
+	Channel  string `json:"channel,omitempty"`
+	Text     string `json:"text"`
+	Username string `json:"username,omitempty"`
+}
+
+// Format renders the markup of one chat.
+type Format interface {
+	// Mention notifies a user: a Slack member ID or a Mattermost username.
+	Mention(userID string) string
+	Bold(text string) string
+	// Escape quotes text so it is shown as is.
+	Escape(text string) string
+}
+
+var (
+	Slack      Format = slackFormat{}
+	Mattermost Format = mattermostFormat{}
+)
+
+type slackFormat struct{}
+
+var slackEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+
+func (slackFormat) Mention(userID string) string {
+	return "<@" + userID + ">"
+}
+
+func (slackFormat) Bold(text string) string {
+	return "*" + text + "*"
+}
+
+func (slackFormat) Escape(text string) string {
+	return slackEscaper.Replace(text)
+}
+
+type mattermostFormat struct{}
+
+var mattermostEscaper = strings.NewReplacer("\\", "\\\\", "*", "\\*", "_", "\\_", "`", "\\`",
+	"[", "\\[", "]", "\\]", "@", "\\@", "~", "\\~")
+
+func (mattermostFormat) Mention(userID string) string {
+	return "@" + strings.TrimPrefix(userID, "@")
+}
+
+func (mattermostFormat) Bold(text string) string {
+	return "**" + text + "**"
+}
+
+func (mattermostFormat) Escape(text string) string {
+	return mattermostEscaper.Replace(text)
+}
+
+type Client struct {
+	http *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{http: httpClient}
+}
+
+// Post sends msg to the incoming webhook at webhookURL.
+func (c *Client) Post(ctx context.Context, webhookURL string, msg *Message) error {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return fmt.Errorf("encode message: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhookURL, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("POST chat webhook: %w", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return remote.CheckResponse("chat webhook", resp)
+}
@@ -0,0 +1,179 @@
+package email
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"pullrequest-inator/internal/infrastructure/remote"
+	"strconv"
+	"time"
+)
+
+const (
+	TLSNone     = "none"
+	TLSStartTLS = "starttls"
+	TLSImplicit = "tls"
+)
+
+var ErrNotConfigured = errors.New("smtp host is not configured")
+
+type Config struct {
+	Host string
+	Port int
+	// Username and Password enable PLAIN authentication, which net/smtp only
+	// performs over TLS or to localhost.
+	Username string
+	Password string
+	From     string
+	// TLS is TLSNone, TLSStartTLS or TLSImplicit.
+	TLS     string
+	Timeout time.Duration
+}
+
+// Message is an email with a plain text and an HTML alternative.
+type Message struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+type Client struct {
+	config Config
+}
+
+func NewClient(config Config) *Client {
+	if config.Timeout <= 0 {
+		config.Timeout = 30 * time.Second
+	}
+	return &Client{config: config}
+}
+
+// Send delivers msg over a new SMTP connection.
+func (c *Client) Send(ctx context.Context, msg *Message) error {
+	if c.config.Host == "" {
+		return ErrNotConfigured
+	}
+	body, err := c.encode(msg)
+	if err != nil {
+		return err
+	}
+
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	deadline := time.Now().Add(c.config.Timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	_ = conn.SetDeadline(deadline)
+
+	client, err := smtp.NewClient(conn, c.config.Host)
+	if err != nil {
+		_ = conn.Close()
+		return responseError(err)
+	}
+	defer func() {
+		_ = client.Close()
+	}()
+
+	if c.config.TLS == TLSStartTLS {
+		if err := client.StartTLS(&tls.Config{ServerName: c.config.Host}); err != nil {
+			return responseError(err)
+		}
+	}
+	if c.config.Username != "" {
+		if err := client.Auth(smtp.PlainAuth("", c.config.Username, c.config.Password, c.config.Host)); err != nil {
+			return responseError(err)
+		}
+	}
+
+	if err := client.Mail(c.config.From); err != nil {
+		return responseError(err)
+	}
+	if err := client.Rcpt(msg.To); err != nil {
+		return responseError(err)
+	}
+	w, err := client.Data()
+	if err != nil {
+		return responseError(err)
+	}
+	if _, err := w.Write(body); err != nil {
+		return fmt.Errorf("write message: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return responseError(err)
+	}
+	return responseError(client.Quit())
+}
+
+func (c *Client) dial(ctx context.Context) (net.Conn, error) {
+	addr := net.JoinHostPort(c.config.Host, strconv.Itoa(c.config.Port))
+	dialer := &net.Dialer{Timeout: c.config.Timeout}
+
+	var conn net.Conn
+	var err error
+	if c.config.TLS == TLSImplicit {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: &tls.Config{ServerName: c.config.Host}}
+		conn, err = tlsDialer.DialContext(ctx, "tcp", addr)
+	} else {
+		conn, err = dialer.DialContext(ctx, "tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("connect to %s: %w", addr, err)
+	}
+	return conn, nil
+}
+
+// encode renders msg as a multipart/alternative MIME message.
+func (c *Client) encode(msg *Message) ([]byte, error) {
+	var buf bytes.Buffer
+	parts := multipart.NewWriter(&buf)
+
+	fmt.Fprintf(&buf, "From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\n", c.config.From, msg.To,
+		mime.QEncoding.Encode("utf-8", msg.Subject), time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "Content-Type: multipart/alternative; boundary=%s\r\n\r\n", parts.Boundary())
+
+	for _, part := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", msg.Text},
+		{"text/html; charset=utf-8", msg.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("encode message: %w", err)
+		}
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("encode message: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("encode message: %w", err)
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("encode message: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// responseError turns SMTP replies into a *remote.ResponseError, retryable
+// for the 4xx ones.
+func responseError(err error) error {
+	var reply *textproto.Error
+	if errors.As(err, &reply) {
+		return &remote.ResponseError{Service: "smtp", StatusCode: reply.Code, Message: reply.Msg, Retryable: reply.Code < 500}
+	}
+	return err
+}
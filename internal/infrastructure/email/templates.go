@@ -0,0 +1,108 @@
+package email
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+	"time"
+)
+
+// Names of the notification templates. Each has a text and an HTML version;
+// the text one also defines the subject.
+const (
+	TemplateAssigned   = "assigned"
+	TemplateUnassigned = "unassigned"
+	TemplateDigest     = "digest"
+)
+
+type PullRequest struct {
+	ID       string
+	Title    string
+	Author   string
+	OpenedAt time.Time
+	Age      time.Duration
+}
+
+// Assignment is the data of TemplateAssigned. Replaces names the reviewer the
+// pull request was taken from, if any.
+type Assignment struct {
+	Reviewer    string
+	PullRequest PullRequest
+	Replaces    string
+}
+
+// Unassignment is the data of TemplateUnassigned.
+type Unassignment struct {
+	Reviewer    string
+	PullRequest PullRequest
+	ReplacedBy  string
+}
+
+// Digest is the data of TemplateDigest; PullRequests are the oldest first.
+type Digest struct {
+	Reviewer     string
+	Date         time.Time
+	PullRequests []PullRequest
+}
+
+//go:embed templates
+var templateFS embed.FS
+
+var funcs = map[string]any{"age": formatAge}
+
+type template struct {
+	text *texttemplate.Template
+	html *htmltemplate.Template
+}
+
+var templates = map[string]*template{
+	TemplateAssigned:   mustParse(TemplateAssigned),
+	TemplateUnassigned: mustParse(TemplateUnassigned),
+	TemplateDigest:     mustParse(TemplateDigest),
+}
+
+func mustParse(name string) *template {
+	return &template{
+		text: texttemplate.Must(texttemplate.New(name+".txt").Funcs(funcs).
+			ParseFS(templateFS, "templates/"+name+".txt")),
+		html: htmltemplate.Must(htmltemplate.New(name+".html").Funcs(funcs).
+			ParseFS(templateFS, "templates/"+name+".html")),
+	}
+}
+
+// Render builds the message of the named template for data, addressed to to.
+func Render(name, to string, data any) (*Message, error) {
+	t, ok := templates[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown email template %q", name)
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := t.text.ExecuteTemplate(&subject, "subject", data); err != nil {
+		return nil, fmt.Errorf("render %s subject: %w", name, err)
+	}
+	if err := t.text.Execute(&text, data); err != nil {
+		return nil, fmt.Errorf("render %s text: %w", name, err)
+	}
+	if err := t.html.Execute(&html, data); err != nil {
+		return nil, fmt.Errorf("render %s html: %w", name, err)
+	}
+
+	return &Message{To: to, Subject: subject.String(), Text: text.String(), HTML: html.String()}, nil
+}
+
+// formatAge renders d in its two largest units, such as "3d 4h" or "25m".
+func formatAge(d time.Duration) string {
+	days := int(d / (24 * time.Hour))
+	hours := int(d % (24 * time.Hour) / time.Hour)
+	minutes := int(d % time.Hour / time.Minute)
+	switch {
+	case days > 0:
+		return fmt.Sprintf("%dd %dh", days, hours)
+	case hours > 0:
+		return fmt.Sprintf("%dh %dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dm", minutes)
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"pullrequest-inator/internal/infrastructure/remote"
+	"strconv"
+	"strings"
+)
+
+const DefaultAPIURL = "https://api.github.com"
+
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+func NewClient(baseURL, token string, httpClient *http.Client) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    httpClient,
+	}
+}
+
+// SyncReviewers requests reviews from reviewers and withdraws the requests of
+// removed on pull request number of repository ("owner/name"). Requesting a
+// review that is already requested is a no-op on GitHub.
+func (c *Client) SyncReviewers(ctx context.Context, repository string, number int, reviewers, removed []string) error {
+	path := "/repos/" + repository + "/pulls/" + strconv.Itoa(number) + "/requested_reviewers"
+	if len(removed) > 0 {
+		if err := c.do(ctx, http.MethodDelete, path, map[string][]string{"reviewers": removed}); err != nil {
+			return err
+		}
+	}
+	if len(reviewers) > 0 {
+		if err := c.do(ctx, http.MethodPost, path, map[string][]string{"reviewers": reviewers}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("marshal request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return remote.CheckResponse("github api", resp)
+}
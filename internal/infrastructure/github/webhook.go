@@ -0,0 +1,103 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	ErrInvalidPayload   = errors.New("invalid webhook payload")
+)
+
+const (
+	EventPing        = "ping"
+	EventPullRequest = "pull_request"
+
+	ActionOpened           = "opened"
+	ActionEdited           = "edited"
+	ActionClosed           = "closed"
+	ActionReopened         = "reopened"
+	ActionReadyForReview   = "ready_for_review"
+	ActionConvertedToDraft = "converted_to_draft"
+
+	signaturePrefix = "sha256="
+)
+
+type Account struct {
+	Login string `json:"login"`
+}
+
+type Repository struct {
+	FullName string `json:"full_name"`
+}
+
+type PullRequest struct {
+	Number   int      `json:"number"`
+	Title    string   `json:"title"`
+	HTMLURL  string   `json:"html_url"`
+	Draft    bool     `json:"draft"`
+	Merged   bool     `json:"merged"`
+	User     Account  `json:"user"`
+	MergedBy *Account `json:"merged_by"`
+}
+
+// Change is an entry of the changes object of an edited event, holding the
+// previous value.
+type Change struct {
+	From string `json:"from"`
+}
+
+type Changes struct {
+	Title *Change `json:"title"`
+}
+
+// PullRequestEvent is the part of a pull_request webhook payload the service
+// reacts to.
+type PullRequestEvent struct {
+	Action      string      `json:"action"`
+	Number      int         `json:"number"`
+	PullRequest PullRequest `json:"pull_request"`
+	Repository  Repository  `json:"repository"`
+	Sender      Account     `json:"sender"`
+	Changes     Changes     `json:"changes"`
+}
+
+// VerifySignature checks the X-Hub-Signature-256 header of a delivery against
+// the HMAC-SHA256 of its raw body.
+func VerifySignature(secret []byte, header string, body []byte) error {
+	if len(secret) == 0 {
+		return fmt.Errorf("%w: webhook secret is not configured", ErrInvalidSignature)
+	}
+	if !strings.HasPrefix(header, signaturePrefix) {
+		return fmt.Errorf("%w: missing %s signature", ErrInvalidSignature, signaturePrefix)
+	}
+
+	got, err := hex.DecodeString(strings.TrimPrefix(header, signaturePrefix))
+	if err != nil {
+		return fmt.Errorf("%w: malformed signature", ErrInvalidSignature)
+	}
+
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
+
+func ParsePullRequestEvent(body []byte) (*PullRequestEvent, error) {
+	var event PullRequestEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if event.Action == "" || event.Repository.FullName == "" || event.PullRequest.Number == 0 {
+		return nil, fmt.Errorf("%w: not a pull_request event", ErrInvalidPayload)
+	}
+	return &event, nil
+}
@@ -0,0 +1,121 @@
+package gitlab
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"pullrequest-inator/internal/infrastructure/remote"
+	"strconv"
+	"strings"
+)
+
+const service = "gitlab api"
+
+type Client struct {
+	baseURL string
+	token   string
+	http    *http.Client
+}
+
+// NewClient returns a client for the REST API at baseURL, e.g.
+// https://gitlab.example.com/api/v4.
+func NewClient(baseURL, token string, httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		token:   token,
+		http:    httpClient,
+	}
+}
+
+// SyncReviewers sets the reviewers of merge request iid of project
+// ("group/name") to exactly the given usernames. GitLab replaces the whole
+// list, so removed needs no separate request.
+func (c *Client) SyncReviewers(ctx context.Context, project string, iid int, reviewers, _ []string) error {
+	ids := make([]int, 0, len(reviewers))
+	for _, username := range reviewers {
+		id, err := c.userID(ctx, username)
+		if err != nil {
+			return err
+		}
+		ids = append(ids, id)
+	}
+
+	path := "/projects/" + url.PathEscape(project) + "/merge_requests/" + strconv.Itoa(iid)
+	return c.do(ctx, http.MethodPut, path, map[string][]int{"reviewer_ids": ids}, nil)
+}
+
+// Username returns the username of the GitLab user with the given ID.
+func (c *Client) Username(ctx context.Context, id int) (string, error) {
+	var user struct {
+		Username string `json:"username"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/users/"+strconv.Itoa(id), nil, &user); err != nil {
+		return "", err
+	}
+	return user.Username, nil
+}
+
+func (c *Client) userID(ctx context.Context, username string) (int, error) {
+	var users []struct {
+		ID int `json:"id"`
+	}
+	if err := c.do(ctx, http.MethodGet, "/users?username="+url.QueryEscape(username), nil, &users); err != nil {
+		return 0, err
+	}
+	if len(users) == 0 {
+		return 0, &remote.ResponseError{
+			Service:    service,
+			StatusCode: http.StatusNotFound,
+			Message:    fmt.Sprintf("no GitLab user %q", username),
+		}
+	}
+	return users[0].ID, nil
+}
+
+func (c *Client) do(ctx context.Context, method, path string, body, out any) error {
+	var payload io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("marshal request: %w", err)
+		}
+		payload = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, payload)
+	if err != nil {
+		return fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	}
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return fmt.Errorf("%s %s: %w", method, path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if err := remote.CheckResponse(service, resp); err != nil {
+		return err
+	}
+	if out != nil {
+		if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+			return fmt.Errorf("decode response: %w", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,141 @@
+package gitlab
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	ErrInvalidToken   = errors.New("invalid webhook token")
+	ErrInvalidPayload = errors.New("invalid webhook payload")
+)
+
+const (
+	EventMergeRequest = "Merge Request Hook"
+
+	ActionOpen   = "open"
+	ActionUpdate = "update"
+	ActionMerge  = "merge"
+	ActionClose  = "close"
+	ActionReopen = "reopen"
+)
+
+type User struct {
+	ID       int    `json:"id"`
+	Username string `json:"username"`
+}
+
+type Project struct {
+	PathWithNamespace string `json:"path_with_namespace"`
+}
+
+type MergeRequest struct {
+	IID            int    `json:"iid"`
+	Title          string `json:"title"`
+	URL            string `json:"url"`
+	Action         string `json:"action"`
+	Draft          bool   `json:"draft"`
+	WorkInProgress bool   `json:"work_in_progress"`
+	AuthorID       int    `json:"author_id"`
+	MergeUserID    int    `json:"merge_user_id"`
+}
+
+// BoolChange is an entry of the changes object for a boolean attribute.
+type BoolChange struct {
+	Previous bool `json:"previous"`
+	Current  bool `json:"current"`
+}
+
+// StringChange is an entry of the changes object for a string attribute.
+type StringChange struct {
+	Previous string `json:"previous"`
+	Current  string `json:"current"`
+}
+
+type Changes struct {
+	Title          *StringChange `json:"title"`
+	Draft          *BoolChange   `json:"draft"`
+	WorkInProgress *BoolChange   `json:"work_in_progress"`
+}
+
+// MergeRequestEvent is the part of a Merge Request Hook payload the service
+// reacts to. User is whoever triggered the event, who is not necessarily the
+// author of the merge request or the person who merged it.
+type MergeRequestEvent struct {
+	ObjectKind       string       `json:"object_kind"`
+	User             User         `json:"user"`
+	Project          Project      `json:"project"`
+	ObjectAttributes MergeRequest `json:"object_attributes"`
+	Changes          Changes      `json:"changes"`
+}
+
+// LeftDraft reports whether an update event took the merge request out of
+// draft. GitLab before 15.0 only reports the change as work_in_progress.
+func (e *MergeRequestEvent) LeftDraft() bool {
+	change := e.draftChange()
+	return change != nil && change.Previous && !change.Current
+}
+
+// EnteredDraft reports whether an update event put the merge request back in
+// draft.
+func (e *MergeRequestEvent) EnteredDraft() bool {
+	change := e.draftChange()
+	return change != nil && !change.Previous && change.Current
+}
+
+// Retitled reports whether an update event changed the title.
+func (e *MergeRequestEvent) Retitled() bool {
+	return e.Changes.Title != nil && e.Changes.Title.Previous != e.Changes.Title.Current
+}
+
+func (e *MergeRequestEvent) draftChange() *BoolChange {
+	if e.Changes.Draft != nil {
+		return e.Changes.Draft
+	}
+	return e.Changes.WorkInProgress
+}
+
+// VerifyToken checks the X-Gitlab-Token header against the secret token
+// configured on the GitLab webhook.
+func VerifyToken(secret, token string) error {
+	if secret == "" {
+		return fmt.Errorf("%w: webhook token is not configured", ErrInvalidToken)
+	}
+	if subtle.ConstantTimeCompare([]byte(secret), []byte(token)) != 1 {
+		return ErrInvalidToken
+	}
+	return nil
+}
+
+func ParseMergeRequestEvent(body []byte) (*MergeRequestEvent, error) {
+	var event MergeRequestEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	if event.ObjectKind != "merge_request" || event.Project.PathWithNamespace == "" || event.ObjectAttributes.IID == 0 {
+		return nil, fmt.Errorf("%w: not a merge request event", ErrInvalidPayload)
+	}
+	return &event, nil
+}
+
+// ParseUserMapping parses a comma-separated list of gitlab_username=user_id
+// pairs.
+func ParseUserMapping(raw string) (map[string]string, error) {
+	mapping := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		username, userID, ok := strings.Cut(pair, "=")
+		username, userID = strings.TrimSpace(username), strings.TrimSpace(userID)
+		if !ok || username == "" || userID == "" {
+			return nil, fmt.Errorf("invalid user mapping %q, expected gitlab_username=user_id", pair)
+		}
+		mapping[username] = userID
+	}
+	return mapping, nil
+}
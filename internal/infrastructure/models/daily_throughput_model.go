@@ -0,0 +1,12 @@
+package models
+
+import (
+	"time"
+)
+
+// DailyThroughput counts the pull requests opened and merged on a UTC day.
+type DailyThroughput struct {
+	Day    time.Time `db:"day"`
+	Opened int       `db:"opened"`
+	Merged int       `db:"merged"`
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ProviderGitHub = "github"
+	ProviderGitLab = "gitlab"
+)
+
+// ExternalPullRequest links a pull request to the one it mirrors on a code
+// hosting provider.
+type ExternalPullRequest struct {
+	PullRequestID int64     `db:"pull_request_id"`
+	Provider      string    `db:"provider"`
+	Repository    string    `db:"repository"`
+	Number        int       `db:"number"`
+	URL           string    `db:"url"`
+	CreatedAt     time.Time `db:"created_at"`
+}
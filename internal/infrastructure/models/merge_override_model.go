@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+type MergeOverride struct {
+	ID              int64     `db:"id"`
+	PullRequestID   int64     `db:"pull_request_id"`
+	ForcedBy        *int64    `db:"forced_by"`
+	Reason          string    `db:"reason"`
+	UnmetConditions []string  `db:"unmet_conditions"`
+	CreatedAt       time.Time `db:"created_at"`
+}
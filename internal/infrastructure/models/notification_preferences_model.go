@@ -0,0 +1,14 @@
+package models
+
+import (
+	"time"
+)
+
+// NotificationPreferences are a user's notification opt-outs. Users without
+// stored preferences get every notification.
+type NotificationPreferences struct {
+	UserID       int64     `db:"user_id"`
+	ChatEnabled  bool      `db:"chat_enabled"`
+	EmailEnabled bool      `db:"email_enabled"`
+	UpdatedAt    time.Time `db:"updated_at"`
+}
@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+// OutboxMessage is an event stored in the same transaction as the change it
+// describes, waiting to be handed to the event sinks. A message that ran out
+// of attempts is kept with FailedAt set instead of ProcessedAt.
+type OutboxMessage struct {
+	ID          int64      `db:"id"`
+	EventType   string     `db:"event_type"`
+	Payload     []byte     `db:"payload"`
+	Attempts    int        `db:"attempts"`
+	LastError   *string    `db:"last_error"`
+	AvailableAt time.Time  `db:"available_at"`
+	CreatedAt   time.Time  `db:"created_at"`
+	ProcessedAt *time.Time `db:"processed_at"`
+	FailedAt    *time.Time `db:"failed_at"`
+	// DoneSinks names the sinks that already took the message.
+	DoneSinks []string `db:"done_sinks"`
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// PendingReview is an open pull request still waiting for the reviewer's
+// decision.
+type PendingReview struct {
+	ReviewerID    int64     `db:"reviewer_id"`
+	PullRequestID int64     `db:"pull_request_id"`
+	TeamID        *int64    `db:"team_id"`
+	Title         string    `db:"title"`
+	AuthorID      int64     `db:"author_id"`
+	CreatedAt     time.Time `db:"created_at"`
+	AssignedAt    time.Time `db:"assigned_at"`
+}
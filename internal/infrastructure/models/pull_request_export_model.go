@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// PullRequestExport is a pull request as exported for reporting, with its
+// current reviewers and how many times they were replaced.
+type PullRequestExport struct {
+	ID              int64      `db:"id"`
+	Title           string     `db:"title"`
+	AuthorID        int64      `db:"author_id"`
+	TeamName        *string    `db:"team_name"`
+	Status          string     `db:"status"`
+	ReviewerIDs     []int64    `db:"reviewer_ids"`
+	CreatedAt       time.Time  `db:"created_at"`
+	FirstReviewedAt *time.Time `db:"first_reviewed_at"`
+	MergedAt        *time.Time `db:"merged_at"`
+	ClosedAt        *time.Time `db:"closed_at"`
+	Reassignments   int        `db:"reassignments"`
+}
@@ -0,0 +1,25 @@
+package models
+
+// PullRequestStats sums up the pull requests created in a period: their
+// statuses, how long they waited for the first review, and how long the ones
+// merged in the period took to merge.
+type PullRequestStats struct {
+	Total  int `db:"total"`
+	Draft  int `db:"draft"`
+	Open   int `db:"open"`
+	Merged int `db:"merged"`
+	Closed int `db:"closed"`
+
+	FirstReview Latency
+	Merge       Latency
+
+	Reassignments          int `db:"reassignments"`
+	ReassignedPullRequests int `db:"reassigned_pull_requests"`
+}
+
+// Latency is the distribution of a duration in hours. Percentiles holds the
+// 50th, 90th and 99th percentiles, and is empty when Count is 0.
+type Latency struct {
+	Count       int
+	Percentiles []float64
+}
@@ -8,10 +8,33 @@ type PullRequest struct {
 	ID        int64      `db:"id"`
 	Title     string     `db:"title"`
 	AuthorID  int64      `db:"author_id"`
+	TeamID    *int64     `db:"team_id"`
+	TeamName  *string    `db:"team_name"`
 	StatusID  int64      `db:"status_id"`
 	MergedAt  *time.Time `db:"merged_at"`
+	ClosedAt  *time.Time `db:"closed_at"`
 	CreatedAt time.Time  `db:"created_at"`
 	UpdatedAt time.Time  `db:"updated_at"`
 
 	ReviewersIDs []int64
+	Reviews      []Review
+}
+
+const (
+	ReviewDecisionApproved         = "APPROVED"
+	ReviewDecisionChangesRequested = "CHANGES_REQUESTED"
+	ReviewDecisionCommented        = "COMMENTED"
+)
+
+type Review struct {
+	ReviewerID int64      `db:"reviewer_id"`
+	AssignedAt *time.Time `db:"assigned_at"`
+	Decision   *string    `db:"decision"`
+	DecidedAt  *time.Time `db:"decided_at"`
+}
+
+type ReviewerReplacement struct {
+	PullRequestID int64
+	OldReviewerID int64
+	NewReviewerID int64
 }
@@ -0,0 +1,20 @@
+package models
+
+import (
+	"time"
+)
+
+// ReviewAssignmentExport is a review assigned on a pull request, as exported
+// for reporting. A reviewer who was replaced has ReassignedAt and ReplacedBy
+// set; when they were assigned and what they decided is not kept.
+type ReviewAssignmentExport struct {
+	PullRequestID int64      `db:"pull_request_id"`
+	TeamName      *string    `db:"team_name"`
+	Status        string     `db:"status"`
+	ReviewerID    int64      `db:"reviewer_id"`
+	AssignedAt    *time.Time `db:"assigned_at"`
+	Decision      *string    `db:"decision"`
+	DecidedAt     *time.Time `db:"decided_at"`
+	ReassignedAt  *time.Time `db:"reassigned_at"`
+	ReplacedBy    *int64     `db:"replaced_by"`
+}
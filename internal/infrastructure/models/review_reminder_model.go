@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// ReviewReminder records the reminders sent about an assignment. Like
+// ReviewSLABreach, an assignment is identified by its AssignedAt.
+type ReviewReminder struct {
+	PullRequestID int64     `db:"pull_request_id"`
+	ReviewerID    int64     `db:"reviewer_id"`
+	AssignedAt    time.Time `db:"assigned_at"`
+	Reminders     int       `db:"reminders"`
+	RemindedAt    time.Time `db:"reminded_at"`
+}
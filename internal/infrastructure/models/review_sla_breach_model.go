@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// ReviewSLABreach flags an assignment that went past its team's review SLA.
+// An assignment is identified by its AssignedAt, so a reviewer assigned to
+// the same pull request again can be flagged again.
+type ReviewSLABreach struct {
+	PullRequestID int64     `db:"pull_request_id"`
+	ReviewerID    int64     `db:"reviewer_id"`
+	AssignedAt    time.Time `db:"assigned_at"`
+	BreachedAt    time.Time `db:"breached_at"`
+}
@@ -0,0 +1,8 @@
+package models
+
+// ReviewerAssignments counts the reviews assigned to a reviewer.
+type ReviewerAssignments struct {
+	ReviewerID int64  `db:"reviewer_id"`
+	Username   string `db:"username"`
+	Assigned   int    `db:"assigned"`
+}
@@ -0,0 +1,21 @@
+package models
+
+// ReviewerLoad is the review workload of a reviewer in a team: the reviews
+// of the team's pull requests assigned to them, by status.
+type ReviewerLoad struct {
+	TeamID     int64  `db:"team_id"`
+	TeamName   string `db:"team_name"`
+	ReviewerID int64  `db:"reviewer_id"`
+	Username   string `db:"username"`
+	IsActive   bool   `db:"is_active"`
+
+	Assigned int `db:"assigned"`
+	// Open counts the reviews of open pull requests still awaiting a decision.
+	Open   int `db:"open"`
+	Merged int `db:"merged"`
+	// AverageOpenAgeHours is how long the open reviews have been assigned on
+	// average, nil when there are none.
+	AverageOpenAgeHours *float64 `db:"average_open_age_hours"`
+	// TeamAssigned is the number of reviews assigned in the team overall.
+	TeamAssigned int `db:"team_assigned"`
+}
@@ -0,0 +1,18 @@
+package models
+
+import (
+	"time"
+)
+
+// ReviewerSyncFailure records reviewers that could not be written back to the
+// provider hosting the pull request.
+type ReviewerSyncFailure struct {
+	ID            int64     `db:"id"`
+	PullRequestID int64     `db:"pull_request_id"`
+	Provider      string    `db:"provider"`
+	Reviewers     []string  `db:"reviewers"`
+	Removed       []string  `db:"removed"`
+	Attempts      int       `db:"attempts"`
+	Error         string    `db:"error"`
+	CreatedAt     time.Time `db:"created_at"`
+}
@@ -0,0 +1,13 @@
+package models
+
+import (
+	"time"
+)
+
+// StatsFilter narrows statistics down to the pull requests of a team and to
+// the events in [From, To). Nil fields do not filter.
+type StatsFilter struct {
+	TeamID *int64
+	From   *time.Time
+	To     *time.Time
+}
@@ -0,0 +1,17 @@
+package models
+
+import (
+	"time"
+)
+
+// TeamChatChannel is the incoming webhook a team's notifications are posted
+// to. Provider is ProviderSlack or ProviderMattermost and selects the message
+// markup; an empty Channel keeps the webhook's own channel.
+type TeamChatChannel struct {
+	TeamID     int64     `db:"team_id"`
+	Provider   string    `db:"provider"`
+	WebhookURL string    `db:"webhook_url"`
+	Channel    string    `db:"channel"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
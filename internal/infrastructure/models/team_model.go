@@ -5,10 +5,14 @@ import (
 )
 
 type Team struct {
-	ID        int64     `db:"id"`
-	Name      string    `db:"name"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID                int64     `db:"id"`
+	Name              string    `db:"name"`
+	ReviewerStrategy  string    `db:"reviewer_strategy"`
+	MinReviewers      *int      `db:"min_reviewers"`
+	MaxReviewers      *int      `db:"max_reviewers"`
+	RequiredApprovals int       `db:"required_approvals"`
+	CreatedAt         time.Time `db:"created_at"`
+	UpdatedAt         time.Time `db:"updated_at"`
 
 	UserIDs []int64
 }
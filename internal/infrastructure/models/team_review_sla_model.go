@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+// TeamReviewSLA is how long the reviewers of a team's pull requests may take
+// to decide, counted in working time. The working day runs from
+// WorkStartMinutes to WorkEndMinutes after midnight in Timezone, on WorkDays
+// (ISO weekdays, 1 is Monday).
+type TeamReviewSLA struct {
+	TeamID               int64     `db:"team_id"`
+	FirstDecisionSeconds int       `db:"first_decision_seconds"`
+	WorkStartMinutes     int       `db:"work_start_minutes"`
+	WorkEndMinutes       int       `db:"work_end_minutes"`
+	WorkDays             []int     `db:"work_days"`
+	Timezone             string    `db:"timezone"`
+	AutoReassign         bool      `db:"auto_reassign"`
+	CreatedAt            time.Time `db:"created_at"`
+	UpdatedAt            time.Time `db:"updated_at"`
+}
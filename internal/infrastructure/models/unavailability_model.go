@@ -0,0 +1,21 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	UnavailabilitySourceManual = "manual"
+	UnavailabilitySourceICS    = "ics"
+)
+
+type Unavailability struct {
+	ID          int64     `db:"id"`
+	UserID      int64     `db:"user_id"`
+	StartsAt    time.Time `db:"starts_at"`
+	EndsAt      time.Time `db:"ends_at"`
+	Reason      string    `db:"reason"`
+	Source      string    `db:"source"`
+	ExternalUID *string   `db:"external_uid"`
+	CreatedAt   time.Time `db:"created_at"`
+}
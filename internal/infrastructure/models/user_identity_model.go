@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+)
+
+const (
+	ProviderSlack      = "slack"
+	ProviderMattermost = "mattermost"
+	ProviderEmail      = "email"
+)
+
+// UserIdentity is a user's account on an outside system: a VCS login, a chat
+// user ID or an email address. A user has at most one per provider.
+type UserIdentity struct {
+	ID         int64     `db:"id"`
+	UserID     int64     `db:"user_id"`
+	Provider   string    `db:"provider"`
+	ExternalID string    `db:"external_id"`
+	CreatedAt  time.Time `db:"created_at"`
+	UpdatedAt  time.Time `db:"updated_at"`
+}
@@ -0,0 +1,15 @@
+package models
+
+import (
+	"time"
+)
+
+// WebhookSubscription is an outside endpoint that receives events. An empty
+// Events list subscribes to every event.
+type WebhookSubscription struct {
+	ID        int64     `db:"id"`
+	URL       string    `db:"url"`
+	Secret    string    `db:"secret"`
+	Events    []string  `db:"events"`
+	CreatedAt time.Time `db:"created_at"`
+}
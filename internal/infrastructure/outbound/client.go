@@ -0,0 +1,65 @@
+package outbound
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"pullrequest-inator/internal/infrastructure/remote"
+)
+
+const (
+	HeaderEvent     = "X-Inator-Event"
+	HeaderDelivery  = "X-Inator-Delivery"
+	HeaderSignature = "X-Inator-Signature-256"
+
+	signaturePrefix = "sha256="
+	userAgent       = "pullrequest-inator"
+)
+
+// Sign returns the X-Inator-Signature-256 value for body: the hex HMAC-SHA256
+// of the body keyed with the subscription secret, prefixed with "sha256=".
+func Sign(secret, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+type Client struct {
+	http *http.Client
+}
+
+func NewClient(httpClient *http.Client) *Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Client{http: httpClient}
+}
+
+// Send posts body to url, signed with secret. It returns the response status,
+// or 0 when no response came back, along with a *remote.ResponseError for
+// non-2xx responses.
+func (c *Client) Send(ctx context.Context, url, secret, deliveryID, eventType string,
+	body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set(HeaderEvent, eventType)
+	req.Header.Set(HeaderDelivery, deliveryID)
+	req.Header.Set(HeaderSignature, Sign([]byte(secret), body))
+
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("POST %s: %w", url, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	return resp.StatusCode, remote.CheckResponse("subscriber", resp)
+}
@@ -0,0 +1,87 @@
+package outbound
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+	"syscall"
+)
+
+var ErrForbiddenAddress = errors.New("address not allowed")
+
+// Guard keeps subscriber requests off internal addresses: loopback, private,
+// link-local (cloud metadata endpoints included) and unspecified ones. Hosts
+// listed as allowed are exempt, for subscribers deliberately run in-network.
+type Guard struct {
+	allowedHosts map[string]bool
+	resolver     *net.Resolver
+	dialer       *net.Dialer
+}
+
+func NewGuard(allowedHosts []string) *Guard {
+	g := &Guard{allowedHosts: make(map[string]bool, len(allowedHosts)), resolver: net.DefaultResolver}
+	for _, host := range allowedHosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			g.allowedHosts[host] = true
+		}
+	}
+	g.dialer = &net.Dialer{Control: g.control}
+	return g
+}
+
+// CheckURL tells whether events may be sent to rawURL: an absolute http(s) URL
+// whose host resolves to allowed addresses only.
+func (g *Guard) CheckURL(ctx context.Context, rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return errors.New("url must be an absolute http(s) URL")
+	}
+
+	host := strings.ToLower(u.Hostname())
+	if g.allowedHosts[host] {
+		return nil
+	}
+	addrs, err := g.resolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if forbidden(addr.IP) {
+			return fmt.Errorf("%w: %s resolves to %s", ErrForbiddenAddress, host, addr.IP)
+		}
+	}
+	return nil
+}
+
+// DialContext dials like net.Dialer, refusing forbidden addresses once they
+// are resolved, so a host cannot be pointed at one after CheckURL passed it.
+// It is meant for the transport of the client sending the events.
+func (g *Guard) DialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return nil, err
+	}
+	if g.allowedHosts[strings.ToLower(host)] {
+		return (&net.Dialer{}).DialContext(ctx, network, address)
+	}
+	return g.dialer.DialContext(ctx, network, address)
+}
+
+func (g *Guard) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	if ip := net.ParseIP(host); ip == nil || forbidden(ip) {
+		return fmt.Errorf("%w: %s", ErrForbiddenAddress, host)
+	}
+	return nil
+}
+
+func forbidden(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast()
+}
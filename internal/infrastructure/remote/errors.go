@@ -0,0 +1,57 @@
+// Package remote holds what the clients of external services share: the error
+// for a response the service rejected and whether repeating the call may help.
+package remote
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// ResponseError is a request the remote service answered with an error.
+type ResponseError struct {
+	// Service names the service in the error message.
+	Service    string
+	StatusCode int
+	Message    string
+	// Retryable reports whether repeating the request may succeed.
+	Retryable bool
+}
+
+func (e *ResponseError) Error() string {
+	return fmt.Sprintf("%s: status %d: %s", e.Service, e.StatusCode, e.Message)
+}
+
+func (e *ResponseError) Temporary() bool {
+	return e.Retryable
+}
+
+// CheckResponse returns a *ResponseError for a non-2xx resp, with the start of
+// its body as the message. Timeouts, rate limits and server errors are
+// retryable.
+func CheckResponse(service string, resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	return &ResponseError{
+		Service:    service,
+		StatusCode: resp.StatusCode,
+		Message:    strings.TrimSpace(string(msg)),
+		Retryable: resp.StatusCode == http.StatusRequestTimeout || resp.StatusCode == http.StatusTooManyRequests ||
+			resp.StatusCode >= http.StatusInternalServerError,
+	}
+}
+
+// Temporary reports whether err is worth retrying: anything but a response
+// the service marked as final, such as one naming an unknown user.
+func Temporary(err error) bool {
+	var t interface{ Temporary() bool }
+	if errors.As(err, &t) {
+		return t.Temporary()
+	}
+	return true
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"time"
+)
+
+type EmailDigest interface {
+	Claim(ctx context.Context, userID int64, day time.Time) (bool, error)
+	Release(ctx context.Context, userID int64, day time.Time) error
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type ExternalPullRequest interface {
+	Link(ctx context.Context, link *models.ExternalPullRequest) error
+	FindByPullRequestID(ctx context.Context, prID int64) (*models.ExternalPullRequest, error)
+	FindByExternal(ctx context.Context, provider, repository string, number int) (*models.ExternalPullRequest, error)
+}
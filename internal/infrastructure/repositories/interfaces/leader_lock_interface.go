@@ -0,0 +1,8 @@
+package repositories
+
+import "context"
+
+type LeaderLock interface {
+	TryAcquire(ctx context.Context) (bool, error)
+	Release(ctx context.Context) error
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type MergeOverride interface {
+	Create(ctx context.Context, override *models.MergeOverride) error
+	FindByPullRequestID(ctx context.Context, prID int64) ([]*models.MergeOverride, error)
+}
@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type NotificationPreferences interface {
+	Upsert(ctx context.Context, prefs *models.NotificationPreferences) error
+	FindByUserID(ctx context.Context, userID int64) (*models.NotificationPreferences, error)
+	FindByUserIDs(ctx context.Context, userIDs []int64) ([]*models.NotificationPreferences, error)
+}
@@ -0,0 +1,17 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+	"time"
+)
+
+type Outbox interface {
+	Create(ctx context.Context, message *models.OutboxMessage) error
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*models.OutboxMessage, error)
+	MarkSinkDone(ctx context.Context, id int64, sink string) error
+	MarkProcessed(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, cause string, retryAt time.Time) error
+	MarkDead(ctx context.Context, id int64, cause string) error
+	DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error)
+}
@@ -3,11 +3,16 @@ package repositories
 import (
 	"context"
 	"pullrequest-inator/internal/infrastructure/models"
+	"time"
 )
 
 type PullRequest interface {
 	Repository[models.PullRequest, int64]
+	LockByID(ctx context.Context, id int64) (*models.PullRequest, error)
 	FindByReviewer(ctx context.Context, userID int64) ([]*models.PullRequest, error)
-	GetPRStatusCounts(ctx context.Context) (map[string]int, error)
-	GetReviewerStats(ctx context.Context) (map[int64]int, error)
+	CountOpenReviews(ctx context.Context, userIDs []int64) (map[int64]int, error)
+	FindOpenByReviewers(ctx context.Context, userIDs []int64) ([]*models.PullRequest, error)
+	FindPendingReviews(ctx context.Context, userIDs []int64) ([]*models.PendingReview, error)
+	ReplaceReviewers(ctx context.Context, replacements []models.ReviewerReplacement) error
+	SetReviewDecision(ctx context.Context, prID, reviewerID int64, decision string, decidedAt time.Time) error
 }
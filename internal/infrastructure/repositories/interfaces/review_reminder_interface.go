@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+	"time"
+)
+
+type ReviewReminder interface {
+	FindDue(ctx context.Context, staleBefore time.Time) ([]*models.PendingReview, error)
+	Record(ctx context.Context, reminder *models.ReviewReminder, remindedBefore time.Time) (bool, error)
+	DeleteSettled(ctx context.Context) (int64, error)
+}
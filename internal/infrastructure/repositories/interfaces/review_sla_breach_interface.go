@@ -0,0 +1,10 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type ReviewSLABreach interface {
+	Create(ctx context.Context, breach *models.ReviewSLABreach) (bool, error)
+}
@@ -0,0 +1,11 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type ReviewerSyncFailure interface {
+	Create(ctx context.Context, failure *models.ReviewerSyncFailure) error
+	FindByPullRequestID(ctx context.Context, prID int64) ([]*models.ReviewerSyncFailure, error)
+}
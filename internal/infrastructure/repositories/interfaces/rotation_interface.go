@@ -0,0 +1,8 @@
+package repositories
+
+import "context"
+
+type Rotation interface {
+	LockCursor(ctx context.Context, teamID int64) (int64, error)
+	AdvanceCursor(ctx context.Context, teamID int64, lastUserID int64) error
+}
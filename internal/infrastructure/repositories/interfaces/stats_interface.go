@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type Stats interface {
+	GetPullRequestStats(ctx context.Context, filter models.StatsFilter) (*models.PullRequestStats, error)
+	GetThroughput(ctx context.Context, filter models.StatsFilter) ([]*models.DailyThroughput, error)
+	GetReviewerAssignments(ctx context.Context, filter models.StatsFilter) ([]*models.ReviewerAssignments, error)
+	GetReviewerLoads(ctx context.Context, teamID, reviewerID *int64) ([]*models.ReviewerLoad, error)
+	// ExportPullRequests and ExportReviewAssignments call fn for each row as it
+	// is read and stop at the first error it returns. fn must not keep the row,
+	// it is reused for the next one.
+	ExportPullRequests(ctx context.Context, filter models.StatsFilter,
+		fn func(*models.PullRequestExport) error) error
+	ExportReviewAssignments(ctx context.Context, filter models.StatsFilter,
+		fn func(*models.ReviewAssignmentExport) error) error
+}
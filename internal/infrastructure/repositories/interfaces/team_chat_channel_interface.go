@@ -0,0 +1,12 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type TeamChatChannel interface {
+	Upsert(ctx context.Context, channel *models.TeamChatChannel) error
+	FindByTeamID(ctx context.Context, teamID int64) (*models.TeamChatChannel, error)
+	DeleteByTeamID(ctx context.Context, teamID int64) error
+}
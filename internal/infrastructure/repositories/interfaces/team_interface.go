@@ -9,6 +9,9 @@ import (
 type Team interface {
 	Repository[models.Team, int64]
 	FindByName(ctx context.Context, name string) (*models.Team, error)
-	FindByUserID(ctx context.Context, userID int64) (*models.Team, error)
+	FindAllByUserID(ctx context.Context, userID int64) ([]*models.Team, error)
 	CreateWithUsers(ctx context.Context, teamReq *dtos.Team) error
+	UpdateSettings(ctx context.Context, team *models.Team) error
+	FindBackupTeams(ctx context.Context, teamID int64) ([]*models.Team, error)
+	SetBackupTeams(ctx context.Context, teamID int64, backupTeamIDs []int64) error
 }
@@ -0,0 +1,13 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type TeamReviewSLA interface {
+	Upsert(ctx context.Context, sla *models.TeamReviewSLA) error
+	FindByTeamID(ctx context.Context, teamID int64) (*models.TeamReviewSLA, error)
+	FindAll(ctx context.Context) ([]*models.TeamReviewSLA, error)
+	DeleteByTeamID(ctx context.Context, teamID int64) error
+}
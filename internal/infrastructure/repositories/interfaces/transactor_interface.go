@@ -0,0 +1,7 @@
+package repositories
+
+import "context"
+
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+	"time"
+)
+
+type Unavailability interface {
+	Create(ctx context.Context, period *models.Unavailability) error
+	FindByUserID(ctx context.Context, userID int64) ([]*models.Unavailability, error)
+	DeleteByID(ctx context.Context, userID int64, id int64) error
+	SyncImported(ctx context.Context, userID int64, periods []*models.Unavailability) (int64, error)
+	FindUnavailableAt(ctx context.Context, userIDs []int64, at time.Time) (map[int64]bool, error)
+}
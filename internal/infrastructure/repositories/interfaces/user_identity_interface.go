@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type UserIdentity interface {
+	Upsert(ctx context.Context, identity *models.UserIdentity) error
+	FindByUserID(ctx context.Context, userID int64) ([]*models.UserIdentity, error)
+	FindByExternalID(ctx context.Context, provider, externalID string) (*models.UserIdentity, error)
+	FindByUserIDs(ctx context.Context, provider string, userIDs []int64) ([]*models.UserIdentity, error)
+	Delete(ctx context.Context, userID int64, provider string) error
+}
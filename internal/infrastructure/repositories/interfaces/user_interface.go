@@ -1,9 +1,13 @@
 package repositories
 
 import (
+	"context"
 	"pullrequest-inator/internal/infrastructure/models"
 )
 
 type User interface {
 	Repository[models.User, int64]
+	FindByIDs(ctx context.Context, ids []int64) ([]*models.User, error)
+	FindByUsername(ctx context.Context, username string) (*models.User, error)
+	SetActive(ctx context.Context, ids []int64, active bool) error
 }
@@ -0,0 +1,14 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type WebhookDelivery interface {
+	Create(ctx context.Context, delivery *models.WebhookDelivery) error
+	FindByID(ctx context.Context, id int64) (*models.WebhookDelivery, error)
+	FindByEvent(ctx context.Context, subscriptionID int64, eventType string, payload []byte) (*models.WebhookDelivery, error)
+	FindBySubscriptionID(ctx context.Context, subscriptionID int64) ([]*models.WebhookDelivery, error)
+	RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error
+}
@@ -0,0 +1,15 @@
+package repositories
+
+import (
+	"context"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+type WebhookSubscription interface {
+	Create(ctx context.Context, subscription *models.WebhookSubscription) error
+	FindByID(ctx context.Context, id int64) (*models.WebhookSubscription, error)
+	FindAll(ctx context.Context) ([]*models.WebhookSubscription, error)
+	FindByEvent(ctx context.Context, eventType string) ([]*models.WebhookSubscription, error)
+	UpdateSecret(ctx context.Context, id int64, secret string) error
+	DeleteByID(ctx context.Context, id int64) error
+}
@@ -0,0 +1,85 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	tryAdvisoryLockQuery = `SELECT pg_try_advisory_lock(hashtext($1));`
+	advisoryUnlockQuery  = `SELECT pg_advisory_unlock(hashtext($1));`
+)
+
+// AdvisoryLock elects a leader among the service replicas with a session-level
+// Postgres advisory lock. The holder keeps a pool connection to itself for as
+// long as it leads, so the lock goes to another replica as soon as the
+// holder's connection drops, whether it shut down or crashed.
+type AdvisoryLock struct {
+	db   *pgxpool.Pool
+	name string
+
+	mu   sync.Mutex
+	conn *pgxpool.Conn
+}
+
+func NewAdvisoryLock(db *pgxpool.Pool, name string) *AdvisoryLock {
+	return &AdvisoryLock{db: db, name: name}
+}
+
+// TryAcquire reports whether this replica holds the lock, taking it if it is
+// free. A held lock whose connection no longer answers is given up.
+func (l *AdvisoryLock) TryAcquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		if err := l.conn.Ping(ctx); err == nil {
+			return true, nil
+		}
+		l.discard(ctx)
+	}
+
+	c, err := l.db.Acquire(ctx)
+	if err != nil {
+		return false, fmt.Errorf("acquire connection for lock %q: %w", l.name, err)
+	}
+	var locked bool
+	if err := c.QueryRow(ctx, tryAdvisoryLockQuery, l.name).Scan(&locked); err != nil {
+		c.Release()
+		return false, fmt.Errorf("try lock %q: %w", l.name, err)
+	}
+	if !locked {
+		c.Release()
+		return false, nil
+	}
+
+	l.conn = c
+	return true, nil
+}
+
+// Release gives the lock up if this replica holds it.
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	if _, err := l.conn.Exec(ctx, advisoryUnlockQuery, l.name); err != nil {
+		l.discard(ctx)
+		return fmt.Errorf("unlock %q: %w", l.name, err)
+	}
+	l.conn.Release()
+	l.conn = nil
+	return nil
+}
+
+// discard closes the lock's connection instead of returning it to the pool,
+// which ends the session and with it any lock it may still hold.
+func (l *AdvisoryLock) discard(ctx context.Context) {
+	_ = l.conn.Hijack().Close(ctx)
+	l.conn = nil
+}
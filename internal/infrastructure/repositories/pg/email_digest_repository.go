@@ -0,0 +1,49 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type EmailDigestRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewEmailDigestRepository(db *pgxpool.Pool) *EmailDigestRepository {
+	return &EmailDigestRepository{db: db}
+}
+
+const (
+	claimEmailDigestQuery = `
+		INSERT INTO email_digests (user_id, digest_date)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, digest_date) DO NOTHING;
+	`
+	releaseEmailDigestQuery = `
+		DELETE FROM email_digests WHERE user_id = $1 AND digest_date = $2;
+	`
+)
+
+// Claim records that the user's digest for day is being sent. It reports
+// false if it already was, so each digest goes out once even with several
+// instances running.
+func (r *EmailDigestRepository) Claim(ctx context.Context, userID int64, day time.Time) (bool, error) {
+	cmd, err := conn(ctx, r.db).Exec(ctx, claimEmailDigestQuery, userID, day)
+	if err != nil {
+		return false, fmt.Errorf("claim digest of user %d: %w", userID, err)
+	}
+
+	return cmd.RowsAffected() == 1, nil
+}
+
+// Release undoes Claim so that a digest that failed to send can be retried.
+func (r *EmailDigestRepository) Release(ctx context.Context, userID int64, day time.Time) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, releaseEmailDigestQuery, userID, day); err != nil {
+		return fmt.Errorf("release digest of user %d: %w", userID, err)
+	}
+
+	return nil
+}
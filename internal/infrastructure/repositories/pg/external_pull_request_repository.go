@@ -0,0 +1,77 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrExternalPullRequestNotFound = errors.New("external pull request not found")
+
+type ExternalPullRequestRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewExternalPullRequestRepository(db *pgxpool.Pool) *ExternalPullRequestRepository {
+	return &ExternalPullRequestRepository{db: db}
+}
+
+const (
+	insertExternalPullRequestQuery = `
+		INSERT INTO external_pull_requests (pull_request_id, provider, repository, number, url)
+		VALUES ($1, $2, $3, $4, $5);
+	`
+	selectExternalPullRequestQuery = `
+		SELECT pull_request_id, provider, repository, number, url, created_at
+		FROM external_pull_requests
+		WHERE pull_request_id = $1;
+	`
+	selectExternalPullRequestByExternalQuery = `
+		SELECT pull_request_id, provider, repository, number, url, created_at
+		FROM external_pull_requests
+		WHERE provider = $1 AND repository = $2 AND number = $3;
+	`
+)
+
+func (r *ExternalPullRequestRepository) Link(ctx context.Context, link *models.ExternalPullRequest) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, insertExternalPullRequestQuery, link.PullRequestID, link.Provider,
+		link.Repository, link.Number, link.URL); err != nil {
+		return fmt.Errorf("link PR %d to %s %s#%d: %w", link.PullRequestID, link.Provider, link.Repository,
+			link.Number, err)
+	}
+
+	return nil
+}
+
+func (r *ExternalPullRequestRepository) FindByPullRequestID(ctx context.Context, prID int64) (*models.ExternalPullRequest, error) {
+	var link models.ExternalPullRequest
+	err := conn(ctx, r.db).QueryRow(ctx, selectExternalPullRequestQuery, prID).
+		Scan(&link.PullRequestID, &link.Provider, &link.Repository, &link.Number, &link.URL, &link.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrExternalPullRequestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find external pull request %d: %w", prID, err)
+	}
+
+	return &link, nil
+}
+
+func (r *ExternalPullRequestRepository) FindByExternal(ctx context.Context, provider, repository string,
+	number int) (*models.ExternalPullRequest, error) {
+	var link models.ExternalPullRequest
+	err := conn(ctx, r.db).QueryRow(ctx, selectExternalPullRequestByExternalQuery, provider, repository, number).
+		Scan(&link.PullRequestID, &link.Provider, &link.Repository, &link.Number, &link.URL, &link.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrExternalPullRequestNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find external pull request %s %s#%d: %w", provider, repository, number, err)
+	}
+
+	return &link, nil
+}
@@ -0,0 +1,63 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type MergeOverrideRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewMergeOverrideRepository(db *pgxpool.Pool) *MergeOverrideRepository {
+	return &MergeOverrideRepository{db: db}
+}
+
+const (
+	insertMergeOverrideQuery = `
+		INSERT INTO merge_overrides (pull_request_id, forced_by, reason, unmet_conditions)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at;
+	`
+	selectMergeOverridesByPRQuery = `
+		SELECT id, pull_request_id, forced_by, reason, unmet_conditions, created_at
+		FROM merge_overrides
+		WHERE pull_request_id = $1
+		ORDER BY created_at;
+	`
+)
+
+func (r *MergeOverrideRepository) Create(ctx context.Context, override *models.MergeOverride) error {
+	if err := conn(ctx, r.db).QueryRow(ctx, insertMergeOverrideQuery, override.PullRequestID, override.ForcedBy,
+		override.Reason, override.UnmetConditions).Scan(&override.ID, &override.CreatedAt); err != nil {
+		return fmt.Errorf("record merge override for PR %d: %w", override.PullRequestID, err)
+	}
+
+	return nil
+}
+
+func (r *MergeOverrideRepository) FindByPullRequestID(ctx context.Context, prID int64) ([]*models.MergeOverride, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectMergeOverridesByPRQuery, prID)
+	if err != nil {
+		return nil, fmt.Errorf("find merge overrides of PR %d: %w", prID, err)
+	}
+	defer rows.Close()
+
+	var list []*models.MergeOverride
+	for rows.Next() {
+		var o models.MergeOverride
+		if err := rows.Scan(&o.ID, &o.PullRequestID, &o.ForcedBy, &o.Reason, &o.UnmetConditions, &o.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan merge override: %w", err)
+		}
+		list = append(list, &o)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over merge override rows: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,90 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrNotificationPreferencesNotFound = errors.New("notification preferences not found")
+
+type NotificationPreferencesRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewNotificationPreferencesRepository(db *pgxpool.Pool) *NotificationPreferencesRepository {
+	return &NotificationPreferencesRepository{db: db}
+}
+
+const (
+	upsertNotificationPreferencesQuery = `
+		INSERT INTO notification_preferences (user_id, chat_enabled, email_enabled)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id) DO UPDATE
+		SET chat_enabled = EXCLUDED.chat_enabled, email_enabled = EXCLUDED.email_enabled, updated_at = NOW()
+		RETURNING updated_at;
+	`
+	selectNotificationPreferencesQuery = `
+		SELECT user_id, chat_enabled, email_enabled, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1;
+	`
+	selectNotificationPreferencesByUsersQuery = `
+		SELECT user_id, chat_enabled, email_enabled, updated_at
+		FROM notification_preferences
+		WHERE user_id = ANY($1);
+	`
+)
+
+func (r *NotificationPreferencesRepository) Upsert(ctx context.Context, prefs *models.NotificationPreferences) error {
+	if err := conn(ctx, r.db).QueryRow(ctx, upsertNotificationPreferencesQuery, prefs.UserID, prefs.ChatEnabled,
+		prefs.EmailEnabled).
+		Scan(&prefs.UpdatedAt); err != nil {
+		return fmt.Errorf("set notification preferences of user %d: %w", prefs.UserID, err)
+	}
+
+	return nil
+}
+
+func (r *NotificationPreferencesRepository) FindByUserID(ctx context.Context,
+	userID int64) (*models.NotificationPreferences, error) {
+	var p models.NotificationPreferences
+	err := conn(ctx, r.db).QueryRow(ctx, selectNotificationPreferencesQuery, userID).
+		Scan(&p.UserID, &p.ChatEnabled, &p.EmailEnabled, &p.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrNotificationPreferencesNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find notification preferences of user %d: %w", userID, err)
+	}
+
+	return &p, nil
+}
+
+func (r *NotificationPreferencesRepository) FindByUserIDs(ctx context.Context,
+	userIDs []int64) ([]*models.NotificationPreferences, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectNotificationPreferencesByUsersQuery, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find notification preferences: %w", err)
+	}
+	defer rows.Close()
+
+	list := make([]*models.NotificationPreferences, 0)
+	for rows.Next() {
+		var p models.NotificationPreferences
+		if err := rows.Scan(&p.UserID, &p.ChatEnabled, &p.EmailEnabled, &p.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan notification preferences: %w", err)
+		}
+		list = append(list, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over notification preferences rows: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,145 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type OutboxRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewOutboxRepository(db *pgxpool.Pool) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+const (
+	insertOutboxMessageQuery = `
+		INSERT INTO outbox (event_type, payload)
+		VALUES ($1, $2)
+		RETURNING id, available_at, created_at;
+	`
+	claimPendingOutboxMessagesQuery = `
+		WITH due AS (
+			SELECT id
+			FROM outbox
+			WHERE processed_at IS NULL AND failed_at IS NULL AND available_at <= NOW()
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		UPDATE outbox o
+		SET available_at = NOW() + $2 * INTERVAL '1 millisecond'
+		FROM due
+		WHERE o.id = due.id
+		RETURNING o.id, o.event_type, o.payload, o.attempts, o.last_error, o.available_at, o.created_at,
+		          o.processed_at, o.failed_at,
+		          ARRAY(SELECT d.sink FROM outbox_deliveries d WHERE d.message_id = o.id);
+	`
+	insertOutboxDeliveryQuery = `
+		INSERT INTO outbox_deliveries (message_id, sink)
+		VALUES ($1, $2)
+		ON CONFLICT (message_id, sink) DO NOTHING;
+	`
+	markOutboxMessageProcessedQuery = `
+		UPDATE outbox SET processed_at = NOW(), attempts = attempts + 1, last_error = NULL WHERE id = $1;
+	`
+	markOutboxMessageFailedQuery = `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $2, available_at = $3 WHERE id = $1;
+	`
+	markOutboxMessageDeadQuery = `
+		UPDATE outbox SET attempts = attempts + 1, last_error = $2, failed_at = NOW() WHERE id = $1;
+	`
+	deleteProcessedOutboxMessagesQuery = `
+		DELETE FROM outbox WHERE processed_at < $1;
+	`
+)
+
+func (r *OutboxRepository) Create(ctx context.Context, message *models.OutboxMessage) error {
+	if err := conn(ctx, r.db).QueryRow(ctx, insertOutboxMessageQuery, message.EventType, message.Payload).
+		Scan(&message.ID, &message.AvailableAt, &message.CreatedAt); err != nil {
+		return fmt.Errorf("store %s event in outbox: %w", message.EventType, err)
+	}
+
+	return nil
+}
+
+// ClaimPending takes up to limit unprocessed messages that are due, oldest
+// first, and hides them from other dispatchers for lease. The claim commits
+// right away, so no lock is held while the messages are handed out; a
+// dispatcher that stops before it records the outcome leaves them to be
+// claimed again once the lease is over.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int,
+	lease time.Duration) ([]*models.OutboxMessage, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, claimPendingOutboxMessagesQuery, limit, lease.Milliseconds())
+	if err != nil {
+		return nil, fmt.Errorf("claim outbox messages: %w", err)
+	}
+	defer rows.Close()
+
+	var list []*models.OutboxMessage
+	for rows.Next() {
+		var m models.OutboxMessage
+		if err := rows.Scan(&m.ID, &m.EventType, &m.Payload, &m.Attempts, &m.LastError, &m.AvailableAt,
+			&m.CreatedAt, &m.ProcessedAt, &m.FailedAt, &m.DoneSinks); err != nil {
+			return nil, fmt.Errorf("scan outbox message: %w", err)
+		}
+		list = append(list, &m)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over outbox rows: %w", err)
+	}
+
+	sort.Slice(list, func(i, j int) bool { return list[i].ID < list[j].ID })
+	return list, nil
+}
+
+// MarkSinkDone records that sink took the message, so it is not handed the
+// message again.
+func (r *OutboxRepository) MarkSinkDone(ctx context.Context, id int64, sink string) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, insertOutboxDeliveryQuery, id, sink); err != nil {
+		return fmt.Errorf("record delivery of outbox message %d to %s: %w", id, sink, err)
+	}
+	return nil
+}
+
+func (r *OutboxRepository) MarkProcessed(ctx context.Context, id int64) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, markOutboxMessageProcessedQuery, id); err != nil {
+		return fmt.Errorf("mark outbox message %d processed: %w", id, err)
+	}
+	return nil
+}
+
+// MarkFailed records a failed hand-off and leaves the message pending until
+// retryAt.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id int64, cause string, retryAt time.Time) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, markOutboxMessageFailedQuery, id, cause, retryAt); err != nil {
+		return fmt.Errorf("mark outbox message %d failed: %w", id, err)
+	}
+	return nil
+}
+
+// MarkDead records the last failed hand-off and stops retrying the message.
+// It stays in the outbox for inspection.
+func (r *OutboxRepository) MarkDead(ctx context.Context, id int64, cause string) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, markOutboxMessageDeadQuery, id, cause); err != nil {
+		return fmt.Errorf("mark outbox message %d dead: %w", id, err)
+	}
+	return nil
+}
+
+// DeleteProcessedBefore removes the messages processed before before and
+// returns how many there were.
+func (r *OutboxRepository) DeleteProcessedBefore(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := conn(ctx, r.db).Exec(ctx, deleteProcessedOutboxMessagesQuery, before)
+	if err != nil {
+		return 0, fmt.Errorf("delete processed outbox messages: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
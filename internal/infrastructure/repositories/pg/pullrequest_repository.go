@@ -11,7 +11,10 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
-var ErrPullRequestNotFound = errors.New("pull request not found")
+var (
+	ErrPullRequestNotFound = errors.New("pull request not found")
+	ErrReviewNotFound      = errors.New("review not found")
+)
 
 type PullRequestRepository struct {
 	db *pgxpool.Pool
@@ -23,31 +26,41 @@ func NewPullRequestRepository(db *pgxpool.Pool) *PullRequestRepository {
 
 const (
 	insertPullRequestQuery = `
-       INSERT INTO pull_requests (id, title, author_id, status_id, merged_at)
-       VALUES ($1, $2, $3, $4, $5)
-       RETURNING created_at, updated_at;
+       INSERT INTO pull_requests (id, title, author_id, team_id, status_id, merged_at, closed_at)
+       VALUES ($1, $2, $3, $4, $5, $6, $7)
+       RETURNING created_at, updated_at, (SELECT t.name FROM teams t WHERE t.id = pull_requests.team_id);
     `
 	selectPullRequestByIDQuery = `
-		SELECT id, title, author_id, status_id, merged_at, created_at, updated_at
+		SELECT id, title, author_id, team_id, status_id, merged_at, closed_at, created_at, updated_at,
+		       (SELECT t.name FROM teams t WHERE t.id = pull_requests.team_id)
 		FROM pull_requests
 		WHERE id = $1;
 	`
+	lockPullRequestByIDQuery = `
+		SELECT id, title, author_id, team_id, status_id, merged_at, closed_at, created_at, updated_at,
+		       (SELECT t.name FROM teams t WHERE t.id = pull_requests.team_id)
+		FROM pull_requests
+		WHERE id = $1
+		FOR UPDATE;
+	`
 	selectAllPullRequestsQuery = `
-		SELECT id, title, author_id, status_id, merged_at, created_at, updated_at
+		SELECT id, title, author_id, team_id, status_id, merged_at, closed_at, created_at, updated_at,
+		       (SELECT t.name FROM teams t WHERE t.id = pull_requests.team_id)
 		FROM pull_requests
 		ORDER BY created_at DESC;
 	`
 	updatePullRequestQuery = `
 		UPDATE pull_requests
-		SET title = $1, author_id = $2, status_id = $3, merged_at = $4, updated_at = now()
-		WHERE id = $5
-		RETURNING updated_at;
+		SET title = $1, author_id = $2, team_id = $3, status_id = $4, merged_at = $5, closed_at = $6, updated_at = now()
+		WHERE id = $7
+		RETURNING updated_at, (SELECT t.name FROM teams t WHERE t.id = pull_requests.team_id);
 	`
 	deletePullRequestQuery = `
 		DELETE FROM pull_requests WHERE id = $1;
 	`
 	selectByReviewerQuery = `
-		SELECT pr.id, pr.title, pr.author_id, pr.status_id, pr.merged_at, pr.created_at, pr.updated_at
+		SELECT pr.id, pr.title, pr.author_id, pr.team_id, pr.status_id, pr.merged_at, pr.closed_at, pr.created_at, pr.updated_at,
+		       (SELECT t.name FROM teams t WHERE t.id = pr.team_id)
 		FROM pull_requests pr
 		INNER JOIN pull_request_reviewers prr ON pr.id = prr.pull_request_id
 		WHERE prr.reviewer_id = $1
@@ -55,31 +68,74 @@ const (
 	`
 	insertReviewerQuery = `
 		INSERT INTO pull_request_reviewers (pull_request_id, reviewer_id, assigned_at)
-		VALUES ($1, $2, $3);
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pull_request_id, reviewer_id) DO NOTHING;
 	`
-	deleteReviewersQuery = `
-		DELETE FROM pull_request_reviewers WHERE pull_request_id = $1;
+	deleteRemovedReviewersQuery = `
+		DELETE FROM pull_request_reviewers
+		WHERE pull_request_id = $1 AND reviewer_id <> ALL(COALESCE($2::bigint[], '{}'));
 	`
 	selectReviewersQuery = `
-		SELECT reviewer_id FROM pull_request_reviewers
-		WHERE pull_request_id = $1;
+		SELECT reviewer_id, assigned_at, decision, decided_at FROM pull_request_reviewers
+		WHERE pull_request_id = $1
+		ORDER BY assigned_at, reviewer_id;
 	`
-	countPRsByStatusQuery = `
-		SELECT s.name, COUNT(*) 
+	updateReviewDecisionQuery = `
+		UPDATE pull_request_reviewers
+		SET decision = $3, decided_at = $4
+		WHERE pull_request_id = $1 AND reviewer_id = $2;
+	`
+	countOpenReviewsQuery = `
+		SELECT prr.reviewer_id, COUNT(*)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.id = prr.pull_request_id
+		JOIN pull_request_statuses s ON s.id = pr.status_id
+		WHERE s.name = 'OPEN' AND prr.reviewer_id = ANY($1)
+		GROUP BY prr.reviewer_id;
+	`
+	selectOpenByReviewersQuery = `
+		SELECT pr.id, pr.title, pr.author_id, pr.team_id, pr.status_id, pr.merged_at, pr.closed_at, pr.created_at, pr.updated_at,
+		       (SELECT t.name FROM teams t WHERE t.id = pr.team_id), array_agg(prr.reviewer_id ORDER BY prr.assigned_at, prr.reviewer_id)
 		FROM pull_requests pr
-		JOIN pull_request_statuses s ON pr.status_id = s.id
-		GROUP BY s.name;
+		JOIN pull_request_statuses s ON s.id = pr.status_id
+		JOIN pull_request_reviewers prr ON prr.pull_request_id = pr.id
+		WHERE s.name = 'OPEN' AND pr.id IN (
+			SELECT pull_request_id FROM pull_request_reviewers WHERE reviewer_id = ANY($1)
+		)
+		GROUP BY pr.id
+		ORDER BY pr.created_at;
+	`
+	selectPendingReviewsQuery = `
+		SELECT prr.reviewer_id, pr.id, pr.team_id, pr.title, pr.author_id, pr.created_at, COALESCE(prr.assigned_at, pr.created_at)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.id = prr.pull_request_id
+		JOIN pull_request_statuses s ON s.id = pr.status_id
+		WHERE s.name = 'OPEN'
+		  AND (prr.decision IS NULL OR prr.decision = 'COMMENTED')
+		  AND ($1::bigint[] IS NULL OR prr.reviewer_id = ANY($1))
+		ORDER BY prr.reviewer_id, pr.created_at, pr.id;
 	`
-	countReviewerAssignmentsQuery = `
-		SELECT reviewer_id, COUNT(*) as count
-		FROM pull_request_reviewers
-		GROUP BY reviewer_id
-		ORDER BY count DESC;
+	replaceReviewersQuery = `
+		WITH replaced AS (
+			UPDATE pull_request_reviewers prr
+			SET reviewer_id = r.new_reviewer_id, assigned_at = now(), decision = NULL, decided_at = NULL
+			FROM unnest($1::bigint[], $2::bigint[], $3::bigint[]) AS r(pull_request_id, old_reviewer_id, new_reviewer_id)
+			WHERE prr.pull_request_id = r.pull_request_id AND prr.reviewer_id = r.old_reviewer_id
+			RETURNING prr.pull_request_id, r.old_reviewer_id, r.new_reviewer_id
+		)
+		INSERT INTO reviewer_reassignments (pull_request_id, old_reviewer_id, new_reviewer_id)
+		SELECT pull_request_id, old_reviewer_id, new_reviewer_id FROM replaced;
+	`
+	markFirstReviewQuery = `
+		UPDATE pull_requests SET first_reviewed_at = COALESCE(first_reviewed_at, $2) WHERE id = $1;
+	`
+	touchPullRequestsQuery = `
+		UPDATE pull_requests SET updated_at = now() WHERE id = ANY($1);
 	`
 )
 
 func (r *PullRequestRepository) Create(ctx context.Context, pr *models.PullRequest) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("create transaction: %w", err)
 	}
@@ -93,9 +149,11 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *models.PullReque
 		pr.ID,
 		pr.Title,
 		pr.AuthorID,
+		pr.TeamID,
 		pr.StatusID,
 		pr.MergedAt,
-	).Scan(&pr.CreatedAt, &pr.UpdatedAt); err != nil {
+		pr.ClosedAt,
+	).Scan(&pr.CreatedAt, &pr.UpdatedAt, &pr.TeamName); err != nil {
 		return fmt.Errorf("insert pull request: %w", err)
 	}
 
@@ -113,13 +171,25 @@ func (r *PullRequestRepository) Create(ctx context.Context, pr *models.PullReque
 }
 
 func (r *PullRequestRepository) FindByID(ctx context.Context, id int64) (*models.PullRequest, error) {
+	return r.findByID(ctx, selectPullRequestByIDQuery, id)
+}
+
+// LockByID finds the pull request like FindByID and locks its row until the
+// surrounding transaction ends, so concurrent changes of the pull request
+// are serialized.
+func (r *PullRequestRepository) LockByID(ctx context.Context, id int64) (*models.PullRequest, error) {
+	return r.findByID(ctx, lockPullRequestByIDQuery, id)
+}
+
+func (r *PullRequestRepository) findByID(ctx context.Context, query string, id int64) (*models.PullRequest, error) {
 	var pr models.PullRequest
 
-	err := r.db.QueryRow(
+	err := conn(ctx, r.db).QueryRow(
 		ctx,
-		selectPullRequestByIDQuery,
+		query,
 		id,
-	).Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.StatusID, &pr.MergedAt, &pr.CreatedAt, &pr.UpdatedAt)
+	).Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.TeamID, &pr.StatusID, &pr.MergedAt, &pr.ClosedAt, &pr.CreatedAt, &pr.UpdatedAt,
+		&pr.TeamName)
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, ErrPullRequestNotFound
@@ -128,17 +198,15 @@ func (r *PullRequestRepository) FindByID(ctx context.Context, id int64) (*models
 		return nil, fmt.Errorf("find pull request by id %d: %w", id, err)
 	}
 
-	reviewers, err := r.getReviewers(ctx, pr.ID)
-	if err != nil {
-		return nil, fmt.Errorf("get reviewers for PR %d: %w", pr.ID, err)
+	if err := r.loadReviews(ctx, &pr); err != nil {
+		return nil, err
 	}
-	pr.ReviewersIDs = reviewers
 
 	return &pr, nil
 }
 
 func (r *PullRequestRepository) FindAll(ctx context.Context) ([]*models.PullRequest, error) {
-	rows, err := r.db.Query(ctx, selectAllPullRequestsQuery)
+	rows, err := conn(ctx, r.db).Query(ctx, selectAllPullRequestsQuery)
 	if err != nil {
 		return nil, fmt.Errorf("find all pull requests: %w", err)
 	}
@@ -148,15 +216,14 @@ func (r *PullRequestRepository) FindAll(ctx context.Context) ([]*models.PullRequ
 
 	for rows.Next() {
 		var pr models.PullRequest
-		if err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.StatusID, &pr.MergedAt, &pr.CreatedAt, &pr.UpdatedAt); err != nil {
+		if err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.TeamID, &pr.StatusID, &pr.MergedAt, &pr.ClosedAt, &pr.CreatedAt, &pr.UpdatedAt,
+			&pr.TeamName); err != nil {
 			return nil, fmt.Errorf("scan pull request: %w", err)
 		}
 
-		reviewers, err := r.getReviewers(ctx, pr.ID)
-		if err != nil {
-			return nil, fmt.Errorf("get reviewers for PR %d: %w", pr.ID, err)
+		if err := r.loadReviews(ctx, &pr); err != nil {
+			return nil, err
 		}
-		pr.ReviewersIDs = reviewers
 
 		list = append(list, &pr)
 	}
@@ -169,7 +236,7 @@ func (r *PullRequestRepository) FindAll(ctx context.Context) ([]*models.PullRequ
 }
 
 func (r *PullRequestRepository) Update(ctx context.Context, pr *models.PullRequest) error {
-	tx, err := r.db.Begin(ctx)
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("start transaction for update: %w", err)
 	}
@@ -182,10 +249,12 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *models.PullReque
 		updatePullRequestQuery,
 		pr.Title,
 		pr.AuthorID,
+		pr.TeamID,
 		pr.StatusID,
 		pr.MergedAt,
+		pr.ClosedAt,
 		pr.ID,
-	).Scan(&pr.UpdatedAt)
+	).Scan(&pr.UpdatedAt, &pr.TeamName)
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return ErrPullRequestNotFound
@@ -194,13 +263,14 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *models.PullReque
 		return fmt.Errorf("update pull request %d: %w", pr.ID, err)
 	}
 
-	if _, err := tx.Exec(ctx, deleteReviewersQuery, pr.ID); err != nil {
-		return fmt.Errorf("clear reviewers for PR %d: %w", pr.ID, err)
+	// Reviewers that stay keep their assignment time and decision.
+	if _, err := tx.Exec(ctx, deleteRemovedReviewersQuery, pr.ID, pr.ReviewersIDs); err != nil {
+		return fmt.Errorf("remove reviewers for PR %d: %w", pr.ID, err)
 	}
 
 	if len(pr.ReviewersIDs) > 0 {
 		if err := r.insertReviewersTx(ctx, tx, pr.ID, pr.ReviewersIDs); err != nil {
-			return fmt.Errorf("re-insert reviewers for PR %d: %w", pr.ID, err)
+			return fmt.Errorf("add reviewers for PR %d: %w", pr.ID, err)
 		}
 	}
 
@@ -212,7 +282,7 @@ func (r *PullRequestRepository) Update(ctx context.Context, pr *models.PullReque
 }
 
 func (r *PullRequestRepository) DeleteByID(ctx context.Context, id int64) error {
-	cmd, err := r.db.Exec(ctx, deletePullRequestQuery, id)
+	cmd, err := conn(ctx, r.db).Exec(ctx, deletePullRequestQuery, id)
 	if err != nil {
 		return fmt.Errorf("delete pull request %d: %w", id, err)
 	}
@@ -225,7 +295,7 @@ func (r *PullRequestRepository) DeleteByID(ctx context.Context, id int64) error
 }
 
 func (r *PullRequestRepository) FindByReviewer(ctx context.Context, userID int64) ([]*models.PullRequest, error) {
-	rows, err := r.db.Query(ctx, selectByReviewerQuery, userID)
+	rows, err := conn(ctx, r.db).Query(ctx, selectByReviewerQuery, userID)
 	if err != nil {
 		return nil, fmt.Errorf("get pull requests by user %d: %w", userID, err)
 	}
@@ -235,15 +305,14 @@ func (r *PullRequestRepository) FindByReviewer(ctx context.Context, userID int64
 
 	for rows.Next() {
 		var pr models.PullRequest
-		if err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.StatusID, &pr.MergedAt, &pr.CreatedAt, &pr.UpdatedAt); err != nil {
+		if err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.TeamID, &pr.StatusID, &pr.MergedAt, &pr.ClosedAt, &pr.CreatedAt, &pr.UpdatedAt,
+			&pr.TeamName); err != nil {
 			return nil, fmt.Errorf("scan pull request: %w", err)
 		}
 
-		reviewers, err := r.getReviewers(ctx, pr.ID)
-		if err != nil {
-			return nil, fmt.Errorf("get reviewers for PR %d: %w", pr.ID, err)
+		if err := r.loadReviews(ctx, &pr); err != nil {
+			return nil, err
 		}
-		pr.ReviewersIDs = reviewers
 
 		list = append(list, &pr)
 	}
@@ -255,66 +324,174 @@ func (r *PullRequestRepository) FindByReviewer(ctx context.Context, userID int64
 	return list, nil
 }
 
-func (r *PullRequestRepository) GetPRStatusCounts(ctx context.Context) (map[string]int, error) {
-	rows, err := r.db.Query(ctx, countPRsByStatusQuery)
+func (r *PullRequestRepository) CountOpenReviews(ctx context.Context, userIDs []int64) (map[int64]int, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, countOpenReviewsQuery, userIDs)
 	if err != nil {
-		return nil, fmt.Errorf("count prs by status: %w", err)
+		return nil, fmt.Errorf("count open reviews: %w", err)
 	}
 	defer rows.Close()
 
-	stats := make(map[string]int)
+	loads := make(map[int64]int, len(userIDs))
 	for rows.Next() {
-		var status string
+		var reviewerID int64
 		var count int
-		if err := rows.Scan(&status, &count); err != nil {
-			return nil, err
+		if err := rows.Scan(&reviewerID, &count); err != nil {
+			return nil, fmt.Errorf("scan open review count: %w", err)
 		}
-		stats[status] = count
+		loads[reviewerID] = count
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over open review counts: %w", err)
 	}
-	return stats, nil
+
+	return loads, nil
 }
 
-func (r *PullRequestRepository) GetReviewerStats(ctx context.Context) (map[int64]int, error) {
-	rows, err := r.db.Query(ctx, countReviewerAssignmentsQuery)
+func (r *PullRequestRepository) FindOpenByReviewers(ctx context.Context, userIDs []int64) ([]*models.PullRequest, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectOpenByReviewersQuery, userIDs)
 	if err != nil {
-		return nil, fmt.Errorf("count reviewer assignments: %w", err)
+		return nil, fmt.Errorf("find open pull requests by reviewers: %w", err)
 	}
 	defer rows.Close()
 
-	stats := make(map[int64]int)
+	var list []*models.PullRequest
+
 	for rows.Next() {
-		var reviewerID int64
-		var count int
-		if err := rows.Scan(&reviewerID, &count); err != nil {
-			return nil, err
+		var pr models.PullRequest
+		if err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.TeamID, &pr.StatusID, &pr.MergedAt, &pr.ClosedAt,
+			&pr.CreatedAt, &pr.UpdatedAt, &pr.TeamName, &pr.ReviewersIDs); err != nil {
+			return nil, fmt.Errorf("scan pull request: %w", err)
 		}
-		stats[reviewerID] = count
+		list = append(list, &pr)
 	}
-	return stats, nil
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over open pull request rows: %w", err)
+	}
+
+	return list, nil
 }
 
-func (r *PullRequestRepository) getReviewers(ctx context.Context, prID int64) ([]int64, error) {
-	rows, err := r.db.Query(ctx, selectReviewersQuery, prID)
+// FindPendingReviews returns the reviews awaiting a decision on open pull
+// requests, grouped by reviewer and oldest first. Nil userIDs means everyone.
+func (r *PullRequestRepository) FindPendingReviews(ctx context.Context, userIDs []int64) ([]*models.PendingReview, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectPendingReviewsQuery, userIDs)
 	if err != nil {
-		return nil, fmt.Errorf("get reviewers for PR %d: %w", prID, err)
+		return nil, fmt.Errorf("find pending reviews: %w", err)
 	}
 	defer rows.Close()
 
-	var reviewers []int64
+	var list []*models.PendingReview
 
 	for rows.Next() {
-		var id int64
-		if err := rows.Scan(&id); err != nil {
-			return nil, fmt.Errorf("scan reviewer ID for PR %d: %w", prID, err)
+		var p models.PendingReview
+		if err := rows.Scan(&p.ReviewerID, &p.PullRequestID, &p.TeamID, &p.Title, &p.AuthorID, &p.CreatedAt,
+			&p.AssignedAt); err != nil {
+			return nil, fmt.Errorf("scan pending review: %w", err)
 		}
-		reviewers = append(reviewers, id)
+		list = append(list, &p)
 	}
 
 	if err := rows.Err(); err != nil {
-		return nil, fmt.Errorf("iterating over reviewer rows for PR %d: %w", prID, err)
+		return nil, fmt.Errorf("iterating over pending review rows: %w", err)
 	}
 
-	return reviewers, nil
+	return list, nil
+}
+
+// ReplaceReviewers swaps reviewers of pull requests, resetting their reviews,
+// and records every swap as a reviewer reassignment in the same statement.
+// Reassignments must go through it rather than Update to be counted.
+func (r *PullRequestRepository) ReplaceReviewers(ctx context.Context, replacements []models.ReviewerReplacement) error {
+	if len(replacements) == 0 {
+		return nil
+	}
+
+	prIDs := make([]int64, len(replacements))
+	oldIDs := make([]int64, len(replacements))
+	newIDs := make([]int64, len(replacements))
+	for i, rep := range replacements {
+		prIDs[i] = rep.PullRequestID
+		oldIDs[i] = rep.OldReviewerID
+		newIDs[i] = rep.NewReviewerID
+	}
+
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("start transaction for reviewer replacement: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, replaceReviewersQuery, prIDs, oldIDs, newIDs); err != nil {
+		return fmt.Errorf("replace reviewers: %w", err)
+	}
+	if _, err := tx.Exec(ctx, touchPullRequestsQuery, prIDs); err != nil {
+		return fmt.Errorf("touch pull requests: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit reviewer replacement: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) SetReviewDecision(ctx context.Context, prID, reviewerID int64,
+	decision string, decidedAt time.Time) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("start transaction for review decision: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	cmd, err := tx.Exec(ctx, updateReviewDecisionQuery, prID, reviewerID, decision, decidedAt)
+	if err != nil {
+		return fmt.Errorf("set review decision for PR %d: %w", prID, err)
+	}
+	if cmd.RowsAffected() == 0 {
+		return ErrReviewNotFound
+	}
+	if _, err := tx.Exec(ctx, markFirstReviewQuery, prID, decidedAt); err != nil {
+		return fmt.Errorf("mark first review of PR %d: %w", prID, err)
+	}
+	if _, err := tx.Exec(ctx, touchPullRequestsQuery, []int64{prID}); err != nil {
+		return fmt.Errorf("touch pull request: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("commit review decision: %w", err)
+	}
+
+	return nil
+}
+
+func (r *PullRequestRepository) loadReviews(ctx context.Context, pr *models.PullRequest) error {
+	rows, err := conn(ctx, r.db).Query(ctx, selectReviewersQuery, pr.ID)
+	if err != nil {
+		return fmt.Errorf("get reviewers for PR %d: %w", pr.ID, err)
+	}
+	defer rows.Close()
+
+	pr.ReviewersIDs, pr.Reviews = nil, nil
+	for rows.Next() {
+		var review models.Review
+		if err := rows.Scan(&review.ReviewerID, &review.AssignedAt, &review.Decision, &review.DecidedAt); err != nil {
+			return fmt.Errorf("scan reviewer for PR %d: %w", pr.ID, err)
+		}
+		pr.ReviewersIDs = append(pr.ReviewersIDs, review.ReviewerID)
+		pr.Reviews = append(pr.Reviews, review)
+	}
+
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating over reviewer rows for PR %d: %w", pr.ID, err)
+	}
+
+	return nil
 }
 
 func (r *PullRequestRepository) insertReviewersTx(ctx context.Context, tx pgx.Tx, prID int64, reviewers []int64) error {
@@ -0,0 +1,114 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReviewReminderRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReviewReminderRepository(db *pgxpool.Pool) *ReviewReminderRepository {
+	return &ReviewReminderRepository{db: db}
+}
+
+const (
+	recordReviewReminderQuery = `
+		INSERT INTO review_reminders (pull_request_id, reviewer_id, assigned_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pull_request_id, reviewer_id, assigned_at) DO UPDATE
+			SET reminders = review_reminders.reminders + 1, reminded_at = NOW()
+			WHERE review_reminders.reminded_at <= $4
+		RETURNING reminders, reminded_at;
+	`
+	selectDueReviewRemindersQuery = `
+		SELECT prr.reviewer_id, pr.id, pr.team_id, pr.title, pr.author_id, pr.created_at,
+		       COALESCE(prr.assigned_at, pr.created_at)
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.id = prr.pull_request_id
+		JOIN pull_request_statuses s ON s.id = pr.status_id
+		LEFT JOIN review_reminders rr ON rr.pull_request_id = prr.pull_request_id
+			AND rr.reviewer_id = prr.reviewer_id
+			AND rr.assigned_at = COALESCE(prr.assigned_at, pr.created_at)
+		WHERE s.name = 'OPEN'
+		  AND (prr.decision IS NULL OR prr.decision = 'COMMENTED')
+		  AND COALESCE(prr.assigned_at, pr.created_at) <= $1
+		  AND (rr.reminded_at IS NULL OR rr.reminded_at <= $1)
+		ORDER BY prr.reviewer_id, pr.created_at, pr.id;
+	`
+	deleteSettledReviewRemindersQuery = `
+		DELETE FROM review_reminders rr
+		WHERE NOT EXISTS (
+			SELECT 1
+			FROM pull_request_reviewers prr
+			JOIN pull_requests pr ON pr.id = prr.pull_request_id
+			WHERE prr.pull_request_id = rr.pull_request_id
+			  AND prr.reviewer_id = rr.reviewer_id
+			  AND COALESCE(prr.assigned_at, pr.created_at) = rr.assigned_at
+			  AND (prr.decision IS NULL OR prr.decision = 'COMMENTED')
+		);
+	`
+)
+
+// FindDue returns the reviews awaiting a decision on open pull requests that
+// were assigned, and last reminded of if ever, before staleBefore.
+func (r *ReviewReminderRepository) FindDue(ctx context.Context, staleBefore time.Time) ([]*models.PendingReview, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectDueReviewRemindersQuery, staleBefore)
+	if err != nil {
+		return nil, fmt.Errorf("find due reminders: %w", err)
+	}
+	defer rows.Close()
+
+	var list []*models.PendingReview
+	for rows.Next() {
+		var p models.PendingReview
+		if err := rows.Scan(&p.ReviewerID, &p.PullRequestID, &p.TeamID, &p.Title, &p.AuthorID, &p.CreatedAt,
+			&p.AssignedAt); err != nil {
+			return nil, fmt.Errorf("scan due reminder: %w", err)
+		}
+		list = append(list, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over due reminder rows: %w", err)
+	}
+
+	return list, nil
+}
+
+// DeleteSettled deletes the reminder records of assignments no longer
+// awaiting a decision: decided, handed to another reviewer or gone. Those of
+// pull requests that are only closed or back in draft are kept, so their
+// count goes on if they are reopened.
+func (r *ReviewReminderRepository) DeleteSettled(ctx context.Context) (int64, error) {
+	cmd, err := conn(ctx, r.db).Exec(ctx, deleteSettledReviewRemindersQuery)
+	if err != nil {
+		return 0, fmt.Errorf("delete settled reminders: %w", err)
+	}
+	return cmd.RowsAffected(), nil
+}
+
+// Record counts a reminder about the assignment unless the last one was sent
+// after remindedBefore, in which case it reports false and leaves reminder
+// unchanged.
+func (r *ReviewReminderRepository) Record(ctx context.Context, reminder *models.ReviewReminder,
+	remindedBefore time.Time) (bool, error) {
+	err := conn(ctx, r.db).QueryRow(ctx, recordReviewReminderQuery, reminder.PullRequestID, reminder.ReviewerID,
+		reminder.AssignedAt, remindedBefore).Scan(&reminder.Reminders, &reminder.RemindedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("record reminder to user %d about PR %d: %w", reminder.ReviewerID,
+			reminder.PullRequestID, err)
+	}
+
+	return true, nil
+}
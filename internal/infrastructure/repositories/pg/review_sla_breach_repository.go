@@ -0,0 +1,43 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReviewSLABreachRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReviewSLABreachRepository(db *pgxpool.Pool) *ReviewSLABreachRepository {
+	return &ReviewSLABreachRepository{db: db}
+}
+
+const (
+	insertReviewSLABreachQuery = `
+		INSERT INTO review_sla_breaches (pull_request_id, reviewer_id, assigned_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (pull_request_id, reviewer_id, assigned_at) DO NOTHING
+		RETURNING breached_at;
+	`
+)
+
+// Create flags the assignment. It reports false, leaving breach unchanged, if
+// the assignment was already flagged.
+func (r *ReviewSLABreachRepository) Create(ctx context.Context, breach *models.ReviewSLABreach) (bool, error) {
+	err := conn(ctx, r.db).QueryRow(ctx, insertReviewSLABreachQuery, breach.PullRequestID, breach.ReviewerID,
+		breach.AssignedAt).Scan(&breach.BreachedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("flag review of PR %d by user %d: %w", breach.PullRequestID, breach.ReviewerID, err)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,73 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ReviewerSyncFailureRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewReviewerSyncFailureRepository(db *pgxpool.Pool) *ReviewerSyncFailureRepository {
+	return &ReviewerSyncFailureRepository{db: db}
+}
+
+const (
+	insertReviewerSyncFailureQuery = `
+		INSERT INTO reviewer_sync_failures (pull_request_id, provider, reviewers, removed, attempts, error)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at;
+	`
+	selectReviewerSyncFailuresByPRQuery = `
+		SELECT id, pull_request_id, provider, reviewers, removed, attempts, error, created_at
+		FROM reviewer_sync_failures
+		WHERE pull_request_id = $1
+		ORDER BY created_at;
+	`
+)
+
+func (r *ReviewerSyncFailureRepository) Create(ctx context.Context, failure *models.ReviewerSyncFailure) error {
+	if failure.Reviewers == nil {
+		failure.Reviewers = []string{}
+	}
+	if failure.Removed == nil {
+		failure.Removed = []string{}
+	}
+
+	if err := conn(ctx, r.db).QueryRow(ctx, insertReviewerSyncFailureQuery, failure.PullRequestID, failure.Provider,
+		failure.Reviewers, failure.Removed, failure.Attempts, failure.Error).
+		Scan(&failure.ID, &failure.CreatedAt); err != nil {
+		return fmt.Errorf("record reviewer sync failure for PR %d: %w", failure.PullRequestID, err)
+	}
+
+	return nil
+}
+
+func (r *ReviewerSyncFailureRepository) FindByPullRequestID(ctx context.Context,
+	prID int64) ([]*models.ReviewerSyncFailure, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectReviewerSyncFailuresByPRQuery, prID)
+	if err != nil {
+		return nil, fmt.Errorf("find reviewer sync failures of PR %d: %w", prID, err)
+	}
+	defer rows.Close()
+
+	var list []*models.ReviewerSyncFailure
+	for rows.Next() {
+		var f models.ReviewerSyncFailure
+		if err := rows.Scan(&f.ID, &f.PullRequestID, &f.Provider, &f.Reviewers, &f.Removed, &f.Attempts,
+			&f.Error, &f.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan reviewer sync failure: %w", err)
+		}
+		list = append(list, &f)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over reviewer sync failure rows: %w", err)
+	}
+
+	return list, nil
+}
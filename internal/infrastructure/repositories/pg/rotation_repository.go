@@ -0,0 +1,62 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrNotInTransaction = errors.New("rotation cursor must be used inside a transaction")
+
+type RotationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewRotationRepository(db *pgxpool.Pool) *RotationRepository {
+	return &RotationRepository{db: db}
+}
+
+const (
+	ensureRotationQuery = `
+		INSERT INTO team_rotation (team_id) VALUES ($1)
+		ON CONFLICT (team_id) DO NOTHING;
+	`
+	lockRotationQuery = `
+		SELECT COALESCE(last_user_id, 0) FROM team_rotation
+		WHERE team_id = $1
+		FOR UPDATE;
+	`
+	advanceRotationQuery = `
+		UPDATE team_rotation SET last_user_id = $1, updated_at = now()
+		WHERE team_id = $2;
+	`
+)
+
+// LockCursor returns the last user assigned in the team and locks the cursor
+// row until the surrounding transaction ends, so concurrent assignments in
+// the same team are serialized.
+func (r *RotationRepository) LockCursor(ctx context.Context, teamID int64) (int64, error) {
+	if _, ok := conn(ctx, r.db).(*pgxpool.Pool); ok {
+		return 0, ErrNotInTransaction
+	}
+
+	if _, err := conn(ctx, r.db).Exec(ctx, ensureRotationQuery, teamID); err != nil {
+		return 0, fmt.Errorf("ensure rotation for team %d: %w", teamID, err)
+	}
+
+	var lastUserID int64
+	if err := conn(ctx, r.db).QueryRow(ctx, lockRotationQuery, teamID).Scan(&lastUserID); err != nil {
+		return 0, fmt.Errorf("lock rotation for team %d: %w", teamID, err)
+	}
+
+	return lastUserID, nil
+}
+
+func (r *RotationRepository) AdvanceCursor(ctx context.Context, teamID int64, lastUserID int64) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, advanceRotationQuery, lastUserID, teamID); err != nil {
+		return fmt.Errorf("advance rotation for team %d: %w", teamID, err)
+	}
+	return nil
+}
@@ -0,0 +1,316 @@
+package pg
+
+import (
+	"context"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// StatsRepository computes the statistics in the database. The queries for a
+// period take the team id and the [from, to) bounds of models.StatsFilter as
+// $1, $2 and $3; a NULL one does not filter.
+type StatsRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewStatsRepository(db *pgxpool.Pool) *StatsRepository {
+	return &StatsRepository{db: db}
+}
+
+const (
+	selectPullRequestStatsQuery = `
+		SELECT COUNT(*) FILTER (WHERE created_in_period),
+		       COUNT(*) FILTER (WHERE created_in_period AND status = 'DRAFT'),
+		       COUNT(*) FILTER (WHERE created_in_period AND status = 'OPEN'),
+		       COUNT(*) FILTER (WHERE created_in_period AND status = 'MERGED'),
+		       COUNT(*) FILTER (WHERE created_in_period AND status = 'CLOSED'),
+		       COUNT(first_review_hours) FILTER (WHERE created_in_period),
+		       percentile_cont(ARRAY [0.5, 0.9, 0.99]) WITHIN GROUP (ORDER BY first_review_hours)
+		           FILTER (WHERE created_in_period),
+		       COUNT(merge_hours) FILTER (WHERE merged_in_period),
+		       percentile_cont(ARRAY [0.5, 0.9, 0.99]) WITHIN GROUP (ORDER BY merge_hours)
+		           FILTER (WHERE merged_in_period)
+		FROM (
+			SELECT s.name AS status,
+			       EXTRACT(EPOCH FROM pr.first_reviewed_at - pr.created_at)::double precision / 3600
+			           AS first_review_hours,
+			       EXTRACT(EPOCH FROM pr.merged_at - pr.created_at)::double precision / 3600 AS merge_hours,
+			       ($2::timestamptz IS NULL OR pr.created_at >= $2) AND ($3::timestamptz IS NULL OR pr.created_at < $3)
+			           AS created_in_period,
+			       pr.merged_at IS NOT NULL
+			           AND ($2::timestamptz IS NULL OR pr.merged_at >= $2) AND ($3::timestamptz IS NULL OR pr.merged_at < $3)
+			           AS merged_in_period
+			FROM pull_requests pr
+			JOIN pull_request_statuses s ON s.id = pr.status_id
+			WHERE $1::bigint IS NULL OR pr.team_id = $1
+		) scoped;
+	`
+	countReassignmentsQuery = `
+		SELECT COUNT(*), COUNT(DISTINCT ra.pull_request_id)
+		FROM reviewer_reassignments ra
+		JOIN pull_requests pr ON pr.id = ra.pull_request_id
+		WHERE ($1::bigint IS NULL OR pr.team_id = $1)
+		  AND ($2::timestamptz IS NULL OR ra.reassigned_at >= $2)
+		  AND ($3::timestamptz IS NULL OR ra.reassigned_at < $3);
+	`
+	// The days run from the one of from, or of the first pull request if
+	// it is NULL, to the one of to, or today; days without activity count 0.
+	selectThroughputQuery = `
+		WITH scoped AS (
+			SELECT (pr.created_at AT TIME ZONE 'UTC')::date AS created_on,
+			       (pr.merged_at AT TIME ZONE 'UTC')::date AS merged_on,
+			       ($2::timestamptz IS NULL OR pr.created_at >= $2) AND ($3::timestamptz IS NULL OR pr.created_at < $3)
+			           AS created_in_period,
+			       pr.merged_at IS NOT NULL
+			           AND ($2::timestamptz IS NULL OR pr.merged_at >= $2) AND ($3::timestamptz IS NULL OR pr.merged_at < $3)
+			           AS merged_in_period,
+			       pr.created_at
+			FROM pull_requests pr
+			WHERE $1::bigint IS NULL OR pr.team_id = $1
+		),
+		bounds AS (
+			SELECT (COALESCE($2::timestamptz, MIN(created_at)) AT TIME ZONE 'UTC')::date AS first_day,
+			       ((COALESCE($3::timestamptz, now()) - INTERVAL '1 microsecond') AT TIME ZONE 'UTC')::date AS last_day
+			FROM scoped
+		),
+		opened AS (
+			SELECT created_on AS day, COUNT(*) AS n FROM scoped WHERE created_in_period GROUP BY created_on
+		),
+		merged AS (
+			SELECT merged_on AS day, COUNT(*) AS n FROM scoped WHERE merged_in_period GROUP BY merged_on
+		)
+		SELECT d.day::date, COALESCE(o.n, 0), COALESCE(m.n, 0)
+		FROM bounds
+		CROSS JOIN generate_series(bounds.first_day::timestamp, bounds.last_day::timestamp, INTERVAL '1 day') AS d(day)
+		LEFT JOIN opened o ON o.day = d.day::date
+		LEFT JOIN merged m ON m.day = d.day::date
+		ORDER BY d.day;
+	`
+	countReviewerAssignmentsQuery = `
+		SELECT prr.reviewer_id, u.username, COUNT(*) AS assigned
+		FROM pull_request_reviewers prr
+		JOIN pull_requests pr ON pr.id = prr.pull_request_id
+		JOIN users u ON u.id = prr.reviewer_id
+		WHERE ($1::bigint IS NULL OR pr.team_id = $1)
+		  AND ($2::timestamptz IS NULL OR COALESCE(prr.assigned_at, pr.created_at) >= $2)
+		  AND ($3::timestamptz IS NULL OR COALESCE(prr.assigned_at, pr.created_at) < $3)
+		GROUP BY prr.reviewer_id, u.username
+		ORDER BY assigned DESC, prr.reviewer_id;
+	`
+	// The reviewers of a team are its members and whoever reviews its pull
+	// requests, such as members of its backup teams.
+	selectReviewerLoadsQuery = `
+		WITH reviews AS (
+			SELECT pr.team_id, prr.reviewer_id, s.name AS status,
+			       s.name = 'OPEN' AND (prr.decision IS NULL OR prr.decision = 'COMMENTED') AS pending,
+			       COALESCE(prr.assigned_at, pr.created_at) AS assigned_at
+			FROM pull_request_reviewers prr
+			JOIN pull_requests pr ON pr.id = prr.pull_request_id
+			JOIN pull_request_statuses s ON s.id = pr.status_id
+			WHERE pr.team_id IS NOT NULL
+		),
+		reviewers AS (
+			SELECT team_id, user_id AS reviewer_id FROM team_user
+			UNION
+			SELECT team_id, reviewer_id FROM reviews
+		),
+		loads AS (
+			SELECT t.id AS team_id, t.name AS team_name, u.id AS reviewer_id, u.username, u.is_active,
+			       COUNT(r.reviewer_id) AS assigned,
+			       COUNT(*) FILTER (WHERE r.pending) AS open,
+			       COUNT(*) FILTER (WHERE r.status = 'MERGED') AS merged,
+			       AVG(EXTRACT(EPOCH FROM now() - r.assigned_at)::double precision / 3600)
+			           FILTER (WHERE r.pending) AS average_open_age_hours,
+			       (SUM(COUNT(r.reviewer_id)) OVER (PARTITION BY t.id))::bigint AS team_assigned
+			FROM reviewers rv
+			JOIN teams t ON t.id = rv.team_id
+			JOIN users u ON u.id = rv.reviewer_id
+			LEFT JOIN reviews r ON r.team_id = rv.team_id AND r.reviewer_id = rv.reviewer_id
+			WHERE $1::bigint IS NULL OR t.id = $1
+			GROUP BY t.id, t.name, u.id, u.username, u.is_active
+		)
+		SELECT team_id, team_name, reviewer_id, username, is_active, assigned, open, merged,
+		       average_open_age_hours, team_assigned
+		FROM loads
+		WHERE $2::bigint IS NULL OR reviewer_id = $2
+		ORDER BY team_name, open DESC, reviewer_id;
+	`
+	// The exports cover the pull requests created in the period.
+	exportPullRequestsQuery = `
+		SELECT pr.id, pr.title, pr.author_id, t.name, s.name, pr.created_at, pr.first_reviewed_at, pr.merged_at,
+		       pr.closed_at,
+		       ARRAY(SELECT prr.reviewer_id FROM pull_request_reviewers prr
+		             WHERE prr.pull_request_id = pr.id ORDER BY prr.assigned_at, prr.reviewer_id),
+		       (SELECT COUNT(*) FROM reviewer_reassignments ra WHERE ra.pull_request_id = pr.id)
+		FROM pull_requests pr
+		JOIN pull_request_statuses s ON s.id = pr.status_id
+		LEFT JOIN teams t ON t.id = pr.team_id
+		WHERE ($1::bigint IS NULL OR pr.team_id = $1)
+		  AND ($2::timestamptz IS NULL OR pr.created_at >= $2)
+		  AND ($3::timestamptz IS NULL OR pr.created_at < $3)
+		ORDER BY pr.created_at, pr.id;
+	`
+	exportReviewAssignmentsQuery = `
+		SELECT pr.id, t.name, s.name, a.reviewer_id, a.assigned_at, a.decision, a.decided_at, a.reassigned_at,
+		       a.replaced_by
+		FROM pull_requests pr
+		JOIN pull_request_statuses s ON s.id = pr.status_id
+		LEFT JOIN teams t ON t.id = pr.team_id
+		JOIN LATERAL (
+			SELECT prr.reviewer_id, COALESCE(prr.assigned_at, pr.created_at) AS assigned_at, prr.decision,
+			       prr.decided_at, NULL::timestamptz AS reassigned_at, NULL::bigint AS replaced_by
+			FROM pull_request_reviewers prr
+			WHERE prr.pull_request_id = pr.id
+			UNION ALL
+			SELECT ra.old_reviewer_id, NULL, NULL, NULL, ra.reassigned_at, ra.new_reviewer_id
+			FROM reviewer_reassignments ra
+			WHERE ra.pull_request_id = pr.id
+		) a ON true
+		WHERE ($1::bigint IS NULL OR pr.team_id = $1)
+		  AND ($2::timestamptz IS NULL OR pr.created_at >= $2)
+		  AND ($3::timestamptz IS NULL OR pr.created_at < $3)
+		ORDER BY pr.created_at, pr.id, COALESCE(a.reassigned_at, a.assigned_at), a.reviewer_id;
+	`
+)
+
+func (r *StatsRepository) GetPullRequestStats(ctx context.Context, filter models.StatsFilter) (*models.PullRequestStats, error) {
+	var stats models.PullRequestStats
+	err := conn(ctx, r.db).QueryRow(ctx, selectPullRequestStatsQuery, filter.TeamID, filter.From, filter.To).Scan(
+		&stats.Total, &stats.Draft, &stats.Open, &stats.Merged, &stats.Closed,
+		&stats.FirstReview.Count, &stats.FirstReview.Percentiles,
+		&stats.Merge.Count, &stats.Merge.Percentiles,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("compute pull request stats: %w", err)
+	}
+
+	err = conn(ctx, r.db).QueryRow(ctx, countReassignmentsQuery, filter.TeamID, filter.From, filter.To).Scan(
+		&stats.Reassignments, &stats.ReassignedPullRequests)
+	if err != nil {
+		return nil, fmt.Errorf("count reassignments: %w", err)
+	}
+
+	return &stats, nil
+}
+
+func (r *StatsRepository) GetThroughput(ctx context.Context, filter models.StatsFilter) ([]*models.DailyThroughput, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectThroughputQuery, filter.TeamID, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("compute throughput: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*models.DailyThroughput
+	for rows.Next() {
+		var day models.DailyThroughput
+		if err := rows.Scan(&day.Day, &day.Opened, &day.Merged); err != nil {
+			return nil, fmt.Errorf("scan throughput: %w", err)
+		}
+		days = append(days, &day)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over throughput rows: %w", err)
+	}
+
+	return days, nil
+}
+
+func (r *StatsRepository) GetReviewerAssignments(ctx context.Context,
+	filter models.StatsFilter) ([]*models.ReviewerAssignments, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, countReviewerAssignmentsQuery, filter.TeamID, filter.From, filter.To)
+	if err != nil {
+		return nil, fmt.Errorf("count reviewer assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var list []*models.ReviewerAssignments
+	for rows.Next() {
+		var a models.ReviewerAssignments
+		if err := rows.Scan(&a.ReviewerID, &a.Username, &a.Assigned); err != nil {
+			return nil, fmt.Errorf("scan reviewer assignments: %w", err)
+		}
+		list = append(list, &a)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over reviewer assignment rows: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *StatsRepository) GetReviewerLoads(ctx context.Context, teamID, reviewerID *int64) ([]*models.ReviewerLoad, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectReviewerLoadsQuery, teamID, reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("compute reviewer loads: %w", err)
+	}
+	defer rows.Close()
+
+	var loads []*models.ReviewerLoad
+	for rows.Next() {
+		var l models.ReviewerLoad
+		if err := rows.Scan(&l.TeamID, &l.TeamName, &l.ReviewerID, &l.Username, &l.IsActive, &l.Assigned, &l.Open,
+			&l.Merged, &l.AverageOpenAgeHours, &l.TeamAssigned); err != nil {
+			return nil, fmt.Errorf("scan reviewer load: %w", err)
+		}
+		loads = append(loads, &l)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over reviewer load rows: %w", err)
+	}
+
+	return loads, nil
+}
+
+// ExportPullRequests streams the rows instead of loading them, so the export
+// holds a connection until fn has been called for the last one.
+func (r *StatsRepository) ExportPullRequests(ctx context.Context, filter models.StatsFilter,
+	fn func(*models.PullRequestExport) error) error {
+	rows, err := conn(ctx, r.db).Query(ctx, exportPullRequestsQuery, filter.TeamID, filter.From, filter.To)
+	if err != nil {
+		return fmt.Errorf("export pull requests: %w", err)
+	}
+	defer rows.Close()
+
+	var pr models.PullRequestExport
+	for rows.Next() {
+		if err := rows.Scan(&pr.ID, &pr.Title, &pr.AuthorID, &pr.TeamName, &pr.Status, &pr.CreatedAt,
+			&pr.FirstReviewedAt, &pr.MergedAt, &pr.ClosedAt, &pr.ReviewerIDs, &pr.Reassignments); err != nil {
+			return fmt.Errorf("scan pull request export: %w", err)
+		}
+		if err := fn(&pr); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating over pull request export rows: %w", err)
+	}
+
+	return nil
+}
+
+func (r *StatsRepository) ExportReviewAssignments(ctx context.Context, filter models.StatsFilter,
+	fn func(*models.ReviewAssignmentExport) error) error {
+	rows, err := conn(ctx, r.db).Query(ctx, exportReviewAssignmentsQuery, filter.TeamID, filter.From, filter.To)
+	if err != nil {
+		return fmt.Errorf("export review assignments: %w", err)
+	}
+	defer rows.Close()
+
+	var a models.ReviewAssignmentExport
+	for rows.Next() {
+		if err := rows.Scan(&a.PullRequestID, &a.TeamName, &a.Status, &a.ReviewerID, &a.AssignedAt, &a.Decision,
+			&a.DecidedAt, &a.ReassignedAt, &a.ReplacedBy); err != nil {
+			return fmt.Errorf("scan review assignment export: %w", err)
+		}
+		if err := fn(&a); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("iterating over review assignment export rows: %w", err)
+	}
+
+	return nil
+}
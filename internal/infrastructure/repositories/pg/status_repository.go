@@ -26,7 +26,7 @@ const (
 
 func (r *StatusRepository) FindByID(ctx context.Context, id int64) (*models.Status, error) {
 	var s models.Status
-	if err := r.db.QueryRow(ctx, getStatusByIDQuery, id).Scan(&s.ID, &s.Name); err != nil {
+	if err := conn(ctx, r.db).QueryRow(ctx, getStatusByIDQuery, id).Scan(&s.ID, &s.Name); err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, ErrStatusNotFound
 		}
@@ -36,7 +36,7 @@ func (r *StatusRepository) FindByID(ctx context.Context, id int64) (*models.Stat
 }
 
 func (r *StatusRepository) FindAll(ctx context.Context) ([]*models.Status, error) {
-	rows, err := r.db.Query(ctx, listStatusesQuery)
+	rows, err := conn(ctx, r.db).Query(ctx, listStatusesQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,76 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrTeamChatChannelNotFound = errors.New("team chat channel not found")
+
+type TeamChatChannelRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTeamChatChannelRepository(db *pgxpool.Pool) *TeamChatChannelRepository {
+	return &TeamChatChannelRepository{db: db}
+}
+
+const (
+	upsertTeamChatChannelQuery = `
+		INSERT INTO team_chat_channels (team_id, provider, webhook_url, channel)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (team_id) DO UPDATE
+		SET provider = EXCLUDED.provider, webhook_url = EXCLUDED.webhook_url, channel = EXCLUDED.channel,
+		    updated_at = NOW()
+		RETURNING created_at, updated_at;
+	`
+	selectTeamChatChannelQuery = `
+		SELECT team_id, provider, webhook_url, channel, created_at, updated_at
+		FROM team_chat_channels
+		WHERE team_id = $1;
+	`
+	deleteTeamChatChannelQuery = `
+		DELETE FROM team_chat_channels WHERE team_id = $1;
+	`
+)
+
+func (r *TeamChatChannelRepository) Upsert(ctx context.Context, channel *models.TeamChatChannel) error {
+	if err := conn(ctx, r.db).QueryRow(ctx, upsertTeamChatChannelQuery, channel.TeamID, channel.Provider,
+		channel.WebhookURL, channel.Channel).Scan(&channel.CreatedAt, &channel.UpdatedAt); err != nil {
+		return fmt.Errorf("set chat channel of team %d: %w", channel.TeamID, err)
+	}
+
+	return nil
+}
+
+func (r *TeamChatChannelRepository) FindByTeamID(ctx context.Context, teamID int64) (*models.TeamChatChannel, error) {
+	var c models.TeamChatChannel
+	err := conn(ctx, r.db).QueryRow(ctx, selectTeamChatChannelQuery, teamID).
+		Scan(&c.TeamID, &c.Provider, &c.WebhookURL, &c.Channel, &c.CreatedAt, &c.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTeamChatChannelNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find chat channel of team %d: %w", teamID, err)
+	}
+
+	return &c, nil
+}
+
+func (r *TeamChatChannelRepository) DeleteByTeamID(ctx context.Context, teamID int64) error {
+	cmd, err := conn(ctx, r.db).Exec(ctx, deleteTeamChatChannelQuery, teamID)
+	if err != nil {
+		return fmt.Errorf("delete chat channel of team %d: %w", teamID, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrTeamChatChannelNotFound
+	}
+
+	return nil
+}
@@ -23,20 +23,24 @@ func NewTeamRepository(db *pgxpool.Pool) *TeamRepository {
 }
 
 const (
-	insertTeamQuery         = `INSERT INTO teams (name) VALUES ($1) RETURNING id`
-	updateTeamQuery         = `UPDATE teams SET name=$1 WHERE id=$2`
-	deleteTeamUsersQuery    = `DELETE FROM team_user WHERE team_id=$1`
-	deleteTeamQuery         = `DELETE FROM teams WHERE id=$1`
-	insertTeamUserQuery     = `INSERT INTO team_user (team_id, user_id) VALUES ($1, $2)`
-	selectTeamByIDQuery     = `SELECT id, name, created_at, updated_at FROM teams WHERE id=$1`
-	selectTeamByNameQuery   = `SELECT id, name, created_at, updated_at FROM teams WHERE name=$1`
-	selectTeamUsersQuery    = `SELECT user_id FROM team_user WHERE team_id=$1`
-	selectAllTeamsQuery     = `SELECT id, name, created_at, updated_at FROM teams ORDER BY created_at DESC`
-	selectTeamByUserIDQuery = `SELECT t.id, t.name, t.created_at, t.updated_at FROM teams t JOIN team_user tu ON t.id = tu.team_id WHERE tu.user_id = $1`
+	insertTeamQuery          = `INSERT INTO teams (name) VALUES ($1) RETURNING id`
+	updateTeamQuery          = `UPDATE teams SET name=$1 WHERE id=$2`
+	updateTeamSettingsQuery  = `UPDATE teams SET reviewer_strategy=$1, min_reviewers=$2, max_reviewers=$3, required_approvals=$4 WHERE id=$5 RETURNING updated_at`
+	deleteTeamUsersQuery     = `DELETE FROM team_user WHERE team_id=$1`
+	deleteTeamQuery          = `DELETE FROM teams WHERE id=$1`
+	insertTeamUserQuery      = `INSERT INTO team_user (team_id, user_id) VALUES ($1, $2)`
+	selectTeamByIDQuery      = `SELECT id, name, reviewer_strategy, min_reviewers, max_reviewers, required_approvals, created_at, updated_at FROM teams WHERE id=$1`
+	selectTeamByNameQuery    = `SELECT id, name, reviewer_strategy, min_reviewers, max_reviewers, required_approvals, created_at, updated_at FROM teams WHERE name=$1`
+	selectTeamUsersQuery     = `SELECT user_id FROM team_user WHERE team_id=$1`
+	selectAllTeamsQuery      = `SELECT id, name, reviewer_strategy, min_reviewers, max_reviewers, required_approvals, created_at, updated_at FROM teams ORDER BY created_at DESC`
+	selectBackupTeamIDsQuery = `SELECT backup_team_id FROM team_backups WHERE team_id=$1 ORDER BY position`
+	deleteBackupTeamsQuery   = `DELETE FROM team_backups WHERE team_id=$1`
+	insertBackupTeamQuery    = `INSERT INTO team_backups (team_id, backup_team_id, position) VALUES ($1, $2, $3)`
+	selectTeamsByUserIDQuery = `SELECT t.id, t.name, t.reviewer_strategy, t.min_reviewers, t.max_reviewers, t.required_approvals, t.created_at, t.updated_at FROM teams t JOIN team_user tu ON t.id = tu.team_id WHERE tu.user_id = $1 ORDER BY t.id`
 )
 
 func (r *TeamRepository) Create(ctx context.Context, team *models.Team) error {
-	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -60,14 +64,14 @@ func (r *TeamRepository) Create(ctx context.Context, team *models.Team) error {
 func (r *TeamRepository) FindByID(ctx context.Context, id int64) (*models.Team, error) {
 	team := &models.Team{UserIDs: []int64{}}
 
-	err := r.db.QueryRow(ctx, selectTeamByIDQuery, id).Scan(
-		&team.ID, &team.Name, &team.CreatedAt, &team.UpdatedAt,
+	err := conn(ctx, r.db).QueryRow(ctx, selectTeamByIDQuery, id).Scan(
+		&team.ID, &team.Name, &team.ReviewerStrategy, &team.MinReviewers, &team.MaxReviewers, &team.RequiredApprovals, &team.CreatedAt, &team.UpdatedAt,
 	)
 	if err != nil {
 		return nil, ErrTeamNotFound
 	}
 
-	rows, err := r.db.Query(ctx, selectTeamUsersQuery, id)
+	rows, err := conn(ctx, r.db).Query(ctx, selectTeamUsersQuery, id)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +89,7 @@ func (r *TeamRepository) FindByID(ctx context.Context, id int64) (*models.Team,
 }
 
 func (r *TeamRepository) FindAll(ctx context.Context) ([]*models.Team, error) {
-	rows, err := r.db.Query(ctx, selectAllTeamsQuery)
+	rows, err := conn(ctx, r.db).Query(ctx, selectAllTeamsQuery)
 	if err != nil {
 		return nil, err
 	}
@@ -96,11 +100,11 @@ func (r *TeamRepository) FindAll(ctx context.Context) ([]*models.Team, error) {
 		var t models.Team
 		t.UserIDs = []int64{}
 
-		if err := rows.Scan(&t.ID, &t.Name, &t.CreatedAt, &t.UpdatedAt); err != nil {
+		if err := rows.Scan(&t.ID, &t.Name, &t.ReviewerStrategy, &t.MinReviewers, &t.MaxReviewers, &t.RequiredApprovals, &t.CreatedAt, &t.UpdatedAt); err != nil {
 			return nil, err
 		}
 
-		memberRows, err := r.db.Query(ctx, selectTeamUsersQuery, t.ID)
+		memberRows, err := conn(ctx, r.db).Query(ctx, selectTeamUsersQuery, t.ID)
 		if err != nil {
 			return nil, err
 		}
@@ -120,7 +124,7 @@ func (r *TeamRepository) FindAll(ctx context.Context) ([]*models.Team, error) {
 }
 
 func (r *TeamRepository) Update(ctx context.Context, team *models.Team) error {
-	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -149,8 +153,20 @@ func (r *TeamRepository) Update(ctx context.Context, team *models.Team) error {
 	return tx.Commit(ctx)
 }
 
+func (r *TeamRepository) UpdateSettings(ctx context.Context, team *models.Team) error {
+	err := conn(ctx, r.db).QueryRow(ctx, updateTeamSettingsQuery, team.ReviewerStrategy, team.MinReviewers, team.MaxReviewers, team.RequiredApprovals, team.ID).Scan(&team.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTeamNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("update settings for team %d: %w", team.ID, err)
+	}
+
+	return nil
+}
+
 func (r *TeamRepository) DeleteByID(ctx context.Context, id int64) error {
-	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -173,14 +189,14 @@ func (r *TeamRepository) DeleteByID(ctx context.Context, id int64) error {
 func (r *TeamRepository) FindByName(ctx context.Context, name string) (*models.Team, error) {
 	team := &models.Team{UserIDs: []int64{}}
 
-	err := r.db.QueryRow(ctx, selectTeamByNameQuery, name).Scan(
-		&team.ID, &team.Name, &team.CreatedAt, &team.UpdatedAt,
+	err := conn(ctx, r.db).QueryRow(ctx, selectTeamByNameQuery, name).Scan(
+		&team.ID, &team.Name, &team.ReviewerStrategy, &team.MinReviewers, &team.MaxReviewers, &team.RequiredApprovals, &team.CreatedAt, &team.UpdatedAt,
 	)
 	if err != nil {
 		return nil, ErrTeamNotFound
 	}
 
-	rows, err := r.db.Query(ctx, selectTeamUsersQuery, team.ID)
+	rows, err := conn(ctx, r.db).Query(ctx, selectTeamUsersQuery, team.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -198,7 +214,7 @@ func (r *TeamRepository) FindByName(ctx context.Context, name string) (*models.T
 }
 
 func (r *TeamRepository) CreateWithUsers(ctx context.Context, teamReq *dtos.Team) error {
-	tx, err := r.db.BeginTx(ctx, pgx.TxOptions{})
+	tx, err := conn(ctx, r.db).Begin(ctx)
 	if err != nil {
 		return err
 	}
@@ -236,29 +252,95 @@ func (r *TeamRepository) CreateWithUsers(ctx context.Context, teamReq *dtos.Team
 	return tx.Commit(ctx)
 }
 
-func (r *TeamRepository) FindByUserID(ctx context.Context, userID int64) (*models.Team, error) {
-	team := &models.Team{UserIDs: []int64{}}
-
-	err := r.db.QueryRow(ctx, selectTeamByUserIDQuery, userID).Scan(
-		&team.ID, &team.Name, &team.CreatedAt, &team.UpdatedAt,
-	)
+func (r *TeamRepository) FindAllByUserID(ctx context.Context, userID int64) ([]*models.Team, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectTeamsByUserIDQuery, userID)
 	if err != nil {
-		return nil, ErrTeamNotFound
+		return nil, fmt.Errorf("find teams of user %d: %w", userID, err)
+	}
+
+	teams := make([]*models.Team, 0)
+	for rows.Next() {
+		t := &models.Team{UserIDs: []int64{}}
+		if err := rows.Scan(&t.ID, &t.Name, &t.ReviewerStrategy, &t.MinReviewers, &t.MaxReviewers, &t.RequiredApprovals, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan team of user %d: %w", userID, err)
+		}
+		teams = append(teams, t)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over teams of user %d: %w", userID, err)
+	}
+
+	for _, t := range teams {
+		memberRows, err := conn(ctx, r.db).Query(ctx, selectTeamUsersQuery, t.ID)
+		if err != nil {
+			return nil, err
+		}
+		for memberRows.Next() {
+			var uid int64
+			if err := memberRows.Scan(&uid); err != nil {
+				memberRows.Close()
+				return nil, err
+			}
+			t.UserIDs = append(t.UserIDs, uid)
+		}
+		memberRows.Close()
 	}
 
-	rows, err := r.db.Query(ctx, selectTeamUsersQuery, team.ID)
+	return teams, nil
+}
+
+func (r *TeamRepository) FindBackupTeams(ctx context.Context, teamID int64) ([]*models.Team, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectBackupTeamIDsQuery, teamID)
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("find backup teams of team %d: %w", teamID, err)
 	}
-	defer rows.Close()
 
+	var ids []int64
 	for rows.Next() {
-		var uid int64
-		if err := rows.Scan(&uid); err != nil {
-			return nil, err
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("scan backup team id: %w", err)
 		}
-		team.UserIDs = append(team.UserIDs, uid)
+		ids = append(ids, id)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over backup teams of team %d: %w", teamID, err)
 	}
 
-	return team, nil
+	teams := make([]*models.Team, 0, len(ids))
+	for _, id := range ids {
+		team, err := r.FindByID(ctx, id)
+		if err != nil {
+			return nil, fmt.Errorf("find backup team %d: %w", id, err)
+		}
+		teams = append(teams, team)
+	}
+
+	return teams, nil
+}
+
+func (r *TeamRepository) SetBackupTeams(ctx context.Context, teamID int64, backupTeamIDs []int64) error {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	if _, err := tx.Exec(ctx, deleteBackupTeamsQuery, teamID); err != nil {
+		return fmt.Errorf("clear backup teams of team %d: %w", teamID, err)
+	}
+
+	for i, backupID := range backupTeamIDs {
+		if _, err := tx.Exec(ctx, insertBackupTeamQuery, teamID, backupID, i); err != nil {
+			return fmt.Errorf("link backup team %d to team %d: %w", backupID, teamID, err)
+		}
+	}
+
+	return tx.Commit(ctx)
 }
@@ -0,0 +1,111 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrTeamReviewSLANotFound = errors.New("team review SLA not found")
+
+type TeamReviewSLARepository struct {
+	db *pgxpool.Pool
+}
+
+func NewTeamReviewSLARepository(db *pgxpool.Pool) *TeamReviewSLARepository {
+	return &TeamReviewSLARepository{db: db}
+}
+
+const (
+	upsertTeamReviewSLAQuery = `
+		INSERT INTO team_review_slas (team_id, first_decision_seconds, work_start_minutes, work_end_minutes,
+		                              work_days, timezone, auto_reassign)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (team_id) DO UPDATE
+		SET first_decision_seconds = EXCLUDED.first_decision_seconds, work_start_minutes = EXCLUDED.work_start_minutes,
+		    work_end_minutes = EXCLUDED.work_end_minutes, work_days = EXCLUDED.work_days,
+		    timezone = EXCLUDED.timezone, auto_reassign = EXCLUDED.auto_reassign, updated_at = NOW()
+		RETURNING created_at, updated_at;
+	`
+	selectTeamReviewSLAQuery = `
+		SELECT team_id, first_decision_seconds, work_start_minutes, work_end_minutes, work_days, timezone,
+		       auto_reassign, created_at, updated_at
+		FROM team_review_slas
+		WHERE team_id = $1;
+	`
+	selectAllTeamReviewSLAsQuery = `
+		SELECT team_id, first_decision_seconds, work_start_minutes, work_end_minutes, work_days, timezone,
+		       auto_reassign, created_at, updated_at
+		FROM team_review_slas
+		ORDER BY team_id;
+	`
+	deleteTeamReviewSLAQuery = `
+		DELETE FROM team_review_slas WHERE team_id = $1;
+	`
+)
+
+func (r *TeamReviewSLARepository) Upsert(ctx context.Context, sla *models.TeamReviewSLA) error {
+	if err := conn(ctx, r.db).QueryRow(ctx, upsertTeamReviewSLAQuery, sla.TeamID, sla.FirstDecisionSeconds,
+		sla.WorkStartMinutes, sla.WorkEndMinutes, sla.WorkDays, sla.Timezone, sla.AutoReassign).
+		Scan(&sla.CreatedAt, &sla.UpdatedAt); err != nil {
+		return fmt.Errorf("set review SLA of team %d: %w", sla.TeamID, err)
+	}
+
+	return nil
+}
+
+func (r *TeamReviewSLARepository) FindByTeamID(ctx context.Context, teamID int64) (*models.TeamReviewSLA, error) {
+	var s models.TeamReviewSLA
+	err := conn(ctx, r.db).QueryRow(ctx, selectTeamReviewSLAQuery, teamID).
+		Scan(&s.TeamID, &s.FirstDecisionSeconds, &s.WorkStartMinutes, &s.WorkEndMinutes, &s.WorkDays, &s.Timezone,
+			&s.AutoReassign, &s.CreatedAt, &s.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTeamReviewSLANotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find review SLA of team %d: %w", teamID, err)
+	}
+
+	return &s, nil
+}
+
+func (r *TeamReviewSLARepository) FindAll(ctx context.Context) ([]*models.TeamReviewSLA, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectAllTeamReviewSLAsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("find review SLAs: %w", err)
+	}
+	defer rows.Close()
+
+	list := make([]*models.TeamReviewSLA, 0)
+	for rows.Next() {
+		var s models.TeamReviewSLA
+		if err := rows.Scan(&s.TeamID, &s.FirstDecisionSeconds, &s.WorkStartMinutes, &s.WorkEndMinutes, &s.WorkDays,
+			&s.Timezone, &s.AutoReassign, &s.CreatedAt, &s.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan review SLA: %w", err)
+		}
+		list = append(list, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over review SLA rows: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *TeamReviewSLARepository) DeleteByTeamID(ctx context.Context, teamID int64) error {
+	cmd, err := conn(ctx, r.db).Exec(ctx, deleteTeamReviewSLAQuery, teamID)
+	if err != nil {
+		return fmt.Errorf("delete review SLA of team %d: %w", teamID, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrTeamReviewSLANotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,162 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrUnavailabilityNotFound = errors.New("unavailability period not found")
+
+type UnavailabilityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUnavailabilityRepository(db *pgxpool.Pool) *UnavailabilityRepository {
+	return &UnavailabilityRepository{db: db}
+}
+
+const (
+	insertUnavailabilityQuery = `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, source, external_uid)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at;
+	`
+	upsertImportedUnavailabilityQuery = `
+		INSERT INTO user_unavailability (user_id, starts_at, ends_at, reason, source, external_uid)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (user_id, external_uid) DO UPDATE
+		SET starts_at = EXCLUDED.starts_at, ends_at = EXCLUDED.ends_at, reason = EXCLUDED.reason
+		RETURNING id, created_at;
+	`
+	deleteStaleImportedQuery = `
+		DELETE FROM user_unavailability
+		WHERE user_id = $1 AND source = 'ics' AND NOT (external_uid = ANY($2));
+	`
+	selectUnavailabilityByUserQuery = `
+		SELECT id, user_id, starts_at, ends_at, reason, source, external_uid, created_at
+		FROM user_unavailability
+		WHERE user_id = $1
+		ORDER BY starts_at;
+	`
+	deleteUnavailabilityQuery = `
+		DELETE FROM user_unavailability WHERE id = $1 AND user_id = $2;
+	`
+	selectUnavailableAtQuery = `
+		SELECT DISTINCT user_id FROM user_unavailability
+		WHERE user_id = ANY($1) AND starts_at <= $2 AND ends_at > $2;
+	`
+)
+
+func (r *UnavailabilityRepository) Create(ctx context.Context, period *models.Unavailability) error {
+	if err := conn(ctx, r.db).QueryRow(ctx, insertUnavailabilityQuery, period.UserID, period.StartsAt,
+		period.EndsAt, period.Reason, period.Source, period.ExternalUID).
+		Scan(&period.ID, &period.CreatedAt); err != nil {
+		return fmt.Errorf("create unavailability for user %d: %w", period.UserID, err)
+	}
+
+	return nil
+}
+
+func (r *UnavailabilityRepository) FindByUserID(ctx context.Context, userID int64) ([]*models.Unavailability, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectUnavailabilityByUserQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find unavailability of user %d: %w", userID, err)
+	}
+	defer rows.Close()
+
+	list := make([]*models.Unavailability, 0)
+
+	for rows.Next() {
+		var p models.Unavailability
+		if err := rows.Scan(&p.ID, &p.UserID, &p.StartsAt, &p.EndsAt, &p.Reason, &p.Source,
+			&p.ExternalUID, &p.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan unavailability: %w", err)
+		}
+		list = append(list, &p)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over unavailability rows for user %d: %w", userID, err)
+	}
+
+	return list, nil
+}
+
+func (r *UnavailabilityRepository) DeleteByID(ctx context.Context, userID int64, id int64) error {
+	cmd, err := conn(ctx, r.db).Exec(ctx, deleteUnavailabilityQuery, id, userID)
+	if err != nil {
+		return fmt.Errorf("delete unavailability %d: %w", id, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrUnavailabilityNotFound
+	}
+
+	return nil
+}
+
+// SyncImported makes the calendar-imported periods of the user match periods:
+// periods are upserted by their external UID and previously imported periods
+// missing from the list are removed. It returns the number of removed periods.
+func (r *UnavailabilityRepository) SyncImported(ctx context.Context, userID int64,
+	periods []*models.Unavailability) (int64, error) {
+	tx, err := conn(ctx, r.db).Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("start transaction for calendar sync: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback(ctx)
+	}()
+
+	uids := make([]string, 0, len(periods))
+	for _, p := range periods {
+		if p.ExternalUID == nil {
+			return 0, fmt.Errorf("imported period of user %d has no external uid", userID)
+		}
+		if err := tx.QueryRow(ctx, upsertImportedUnavailabilityQuery, userID, p.StartsAt, p.EndsAt,
+			p.Reason, models.UnavailabilitySourceICS, p.ExternalUID).Scan(&p.ID, &p.CreatedAt); err != nil {
+			return 0, fmt.Errorf("upsert imported unavailability %s: %w", *p.ExternalUID, err)
+		}
+		uids = append(uids, *p.ExternalUID)
+	}
+
+	cmd, err := tx.Exec(ctx, deleteStaleImportedQuery, userID, uids)
+	if err != nil {
+		return 0, fmt.Errorf("delete stale imported unavailability: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("commit calendar sync: %w", err)
+	}
+
+	return cmd.RowsAffected(), nil
+}
+
+func (r *UnavailabilityRepository) FindUnavailableAt(ctx context.Context, userIDs []int64,
+	at time.Time) (map[int64]bool, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectUnavailableAtQuery, userIDs, at)
+	if err != nil {
+		return nil, fmt.Errorf("find unavailable users: %w", err)
+	}
+	defer rows.Close()
+
+	unavailable := make(map[int64]bool)
+	for rows.Next() {
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("scan unavailable user: %w", err)
+		}
+		unavailable[id] = true
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over unavailable users: %w", err)
+	}
+
+	return unavailable, nil
+}
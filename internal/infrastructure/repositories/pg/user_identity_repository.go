@@ -0,0 +1,141 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var (
+	ErrUserIdentityNotFound = errors.New("user identity not found")
+	ErrUserIdentityTaken    = errors.New("external identity belongs to another user")
+)
+
+type UserIdentityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewUserIdentityRepository(db *pgxpool.Pool) *UserIdentityRepository {
+	return &UserIdentityRepository{db: db}
+}
+
+// uniqueViolation is the SQLSTATE of a unique constraint violation.
+const uniqueViolation = "23505"
+
+const (
+	upsertUserIdentityQuery = `
+		INSERT INTO user_identities (user_id, provider, external_id)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (user_id, provider) DO UPDATE
+		SET external_id = EXCLUDED.external_id, updated_at = NOW()
+		RETURNING id, created_at, updated_at;
+	`
+	selectUserIdentitiesByUserQuery = `
+		SELECT id, user_id, provider, external_id, created_at, updated_at
+		FROM user_identities
+		WHERE user_id = $1
+		ORDER BY provider;
+	`
+	selectUserIdentityByExternalIDQuery = `
+		SELECT id, user_id, provider, external_id, created_at, updated_at
+		FROM user_identities
+		WHERE provider = $1 AND external_id = $2;
+	`
+	selectUserIdentitiesByUsersQuery = `
+		SELECT id, user_id, provider, external_id, created_at, updated_at
+		FROM user_identities
+		WHERE provider = $1 AND user_id = ANY($2);
+	`
+	deleteUserIdentityQuery = `
+		DELETE FROM user_identities WHERE user_id = $1 AND provider = $2;
+	`
+)
+
+// Upsert sets the user's identity for the provider, replacing the previous
+// one. It returns ErrUserIdentityTaken when another user already has the
+// external ID.
+func (r *UserIdentityRepository) Upsert(ctx context.Context, identity *models.UserIdentity) error {
+	err := conn(ctx, r.db).QueryRow(ctx, upsertUserIdentityQuery, identity.UserID, identity.Provider,
+		identity.ExternalID).Scan(&identity.ID, &identity.CreatedAt, &identity.UpdatedAt)
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return ErrUserIdentityTaken
+	}
+	if err != nil {
+		return fmt.Errorf("set %s identity of user %d: %w", identity.Provider, identity.UserID, err)
+	}
+
+	return nil
+}
+
+func (r *UserIdentityRepository) FindByUserID(ctx context.Context, userID int64) ([]*models.UserIdentity, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectUserIdentitiesByUserQuery, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find identities of user %d: %w", userID, err)
+	}
+
+	return scanUserIdentities(rows)
+}
+
+func (r *UserIdentityRepository) FindByExternalID(ctx context.Context, provider,
+	externalID string) (*models.UserIdentity, error) {
+	var i models.UserIdentity
+	err := conn(ctx, r.db).QueryRow(ctx, selectUserIdentityByExternalIDQuery, provider, externalID).
+		Scan(&i.ID, &i.UserID, &i.Provider, &i.ExternalID, &i.CreatedAt, &i.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserIdentityNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find %s identity %q: %w", provider, externalID, err)
+	}
+
+	return &i, nil
+}
+
+func (r *UserIdentityRepository) FindByUserIDs(ctx context.Context, provider string,
+	userIDs []int64) ([]*models.UserIdentity, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectUserIdentitiesByUsersQuery, provider, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find %s identities: %w", provider, err)
+	}
+
+	return scanUserIdentities(rows)
+}
+
+func (r *UserIdentityRepository) Delete(ctx context.Context, userID int64, provider string) error {
+	cmd, err := conn(ctx, r.db).Exec(ctx, deleteUserIdentityQuery, userID, provider)
+	if err != nil {
+		return fmt.Errorf("delete %s identity of user %d: %w", provider, userID, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrUserIdentityNotFound
+	}
+
+	return nil
+}
+
+func scanUserIdentities(rows pgx.Rows) ([]*models.UserIdentity, error) {
+	defer rows.Close()
+
+	list := make([]*models.UserIdentity, 0)
+	for rows.Next() {
+		var i models.UserIdentity
+		if err := rows.Scan(&i.ID, &i.UserID, &i.Provider, &i.ExternalID, &i.CreatedAt, &i.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("scan user identity: %w", err)
+		}
+		list = append(list, &i)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over user identity rows: %w", err)
+	}
+
+	return list, nil
+}
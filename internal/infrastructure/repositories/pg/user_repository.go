@@ -22,14 +22,45 @@ func NewUserRepository(db *pgxpool.Pool) *UserRepository {
 
 const (
 	insertUserQuery     = `INSERT INTO users (username, is_active) VALUES ($1, $2) RETURNING id, created_at, updated_at;`
-	selectUserByIDQuery = `SELECT id, username, is_active, created_at, updated_at FROM users WHERE id = $1;`
-	selectAllUsersQuery = `SELECT id, username, is_active, created_at, updated_at FROM users ORDER BY created_at DESC;`
+	selectUserByIDQuery = `
+		SELECT u.id, u.username, u.is_active, u.created_at, u.updated_at,
+		       COALESCE(array_agg(tu.team_id ORDER BY tu.team_id) FILTER (WHERE tu.team_id IS NOT NULL), '{}')
+		FROM users u
+		LEFT JOIN team_user tu ON tu.user_id = u.id
+		WHERE u.id = $1
+		GROUP BY u.id;
+	`
+	selectUserByUsernameQuery = `
+		SELECT u.id, u.username, u.is_active, u.created_at, u.updated_at,
+		       COALESCE(array_agg(tu.team_id ORDER BY tu.team_id) FILTER (WHERE tu.team_id IS NOT NULL), '{}')
+		FROM users u
+		LEFT JOIN team_user tu ON tu.user_id = u.id
+		WHERE u.username = $1
+		GROUP BY u.id;
+	`
+	selectAllUsersQuery = `
+		SELECT u.id, u.username, u.is_active, u.created_at, u.updated_at,
+		       COALESCE(array_agg(tu.team_id ORDER BY tu.team_id) FILTER (WHERE tu.team_id IS NOT NULL), '{}')
+		FROM users u
+		LEFT JOIN team_user tu ON tu.user_id = u.id
+		GROUP BY u.id
+		ORDER BY u.created_at DESC;
+	`
+	selectUsersByIDsQuery = `
+		SELECT u.id, u.username, u.is_active, u.created_at, u.updated_at,
+		       COALESCE(array_agg(tu.team_id ORDER BY tu.team_id) FILTER (WHERE tu.team_id IS NOT NULL), '{}')
+		FROM users u
+		LEFT JOIN team_user tu ON tu.user_id = u.id
+		WHERE u.id = ANY($1)
+		GROUP BY u.id;
+	`
 	updateUserQuery     = `UPDATE users SET username = $1, is_active = $2, updated_at = now() WHERE id = $3 RETURNING updated_at;`
 	deleteUserQuery     = `DELETE FROM users WHERE id = $1;`
+	setUsersActiveQuery = `UPDATE users SET is_active = $1 WHERE id = ANY($2) AND is_active <> $1;`
 )
 
 func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
-	if err := r.db.QueryRow(ctx, insertUserQuery, user.Username, user.IsActive).
+	if err := conn(ctx, r.db).QueryRow(ctx, insertUserQuery, user.Username, user.IsActive).
 		Scan(&user.ID, &user.CreatedAt, &user.UpdatedAt); err != nil {
 		return fmt.Errorf("create user: %w", err)
 	}
@@ -40,8 +71,8 @@ func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
 func (r *UserRepository) FindByID(ctx context.Context, id int64) (*models.User, error) {
 	u := models.User{}
 
-	err := r.db.QueryRow(ctx, selectUserByIDQuery, id).
-		Scan(&u.ID, &u.Username, &u.IsActive, &u.CreatedAt, &u.UpdatedAt)
+	err := conn(ctx, r.db).QueryRow(ctx, selectUserByIDQuery, id).
+		Scan(&u.ID, &u.Username, &u.IsActive, &u.CreatedAt, &u.UpdatedAt, &u.TeamIDs)
 
 	if errors.Is(err, pgx.ErrNoRows) {
 		return nil, ErrUserNotFound
@@ -53,8 +84,24 @@ func (r *UserRepository) FindByID(ctx context.Context, id int64) (*models.User,
 	return &u, nil
 }
 
+func (r *UserRepository) FindByUsername(ctx context.Context, username string) (*models.User, error) {
+	u := models.User{}
+
+	err := conn(ctx, r.db).QueryRow(ctx, selectUserByUsernameQuery, username).
+		Scan(&u.ID, &u.Username, &u.IsActive, &u.CreatedAt, &u.UpdatedAt, &u.TeamIDs)
+
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrUserNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find user by username %q: %w", username, err)
+	}
+
+	return &u, nil
+}
+
 func (r *UserRepository) FindAll(ctx context.Context) ([]*models.User, error) {
-	rows, err := r.db.Query(ctx, selectAllUsersQuery)
+	rows, err := conn(ctx, r.db).Query(ctx, selectAllUsersQuery)
 	if err != nil {
 		return nil, fmt.Errorf("find all users: %w", err)
 	}
@@ -64,7 +111,7 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]*models.User, error) {
 
 	for rows.Next() {
 		var u models.User
-		if err = rows.Scan(&u.ID, &u.Username, &u.IsActive, &u.CreatedAt, &u.UpdatedAt); err != nil {
+		if err = rows.Scan(&u.ID, &u.Username, &u.IsActive, &u.CreatedAt, &u.UpdatedAt, &u.TeamIDs); err != nil {
 			return nil, fmt.Errorf("scan user: %w", err)
 		}
 		list = append(list, &u)
@@ -77,8 +124,39 @@ func (r *UserRepository) FindAll(ctx context.Context) ([]*models.User, error) {
 	return list, nil
 }
 
+func (r *UserRepository) FindByIDs(ctx context.Context, ids []int64) ([]*models.User, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectUsersByIDsQuery, ids)
+	if err != nil {
+		return nil, fmt.Errorf("find users by ids: %w", err)
+	}
+	defer rows.Close()
+
+	list := make([]*models.User, 0, len(ids))
+
+	for rows.Next() {
+		var u models.User
+		if err = rows.Scan(&u.ID, &u.Username, &u.IsActive, &u.CreatedAt, &u.UpdatedAt, &u.TeamIDs); err != nil {
+			return nil, fmt.Errorf("scan user: %w", err)
+		}
+		list = append(list, &u)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over user rows: %w", err)
+	}
+
+	return list, nil
+}
+
+func (r *UserRepository) SetActive(ctx context.Context, ids []int64, active bool) error {
+	if _, err := conn(ctx, r.db).Exec(ctx, setUsersActiveQuery, active, ids); err != nil {
+		return fmt.Errorf("set users active=%t: %w", active, err)
+	}
+	return nil
+}
+
 func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
-	err := r.db.QueryRow(
+	err := conn(ctx, r.db).QueryRow(
 		ctx,
 		updateUserQuery,
 		user.Username,
@@ -97,7 +175,7 @@ func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
 }
 
 func (r *UserRepository) DeleteByID(ctx context.Context, id int64) error {
-	cmd, err := r.db.Exec(ctx, deleteUserQuery, id)
+	cmd, err := conn(ctx, r.db).Exec(ctx, deleteUserQuery, id)
 	if err != nil {
 		return fmt.Errorf("delete user %d: %w", id, err)
 	}
@@ -0,0 +1,148 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrWebhookDeliveryNotFound = errors.New("webhook delivery not found")
+
+type WebhookDeliveryRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookDeliveryRepository(db *pgxpool.Pool) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{db: db}
+}
+
+const (
+	insertWebhookDeliveryQuery = `
+		INSERT INTO webhook_deliveries (subscription_id, event_type, payload, status)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at, updated_at;
+	`
+	selectWebhookDeliveryByIDQuery = `
+		SELECT id, subscription_id, event_type, payload, status, attempts, response_status, last_error,
+		       created_at, updated_at, delivered_at
+		FROM webhook_deliveries
+		WHERE id = $1;
+	`
+	selectWebhookDeliveryByEventQuery = `
+		SELECT id, subscription_id, event_type, payload, status, attempts, response_status, last_error,
+		       created_at, updated_at, delivered_at
+		FROM webhook_deliveries
+		WHERE subscription_id = $1 AND event_type = $2 AND payload = $3::jsonb
+		ORDER BY id DESC
+		LIMIT 1;
+	`
+	selectWebhookDeliveriesBySubscriptionQuery = `
+		SELECT id, subscription_id, event_type, payload, status, attempts, response_status, last_error,
+		       created_at, updated_at, delivered_at
+		FROM webhook_deliveries
+		WHERE subscription_id = $1
+		ORDER BY id DESC;
+	`
+	updateWebhookDeliveryAttemptQuery = `
+		UPDATE webhook_deliveries
+		SET status = $2, attempts = $3, response_status = $4, last_error = $5, delivered_at = $6,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING updated_at;
+	`
+)
+
+func (r *WebhookDeliveryRepository) Create(ctx context.Context, delivery *models.WebhookDelivery) error {
+	if delivery.Status == "" {
+		delivery.Status = models.DeliveryPending
+	}
+
+	if err := conn(ctx, r.db).QueryRow(ctx, insertWebhookDeliveryQuery, delivery.SubscriptionID,
+		delivery.EventType, delivery.Payload, delivery.Status).
+		Scan(&delivery.ID, &delivery.CreatedAt, &delivery.UpdatedAt); err != nil {
+		return fmt.Errorf("create webhook delivery for subscription %d: %w", delivery.SubscriptionID, err)
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) FindByID(ctx context.Context, id int64) (*models.WebhookDelivery, error) {
+	d, err := scanWebhookDelivery(conn(ctx, r.db).QueryRow(ctx, selectWebhookDeliveryByIDQuery, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find webhook delivery %d: %w", id, err)
+	}
+
+	return d, nil
+}
+
+// FindByEvent finds the latest delivery of the event to the subscription. An
+// event is told apart by its payload, which holds the time it occurred.
+func (r *WebhookDeliveryRepository) FindByEvent(ctx context.Context, subscriptionID int64, eventType string,
+	payload []byte) (*models.WebhookDelivery, error) {
+	d, err := scanWebhookDelivery(conn(ctx, r.db).QueryRow(ctx, selectWebhookDeliveryByEventQuery, subscriptionID,
+		eventType, payload))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find delivery of %s to webhook subscription %d: %w", eventType, subscriptionID, err)
+	}
+
+	return d, nil
+}
+
+func (r *WebhookDeliveryRepository) FindBySubscriptionID(ctx context.Context,
+	subscriptionID int64) ([]*models.WebhookDelivery, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectWebhookDeliveriesBySubscriptionQuery, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("find deliveries of webhook subscription %d: %w", subscriptionID, err)
+	}
+	defer rows.Close()
+
+	list := make([]*models.WebhookDelivery, 0)
+	for rows.Next() {
+		d, err := scanWebhookDelivery(rows)
+		if err != nil {
+			return nil, fmt.Errorf("scan webhook delivery: %w", err)
+		}
+		list = append(list, d)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over webhook delivery rows: %w", err)
+	}
+
+	return list, nil
+}
+
+// RecordAttempt stores the outcome of the latest attempt at the delivery:
+// its status, attempt count, response and error.
+func (r *WebhookDeliveryRepository) RecordAttempt(ctx context.Context, delivery *models.WebhookDelivery) error {
+	err := conn(ctx, r.db).QueryRow(ctx, updateWebhookDeliveryAttemptQuery, delivery.ID, delivery.Status,
+		delivery.Attempts, delivery.ResponseStatus, delivery.LastError, delivery.DeliveredAt).
+		Scan(&delivery.UpdatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrWebhookDeliveryNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("record attempt of webhook delivery %d: %w", delivery.ID, err)
+	}
+
+	return nil
+}
+
+func scanWebhookDelivery(row pgx.Row) (*models.WebhookDelivery, error) {
+	var d models.WebhookDelivery
+	if err := row.Scan(&d.ID, &d.SubscriptionID, &d.EventType, &d.Payload, &d.Status, &d.Attempts,
+		&d.ResponseStatus, &d.LastError, &d.CreatedAt, &d.UpdatedAt, &d.DeliveredAt); err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
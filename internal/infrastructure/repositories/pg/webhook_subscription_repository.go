@@ -0,0 +1,144 @@
+package pg
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+var ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+
+type WebhookSubscriptionRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewWebhookSubscriptionRepository(db *pgxpool.Pool) *WebhookSubscriptionRepository {
+	return &WebhookSubscriptionRepository{db: db}
+}
+
+const (
+	insertWebhookSubscriptionQuery = `
+		INSERT INTO webhook_subscriptions (url, secret, events)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at;
+	`
+	selectWebhookSubscriptionByIDQuery = `
+		SELECT id, url, secret, events, created_at
+		FROM webhook_subscriptions
+		WHERE id = $1;
+	`
+	selectWebhookSubscriptionsQuery = `
+		SELECT id, url, secret, events, created_at
+		FROM webhook_subscriptions
+		ORDER BY id;
+	`
+	selectWebhookSubscriptionsByEventQuery = `
+		SELECT id, url, secret, events, created_at
+		FROM webhook_subscriptions
+		WHERE cardinality(events) = 0 OR $1 = ANY(events)
+		ORDER BY id;
+	`
+	updateWebhookSubscriptionSecretQuery = `
+		UPDATE webhook_subscriptions SET secret = $2 WHERE id = $1;
+	`
+	deleteWebhookSubscriptionQuery = `
+		DELETE FROM webhook_subscriptions WHERE id = $1;
+	`
+)
+
+func (r *WebhookSubscriptionRepository) Create(ctx context.Context, subscription *models.WebhookSubscription) error {
+	if subscription.Events == nil {
+		subscription.Events = []string{}
+	}
+
+	if err := conn(ctx, r.db).QueryRow(ctx, insertWebhookSubscriptionQuery, subscription.URL, subscription.Secret,
+		subscription.Events).Scan(&subscription.ID, &subscription.CreatedAt); err != nil {
+		return fmt.Errorf("create webhook subscription: %w", err)
+	}
+
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) FindByID(ctx context.Context, id int64) (*models.WebhookSubscription, error) {
+	var s models.WebhookSubscription
+	err := conn(ctx, r.db).QueryRow(ctx, selectWebhookSubscriptionByIDQuery, id).
+		Scan(&s.ID, &s.URL, &s.Secret, &s.Events, &s.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrWebhookSubscriptionNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find webhook subscription %d: %w", id, err)
+	}
+
+	return &s, nil
+}
+
+func (r *WebhookSubscriptionRepository) FindAll(ctx context.Context) ([]*models.WebhookSubscription, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectWebhookSubscriptionsQuery)
+	if err != nil {
+		return nil, fmt.Errorf("find webhook subscriptions: %w", err)
+	}
+
+	return scanWebhookSubscriptions(rows)
+}
+
+// FindByEvent returns the subscriptions that receive eventType: those listing
+// it and those with no event filter.
+func (r *WebhookSubscriptionRepository) FindByEvent(ctx context.Context,
+	eventType string) ([]*models.WebhookSubscription, error) {
+	rows, err := conn(ctx, r.db).Query(ctx, selectWebhookSubscriptionsByEventQuery, eventType)
+	if err != nil {
+		return nil, fmt.Errorf("find webhook subscriptions for %s: %w", eventType, err)
+	}
+
+	return scanWebhookSubscriptions(rows)
+}
+
+func (r *WebhookSubscriptionRepository) UpdateSecret(ctx context.Context, id int64, secret string) error {
+	cmd, err := conn(ctx, r.db).Exec(ctx, updateWebhookSubscriptionSecretQuery, id, secret)
+	if err != nil {
+		return fmt.Errorf("update webhook subscription %d secret: %w", id, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func (r *WebhookSubscriptionRepository) DeleteByID(ctx context.Context, id int64) error {
+	cmd, err := conn(ctx, r.db).Exec(ctx, deleteWebhookSubscriptionQuery, id)
+	if err != nil {
+		return fmt.Errorf("delete webhook subscription %d: %w", id, err)
+	}
+
+	if cmd.RowsAffected() == 0 {
+		return ErrWebhookSubscriptionNotFound
+	}
+
+	return nil
+}
+
+func scanWebhookSubscriptions(rows pgx.Rows) ([]*models.WebhookSubscription, error) {
+	defer rows.Close()
+
+	list := make([]*models.WebhookSubscription, 0)
+	for rows.Next() {
+		var s models.WebhookSubscription
+		if err := rows.Scan(&s.ID, &s.URL, &s.Secret, &s.Events, &s.CreatedAt); err != nil {
+			return nil, fmt.Errorf("scan webhook subscription: %w", err)
+		}
+		list = append(list, &s)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("iterating over webhook subscription rows: %w", err)
+	}
+
+	return list, nil
+}
@@ -0,0 +1,243 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/chat"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/remote"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"strings"
+	"time"
+)
+
+// ChatPoster posts a message to a chat incoming webhook.
+type ChatPoster interface {
+	Post(ctx context.Context, webhookURL string, msg *chat.Message) error
+}
+
+// chatFormats are the chats a team can be notified in, by provider.
+var chatFormats = map[string]chat.Format{
+	models.ProviderSlack:      chat.Slack,
+	models.ProviderMattermost: chat.Mattermost,
+}
+
+const (
+	chatUsername            = "pullrequest-inator"
+	chatNotificationTimeout = time.Minute
+)
+
+// ChatNotifier is the EventSink telling reviewers in their team's chat
+// channel that they were assigned, were reassigned off a pull request, that
+// their review is overdue or still awaited, or that a pull request they
+// review was merged. Reviewers are mentioned by their chat identity; those
+// without one or who opted out are named without a mention, and nothing is
+// posted when nobody would be mentioned.
+//
+// A message the chat fails to take for a temporary reason fails the event, so
+// the outbox hands it over again; one the chat rejects for good is dropped.
+type ChatNotifier struct {
+	prRepo       repositories.PullRequest
+	userRepo     repositories.User
+	identityRepo repositories.UserIdentity
+	channelRepo  repositories.TeamChatChannel
+	prefsRepo    repositories.NotificationPreferences
+	poster       ChatPoster
+}
+
+func NewChatNotifier(prRepo repositories.PullRequest, userRepo repositories.User,
+	identityRepo repositories.UserIdentity, channelRepo repositories.TeamChatChannel,
+	prefsRepo repositories.NotificationPreferences, poster ChatPoster) (*ChatNotifier, error) {
+	if prRepo == nil {
+		return nil, errors.New("prRepository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+	if identityRepo == nil {
+		return nil, errors.New("userIdentityRepository cannot be nil")
+	}
+	if channelRepo == nil {
+		return nil, errors.New("teamChatChannelRepository cannot be nil")
+	}
+	if prefsRepo == nil {
+		return nil, errors.New("notificationPreferencesRepository cannot be nil")
+	}
+	if poster == nil {
+		return nil, errors.New("chat poster cannot be nil")
+	}
+
+	return &ChatNotifier{
+		prRepo:       prRepo,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		channelRepo:  channelRepo,
+		prefsRepo:    prefsRepo,
+		poster:       poster,
+	}, nil
+}
+
+func (n *ChatNotifier) Name() string {
+	return "chat"
+}
+
+func (n *ChatNotifier) HandleEvent(ctx context.Context, eventType string, payload []byte) error {
+	switch eventType {
+	case EventPullRequestReviewersAssigned:
+		var data dtos.PullRequestEvent
+		if err := decodeEventData(payload, &data); err != nil {
+			return err
+		}
+		return n.notify(ctx, data.PullRequest.PullRequestId, data.PullRequest.AssignedReviewers,
+			func(pr string, names []string) string {
+				return "Review requested from " + strings.Join(names, ", ") + ": " + pr
+			})
+
+	case EventPullRequestReviewerReassigned:
+		var data dtos.ReviewerReplacement
+		if err := decodeEventData(payload, &data); err != nil {
+			return err
+		}
+		return n.notify(ctx, data.PullRequestId, []string{data.NewUserId, data.OldUserId},
+			func(pr string, names []string) string {
+				return names[0] + " now reviews " + pr + " instead of " + names[1]
+			})
+
+	case EventPullRequestReviewOverdue:
+		var data dtos.OverdueReview
+		if err := decodeEventData(payload, &data); err != nil {
+			return err
+		}
+		return n.notify(ctx, data.PullRequestId, []string{data.ReviewerId},
+			func(pr string, names []string) string {
+				return fmt.Sprintf("Review of %s by %s is overdue: waiting %.1f of %.1f working hours",
+					pr, names[0], data.WorkingHours, data.SlaHours)
+			})
+
+	case EventPullRequestReviewReminder:
+		var data dtos.ReviewReminder
+		if err := decodeEventData(payload, &data); err != nil {
+			return err
+		}
+		return n.notify(ctx, data.PullRequestId, []string{data.ReviewerId},
+			func(pr string, names []string) string {
+				return fmt.Sprintf("Reminder: %s has been waiting for a review from %s for %.0f hours",
+					pr, names[0], data.WaitingHours)
+			})
+
+	case EventPullRequestMerged:
+		var data dtos.PullRequestEvent
+		if err := decodeEventData(payload, &data); err != nil {
+			return err
+		}
+		return n.notify(ctx, data.PullRequest.PullRequestId, data.PullRequest.AssignedReviewers,
+			func(pr string, names []string) string {
+				return pr + " reviewed by " + strings.Join(names, ", ") + " was merged"
+			})
+	}
+	return nil
+}
+
+// notify posts the text compose makes of the pull request and the names of
+// users, in the same order, to the channel of the pull request's team.
+func (n *ChatNotifier) notify(ctx context.Context, prID string, users []string,
+	compose func(pr string, names []string) string) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	pr, err := n.prRepo.FindByID(ctx, encoding.DecodeID(prID))
+	if errors.Is(err, pg.ErrPullRequestNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("find PR: %w", err)
+	}
+	if pr.TeamID == nil {
+		return nil
+	}
+
+	channel, err := n.channelRepo.FindByTeamID(ctx, *pr.TeamID)
+	if errors.Is(err, pg.ErrTeamChatChannelNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("find chat channel: %w", err)
+	}
+	format, ok := chatFormats[channel.Provider]
+	if !ok {
+		return nil
+	}
+
+	userIDs := make([]int64, len(users))
+	for i, id := range users {
+		userIDs[i] = encoding.DecodeID(id)
+	}
+	names, mentioned, err := n.names(ctx, channel.Provider, format, userIDs)
+	if err != nil {
+		return err
+	}
+	if !mentioned {
+		return nil
+	}
+
+	title := format.Bold(format.Escape(pr.Title)) + " (" + format.Escape(prID) + ")"
+	ctx, cancel := context.WithTimeout(ctx, chatNotificationTimeout)
+	defer cancel()
+	err = n.poster.Post(ctx, channel.WebhookURL, &chat.Message{
+		Channel:  channel.Channel,
+		Text:     compose(title, names),
+		Username: chatUsername,
+	})
+	if err != nil && remote.Temporary(err) {
+		return fmt.Errorf("post to chat: %w", err)
+	}
+	return nil
+}
+
+// names renders the users for the chat of provider: a mention for those with
+// a chat identity who did not opt out, the plain username for the rest. It
+// reports whether anyone is mentioned.
+func (n *ChatNotifier) names(ctx context.Context, provider string, format chat.Format,
+	userIDs []int64) ([]string, bool, error) {
+	prefs, err := n.prefsRepo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, false, fmt.Errorf("find notification preferences: %w", err)
+	}
+	optedOut := make(map[int64]bool, len(prefs))
+	for _, p := range prefs {
+		optedOut[p.UserID] = !p.ChatEnabled
+	}
+
+	identities, err := n.identityRepo.FindByUserIDs(ctx, provider, userIDs)
+	if err != nil {
+		return nil, false, fmt.Errorf("find identities: %w", err)
+	}
+	chatIDs := make(map[int64]string, len(identities))
+	for _, identity := range identities {
+		chatIDs[identity.UserID] = identity.ExternalID
+	}
+
+	users, err := n.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, false, fmt.Errorf("find users: %w", err)
+	}
+	usernames := make(map[int64]string, len(users))
+	for _, u := range users {
+		usernames[u.ID] = u.Username
+	}
+
+	names := make([]string, len(userIDs))
+	mentioned := false
+	for i, id := range userIDs {
+		if chatID, ok := chatIDs[id]; ok && !optedOut[id] {
+			names[i] = format.Mention(chatID)
+			mentioned = true
+		} else {
+			names[i] = format.Escape(usernames[id])
+		}
+	}
+	return names, mentioned, nil
+}
@@ -0,0 +1,370 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/email"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/remote"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"time"
+)
+
+var ErrEmailNotConfigured = errors.New("email delivery is not configured")
+
+// EmailSender delivers an email.
+type EmailSender interface {
+	Send(ctx context.Context, msg *email.Message) error
+}
+
+type DigestConfig struct {
+	// At is the time of day the digests go out, as an offset from midnight
+	// in Location.
+	At       time.Duration
+	Location *time.Location
+}
+
+var DefaultDigestConfig = DigestConfig{At: 9 * time.Hour, Location: time.UTC}
+
+const (
+	emailNotificationTimeout = time.Minute
+	// digestRetryDelay is how long a digest run that failed for some
+	// reviewers waits before it tries them again.
+	digestRetryDelay = 15 * time.Minute
+)
+
+// EmailNotifier is the EventSink emailing reviewers when they are assigned to
+// a pull request or reassigned off it, and the sender of the daily digest of
+// the reviews each reviewer still owes. Only users with an email identity who
+// did not opt out get emails.
+//
+// Event emails are sent like chat notifications: an email failing for a
+// temporary reason fails the event, so the outbox hands it over again, and
+// one the server rejects for good is dropped. Digests are recorded
+// per reviewer and day, so a reviewer gets at most one a day however many
+// instances are running.
+type EmailNotifier struct {
+	prRepo       repositories.PullRequest
+	userRepo     repositories.User
+	identityRepo repositories.UserIdentity
+	prefsRepo    repositories.NotificationPreferences
+	digestRepo   repositories.EmailDigest
+	sender       EmailSender
+	digest       DigestConfig
+}
+
+func NewEmailNotifier(prRepo repositories.PullRequest, userRepo repositories.User,
+	identityRepo repositories.UserIdentity, prefsRepo repositories.NotificationPreferences,
+	digestRepo repositories.EmailDigest, sender EmailSender,
+	digest DigestConfig) (*EmailNotifier, error) {
+	if prRepo == nil {
+		return nil, errors.New("prRepository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+	if identityRepo == nil {
+		return nil, errors.New("userIdentityRepository cannot be nil")
+	}
+	if prefsRepo == nil {
+		return nil, errors.New("notificationPreferencesRepository cannot be nil")
+	}
+	if digestRepo == nil {
+		return nil, errors.New("emailDigestRepository cannot be nil")
+	}
+	if sender == nil {
+		return nil, errors.New("email sender cannot be nil")
+	}
+	if digest.At < 0 || digest.At >= 24*time.Hour {
+		return nil, errors.New("digest time must be within a day")
+	}
+	if digest.Location == nil {
+		digest.Location = time.UTC
+	}
+
+	return &EmailNotifier{
+		prRepo:       prRepo,
+		userRepo:     userRepo,
+		identityRepo: identityRepo,
+		prefsRepo:    prefsRepo,
+		digestRepo:   digestRepo,
+		sender:       sender,
+		digest:       digest,
+	}, nil
+}
+
+func (n *EmailNotifier) Name() string {
+	return "email"
+}
+
+func (n *EmailNotifier) HandleEvent(ctx context.Context, eventType string, payload []byte) error {
+	switch eventType {
+	case EventPullRequestReviewersAssigned:
+		var data dtos.PullRequestEvent
+		if err := decodeEventData(payload, &data); err != nil {
+			return err
+		}
+		reviewers := make([]int64, len(data.PullRequest.AssignedReviewers))
+		for i, id := range data.PullRequest.AssignedReviewers {
+			reviewers[i] = encoding.DecodeID(id)
+		}
+		return n.notify(ctx, data.PullRequest.PullRequestId, reviewers,
+			func(pr email.PullRequest, reviewer int64, names map[int64]string) (string, any) {
+				return email.TemplateAssigned, &email.Assignment{Reviewer: names[reviewer], PullRequest: pr}
+			})
+
+	case EventPullRequestReviewerReassigned:
+		var data dtos.ReviewerReplacement
+		if err := decodeEventData(payload, &data); err != nil {
+			return err
+		}
+		oldID, newID := encoding.DecodeID(data.OldUserId), encoding.DecodeID(data.NewUserId)
+		return n.notify(ctx, data.PullRequestId, []int64{newID, oldID},
+			func(pr email.PullRequest, reviewer int64, names map[int64]string) (string, any) {
+				if reviewer == newID {
+					return email.TemplateAssigned, &email.Assignment{
+						Reviewer: names[newID], PullRequest: pr, Replaces: names[oldID],
+					}
+				}
+				return email.TemplateUnassigned, &email.Unassignment{
+					Reviewer: names[oldID], PullRequest: pr, ReplacedBy: names[newID],
+				}
+			})
+	}
+	return nil
+}
+
+// notify emails each of the users who accepts emails the template and data
+// compose makes for them. names has the usernames of the users and the
+// author. It fails when an email failed for a temporary reason, after trying
+// the rest.
+func (n *EmailNotifier) notify(ctx context.Context, prID string, users []int64,
+	compose func(pr email.PullRequest, user int64, names map[int64]string) (string, any)) error {
+	if len(users) == 0 {
+		return nil
+	}
+
+	addresses, err := n.addresses(ctx, users)
+	if err != nil {
+		return err
+	}
+	if len(addresses) == 0 {
+		return nil
+	}
+
+	pr, err := n.prRepo.FindByID(ctx, encoding.DecodeID(prID))
+	if errors.Is(err, pg.ErrPullRequestNotFound) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("find PR: %w", err)
+	}
+
+	names, err := n.usernames(ctx, append([]int64{pr.AuthorID}, users...))
+	if err != nil {
+		return err
+	}
+	data := email.PullRequest{
+		ID:       prID,
+		Title:    pr.Title,
+		Author:   names[pr.AuthorID],
+		OpenedAt: pr.CreatedAt,
+		Age:      time.Since(pr.CreatedAt),
+	}
+
+	var errs []error
+	for _, id := range users {
+		address, ok := addresses[id]
+		if !ok {
+			continue
+		}
+		name, body := compose(data, id, names)
+		msg, err := email.Render(name, address, body)
+		if err != nil {
+			return err
+		}
+		if err := n.send(ctx, msg); err != nil && remote.Temporary(err) {
+			errs = append(errs, fmt.Errorf("email user %d: %w", id, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// SendDigests emails today's digest to the given reviewers, or to every
+// reviewer if reviewerIDs is nil, and returns how many were sent. Reviewers
+// without pending reviews and those who already got today's digest are
+// skipped.
+func (n *EmailNotifier) SendDigests(ctx context.Context, reviewerIDs []int64) (int, error) {
+	now := time.Now().In(n.digest.Location)
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	pending, err := n.prRepo.FindPendingReviews(ctx, reviewerIDs)
+	if err != nil {
+		return 0, fmt.Errorf("find pending reviews: %w", err)
+	}
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	byReviewer := make(map[int64][]*models.PendingReview)
+	var reviewers, users []int64
+	for _, p := range pending {
+		if _, ok := byReviewer[p.ReviewerID]; !ok {
+			reviewers = append(reviewers, p.ReviewerID)
+		}
+		byReviewer[p.ReviewerID] = append(byReviewer[p.ReviewerID], p)
+		users = append(users, p.AuthorID)
+	}
+
+	addresses, err := n.addresses(ctx, reviewers)
+	if err != nil {
+		return 0, err
+	}
+	names, err := n.usernames(ctx, append(users, reviewers...))
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	var errs []error
+	for _, reviewer := range reviewers {
+		address, ok := addresses[reviewer]
+		if !ok {
+			continue
+		}
+
+		digest := &email.Digest{Reviewer: names[reviewer], Date: day}
+		for _, p := range byReviewer[reviewer] {
+			digest.PullRequests = append(digest.PullRequests, email.PullRequest{
+				ID:       encoding.EncodeID(p.PullRequestID),
+				Title:    p.Title,
+				Author:   names[p.AuthorID],
+				OpenedAt: p.CreatedAt,
+				Age:      now.Sub(p.CreatedAt),
+			})
+		}
+		msg, err := email.Render(email.TemplateDigest, address, digest)
+		if err != nil {
+			return sent, err
+		}
+
+		claimed, err := n.digestRepo.Claim(ctx, reviewer, day)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		if err := n.send(ctx, msg); err != nil {
+			if errors.Is(err, email.ErrNotConfigured) {
+				_ = n.digestRepo.Release(ctx, reviewer, day)
+				return sent, ErrEmailNotConfigured
+			}
+			errs = append(errs, fmt.Errorf("send digest to user %d: %w", reviewer, err))
+			if err := n.digestRepo.Release(ctx, reviewer, day); err != nil {
+				errs = append(errs, err)
+			}
+			continue
+		}
+		sent++
+	}
+	return sent, errors.Join(errs...)
+}
+
+// SendDigest emails today's digest to the reviewer like SendDigests.
+func (n *EmailNotifier) SendDigest(ctx context.Context, reviewerID int64) (int, error) {
+	if _, err := n.userRepo.FindByID(ctx, reviewerID); errors.Is(err, pg.ErrUserNotFound) {
+		return 0, ErrNotFound
+	} else if err != nil {
+		return 0, fmt.Errorf("find user: %w", err)
+	}
+	return n.SendDigests(ctx, []int64{reviewerID})
+}
+
+// RunDigests sends the digests every day at the configured time until ctx is
+// done. If today's time has already passed on start, the digests are sent
+// right away, so a restart does not skip a day. A run that failed for some
+// reviewers is repeated a little later; the ones already sent are not sent
+// again.
+func (n *EmailNotifier) RunDigests(ctx context.Context) {
+	now := time.Now().In(n.digest.Location)
+	wait := time.Until(n.nextDigest(now))
+	if today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, n.digest.Location).
+		Add(n.digest.At); !today.After(now) {
+		wait = 0
+	}
+
+	for {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		wait = time.Until(n.nextDigest(time.Now()))
+		if _, err := n.SendDigests(ctx, nil); err != nil && ctx.Err() == nil {
+			wait = min(wait, digestRetryDelay)
+		}
+	}
+}
+
+// nextDigest returns the first digest time after now.
+func (n *EmailNotifier) nextDigest(now time.Time) time.Time {
+	now = now.In(n.digest.Location)
+	next := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, n.digest.Location).Add(n.digest.At)
+	if !next.After(now) {
+		next = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, n.digest.Location).Add(n.digest.At)
+	}
+	return next
+}
+
+// addresses returns the email addresses of the users who have one and did
+// not opt out of emails.
+func (n *EmailNotifier) addresses(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	identities, err := n.identityRepo.FindByUserIDs(ctx, models.ProviderEmail, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find identities: %w", err)
+	}
+	addresses := make(map[int64]string, len(identities))
+	for _, identity := range identities {
+		addresses[identity.UserID] = identity.ExternalID
+	}
+	if len(addresses) == 0 {
+		return addresses, nil
+	}
+
+	prefs, err := n.prefsRepo.FindByUserIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find notification preferences: %w", err)
+	}
+	for _, p := range prefs {
+		if !p.EmailEnabled {
+			delete(addresses, p.UserID)
+		}
+	}
+	return addresses, nil
+}
+
+func (n *EmailNotifier) usernames(ctx context.Context, userIDs []int64) (map[int64]string, error) {
+	users, err := n.userRepo.FindByIDs(ctx, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find users: %w", err)
+	}
+	names := make(map[int64]string, len(users))
+	for _, u := range users {
+		names[u.ID] = u.Username
+	}
+	return names, nil
+}
+
+func (n *EmailNotifier) send(ctx context.Context, msg *email.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, emailNotificationTimeout)
+	defer cancel()
+	return n.sender.Send(ctx, msg)
+}
@@ -0,0 +1,88 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"time"
+)
+
+const (
+	EventPullRequestCreated            = "pull_request.created"
+	EventPullRequestReady              = "pull_request.ready"
+	EventPullRequestConvertedToDraft   = "pull_request.converted_to_draft"
+	EventPullRequestRenamed            = "pull_request.renamed"
+	EventPullRequestReviewersAssigned  = "pull_request.reviewers_assigned"
+	EventPullRequestReviewerReassigned = "pull_request.reviewer_reassigned"
+	EventPullRequestMerged             = "pull_request.merged"
+	EventPullRequestClosed             = "pull_request.closed"
+	EventPullRequestReopened           = "pull_request.reopened"
+	EventPullRequestReviewOverdue      = "pull_request.review_overdue"
+	EventPullRequestReviewReminder     = "pull_request.review_reminder"
+	EventTeamCreated                   = "team.created"
+	EventTeamSettingsUpdated           = "team.settings_updated"
+	EventTeamMembersDeactivated        = "team.members_deactivated"
+)
+
+// transitionEvents names the event published after each status transition.
+var transitionEvents = map[Transition]string{
+	TransitionReady:  EventPullRequestReady,
+	TransitionDraft:  EventPullRequestConvertedToDraft,
+	TransitionMerge:  EventPullRequestMerged,
+	TransitionClose:  EventPullRequestClosed,
+	TransitionReopen: EventPullRequestReopened,
+}
+
+// EventPublisher is told about pull request and team changes. It is called
+// inside the transaction making the change and must take part in it, so an
+// error rolls the change back.
+type EventPublisher interface {
+	Publish(ctx context.Context, event *dtos.Event) error
+}
+
+func IsKnownEvent(eventType string) bool {
+	switch eventType {
+	case EventPullRequestCreated, EventPullRequestReady, EventPullRequestConvertedToDraft, EventPullRequestRenamed,
+		EventPullRequestReviewersAssigned, EventPullRequestReviewerReassigned, EventPullRequestMerged,
+		EventPullRequestClosed, EventPullRequestReopened, EventPullRequestReviewOverdue,
+		EventPullRequestReviewReminder, EventTeamCreated, EventTeamSettingsUpdated, EventTeamMembersDeactivated:
+		return true
+	}
+	return false
+}
+
+func newEvent(eventType string, data any) *dtos.Event {
+	return &dtos.Event{
+		Type:       eventType,
+		OccurredAt: time.Now().UTC(),
+		Data:       data,
+	}
+}
+
+func publishAll(ctx context.Context, publisher EventPublisher, events ...*dtos.Event) error {
+	for _, event := range events {
+		if err := publisher.Publish(ctx, event); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func pullRequestEvent(eventType string, pr *dtos.PullRequest) *dtos.Event {
+	return newEvent(eventType, dtos.PullRequestEvent{PullRequest: *pr})
+}
+
+// decodeEventData decodes the data of a stored event into v.
+func decodeEventData(payload []byte, v any) error {
+	var event struct {
+		Data json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(payload, &event); err != nil {
+		return fmt.Errorf("decode event: %w", err)
+	}
+	if err := json.Unmarshal(event.Data, v); err != nil {
+		return fmt.Errorf("decode event data: %w", err)
+	}
+	return nil
+}
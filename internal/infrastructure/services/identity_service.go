@@ -0,0 +1,249 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"strings"
+)
+
+var (
+	ErrInvalidIdentity  = errors.New("invalid external identity")
+	ErrIdentityTaken    = errors.New("external identity belongs to another user")
+	ErrIdentityNotFound = errors.New("no user with this external identity")
+)
+
+// IdentityResolver translates between users and their accounts on outside
+// systems.
+type IdentityResolver interface {
+	ResolveUser(ctx context.Context, provider, externalID string) (int64, error)
+	Logins(ctx context.Context, provider string, userIDs []int64) ([]string, error)
+}
+
+type IdentityService struct {
+	identityRepo   repositories.UserIdentity
+	userRepo       repositories.User
+	gitlabUsers    map[string]int64
+	gitlabLogins   map[int64]string
+	matchUsernames bool
+}
+
+// NewIdentityService creates an IdentityService. gitlabUsers maps GitLab
+// usernames to user IDs for deployments that configure them statically;
+// stored identities take precedence. With matchUsernames, accounts with
+// neither are taken for the user with the same username, which is only safe
+// when usernames mirror the provider's logins.
+func NewIdentityService(identityRepo repositories.UserIdentity, userRepo repositories.User,
+	gitlabUsers map[string]string, matchUsernames bool) (*IdentityService, error) {
+	if identityRepo == nil {
+		return nil, errors.New("userIdentityRepository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+
+	s := &IdentityService{
+		identityRepo:   identityRepo,
+		userRepo:       userRepo,
+		gitlabUsers:    make(map[string]int64, len(gitlabUsers)),
+		gitlabLogins:   make(map[int64]string, len(gitlabUsers)),
+		matchUsernames: matchUsernames,
+	}
+	for login, userID := range gitlabUsers {
+		id := encoding.DecodeID(userID)
+		s.gitlabUsers[normalizeExternalID(models.ProviderGitLab, login)] = id
+		s.gitlabLogins[id] = login
+	}
+	return s, nil
+}
+
+// SetIdentity sets the user's identity for the provider, replacing the one
+// they had.
+func (s *IdentityService) SetIdentity(ctx context.Context, userID int64, provider,
+	externalID string) (*dtos.UserIdentity, error) {
+	externalID, err := validateIdentity(provider, externalID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	identity := &models.UserIdentity{UserID: userID, Provider: provider, ExternalID: externalID}
+	err = s.identityRepo.Upsert(ctx, identity)
+	if errors.Is(err, pg.ErrUserIdentityTaken) {
+		return nil, fmt.Errorf("%w: %s %q", ErrIdentityTaken, provider, externalID)
+	} else if err != nil {
+		return nil, fmt.Errorf("set identity: %w", err)
+	}
+
+	return toUserIdentityDTO(identity), nil
+}
+
+func (s *IdentityService) ListIdentities(ctx context.Context, userID int64) ([]*dtos.UserIdentity, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	identities, err := s.identityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find identities: %w", err)
+	}
+
+	out := make([]*dtos.UserIdentity, len(identities))
+	for i, identity := range identities {
+		out[i] = toUserIdentityDTO(identity)
+	}
+	return out, nil
+}
+
+func (s *IdentityService) DeleteIdentity(ctx context.Context, userID int64, provider string) error {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return err
+	}
+
+	err := s.identityRepo.Delete(ctx, userID, provider)
+	if errors.Is(err, pg.ErrUserIdentityNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete identity: %w", err)
+	}
+	return nil
+}
+
+func (s *IdentityService) LookupIdentity(ctx context.Context, provider,
+	externalID string) (*dtos.UserIdentity, error) {
+	identity, err := s.identityRepo.FindByExternalID(ctx, provider, normalizeExternalID(provider, externalID))
+	if errors.Is(err, pg.ErrUserIdentityNotFound) {
+		return nil, fmt.Errorf("%w: %s %q", ErrIdentityNotFound, provider, externalID)
+	} else if err != nil {
+		return nil, fmt.Errorf("find identity: %w", err)
+	}
+	return toUserIdentityDTO(identity), nil
+}
+
+// ResolveUser finds the user behind an account on provider: by stored
+// identity, then for GitLab by the static mapping, then, if usernames are
+// matched, by a username equal to the account name.
+func (s *IdentityService) ResolveUser(ctx context.Context, provider, externalID string) (int64, error) {
+	normalized := normalizeExternalID(provider, externalID)
+	identity, err := s.identityRepo.FindByExternalID(ctx, provider, normalized)
+	if err == nil {
+		return identity.UserID, nil
+	} else if !errors.Is(err, pg.ErrUserIdentityNotFound) {
+		return 0, fmt.Errorf("find identity: %w", err)
+	}
+
+	if id, ok := s.gitlabUsers[normalized]; ok && provider == models.ProviderGitLab {
+		return id, nil
+	}
+	if !s.matchUsernames {
+		return 0, fmt.Errorf("%w: %s %q", ErrIdentityNotFound, provider, externalID)
+	}
+
+	user, err := s.userRepo.FindByUsername(ctx, externalID)
+	if errors.Is(err, pg.ErrUserNotFound) {
+		return 0, fmt.Errorf("%w: %s %q", ErrIdentityNotFound, provider, externalID)
+	} else if err != nil {
+		return 0, fmt.Errorf("find user by username: %w", err)
+	}
+	return user.ID, nil
+}
+
+// Logins returns the provider account names of the given users in the same
+// order, resolved the same way as ResolveUser. Users with none are skipped.
+func (s *IdentityService) Logins(ctx context.Context, provider string, userIDs []int64) ([]string, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	identities, err := s.identityRepo.FindByUserIDs(ctx, provider, userIDs)
+	if err != nil {
+		return nil, fmt.Errorf("find identities: %w", err)
+	}
+	stored := make(map[int64]string, len(identities))
+	for _, identity := range identities {
+		stored[identity.UserID] = identity.ExternalID
+	}
+
+	usernames := make(map[int64]string)
+	if s.matchUsernames {
+		users, err := s.userRepo.FindByIDs(ctx, userIDs)
+		if err != nil {
+			return nil, fmt.Errorf("find users: %w", err)
+		}
+		for _, u := range users {
+			usernames[u.ID] = u.Username
+		}
+	}
+
+	logins := make([]string, 0, len(userIDs))
+	for _, id := range userIDs {
+		if login, ok := stored[id]; ok {
+			logins = append(logins, login)
+		} else if login, ok := s.gitlabLogins[id]; ok && provider == models.ProviderGitLab {
+			logins = append(logins, login)
+		} else if username, ok := usernames[id]; ok {
+			logins = append(logins, username)
+		}
+	}
+	return logins, nil
+}
+
+func (s *IdentityService) ensureUser(ctx context.Context, userID int64) error {
+	_, err := s.userRepo.FindByID(ctx, userID)
+	if errors.Is(err, pg.ErrUserNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("find user: %w", err)
+	}
+	return nil
+}
+
+func validateIdentity(provider, externalID string) (string, error) {
+	switch provider {
+	case models.ProviderGitHub, models.ProviderGitLab, models.ProviderSlack, models.ProviderMattermost,
+		models.ProviderEmail:
+	default:
+		return "", fmt.Errorf("%w: unknown provider %q", ErrInvalidIdentity, provider)
+	}
+
+	externalID = normalizeExternalID(provider, externalID)
+	if externalID == "" {
+		return "", fmt.Errorf("%w: external_id is required", ErrInvalidIdentity)
+	}
+	if provider == models.ProviderEmail {
+		if addr, err := mail.ParseAddress(externalID); err != nil || addr.Address != externalID {
+			return "", fmt.Errorf("%w: %q is not an email address", ErrInvalidIdentity, externalID)
+		}
+	}
+	return externalID, nil
+}
+
+// normalizeExternalID trims the ID and lowercases it for providers that
+// compare names case-insensitively. Chat user IDs are kept as they are.
+func normalizeExternalID(provider, externalID string) string {
+	externalID = strings.TrimSpace(externalID)
+	switch provider {
+	case models.ProviderGitHub, models.ProviderGitLab, models.ProviderEmail:
+		return strings.ToLower(externalID)
+	}
+	return externalID
+}
+
+func toUserIdentityDTO(i *models.UserIdentity) *dtos.UserIdentity {
+	return &dtos.UserIdentity{
+		UserId:     encoding.EncodeID(i.UserID),
+		Provider:   i.Provider,
+		ExternalId: i.ExternalID,
+		CreatedAt:  i.CreatedAt,
+	}
+}
@@ -9,8 +9,12 @@ type PullRequest interface {
 	CreatePullRequest(ctx context.Context, pr *dtos.PullRequest) (*dtos.PullRequest, error)
 	ReassignReviewer(ctx context.Context, userID int64, prID int64) (*dtos.ReassignReviewerResponse, error)
 	FindPullRequestsByReviewer(ctx context.Context, userID int64) ([]*dtos.PullRequest, error)
-	MarkAsMerged(ctx context.Context, prID int64) (*dtos.PullRequest, error)
+	MarkAsMerged(ctx context.Context, prID int64, override *dtos.MergeOverride) (*dtos.PullRequest, error)
+	GetMergeability(ctx context.Context, prID int64) (*dtos.Mergeability, error)
+	MarkReady(ctx context.Context, prID int64) (*dtos.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID int64) (*dtos.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, prID int64) (*dtos.PullRequest, error)
+	SubmitReview(ctx context.Context, prID int64, reviewerID int64, decision string) (*dtos.PullRequest, error)
 	GetUserReviews(ctx context.Context, userID int64) (*dtos.UserGetReviewResponse, error)
-	CreateWithReviewers(ctx context.Context, prID int64, prName string, authorID int64) (*dtos.PullRequest, error)
-	GetStatistics(ctx context.Context) (*dtos.StatsResponse, error)
+	CreateWithReviewers(ctx context.Context, prID int64, prName string, authorID int64, teamName string, draft bool) (*dtos.PullRequest, error)
 }
@@ -8,5 +8,7 @@ import (
 type Team interface {
 	CreateTeamWithUsers(ctx context.Context, teamReq *dtos.Team) error
 	GetTeamByName(ctx context.Context, teamName string) (*dtos.Team, error)
-	SetUserActiveByID(ctx context.Context, userID int64, active bool) (*dtos.User, error)
+	SetUserActiveByID(ctx context.Context, userID int64, active bool, reassignOpenReviews bool) (*dtos.User, *dtos.ReviewReassignment, error)
+	GetTeamSettings(ctx context.Context, teamName string) (*dtos.TeamSettings, error)
+	UpdateTeamSettings(ctx context.Context, req *dtos.TeamSettingsUpdate) (*dtos.TeamSettings, error)
 }
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"context"
+	"io"
+	"pullrequest-inator/internal/api/dtos"
+	"time"
+)
+
+type Unavailability interface {
+	AddPeriod(ctx context.Context, userID int64, startsAt, endsAt time.Time, reason string) (*dtos.Unavailability, error)
+	ListPeriods(ctx context.Context, userID int64) ([]*dtos.Unavailability, error)
+	DeletePeriod(ctx context.Context, userID int64, periodID int64) error
+	ImportCalendar(ctx context.Context, userID int64, r io.Reader) (*dtos.CalendarImportResult, error)
+}
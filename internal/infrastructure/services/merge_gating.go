@@ -0,0 +1,195 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"strings"
+	"time"
+)
+
+var (
+	ErrMergeBlocked     = errors.New("merge requirements are not met")
+	ErrForcedByRequired = errors.New("a forced merge must name the user forcing it")
+)
+
+// mergeCheck is the evaluation of a team's merge policy against the current
+// reviews of a pull request.
+type mergeCheck struct {
+	approvals          int
+	required           int
+	changesRequestedBy []int64
+	unmet              []string
+}
+
+func (s *PullRequestService) checkMergePolicy(ctx context.Context, pr *models.PullRequest) (*mergeCheck, error) {
+	check := &mergeCheck{}
+
+	team, err := s.reviewTeam(ctx, pr)
+	if err != nil && !errors.Is(err, ErrTeamNotFound) {
+		return nil, err
+	}
+	if team != nil {
+		check.required = team.RequiredApprovals
+	}
+
+	for _, r := range pr.Reviews {
+		if r.Decision == nil {
+			continue
+		}
+		switch *r.Decision {
+		case models.ReviewDecisionApproved:
+			check.approvals++
+		case models.ReviewDecisionChangesRequested:
+			check.changesRequestedBy = append(check.changesRequestedBy, r.ReviewerID)
+		}
+	}
+
+	if check.approvals < check.required {
+		check.unmet = append(check.unmet,
+			fmt.Sprintf("%d of %d required approvals", check.approvals, check.required))
+	}
+	for _, id := range check.changesRequestedBy {
+		check.unmet = append(check.unmet, fmt.Sprintf("changes requested by %s", encoding.EncodeID(id)))
+	}
+
+	return check, nil
+}
+
+// GetMergeability reports whether the pull request could be merged now
+// without forcing, and lists the earlier forced merges of it.
+func (s *PullRequestService) GetMergeability(ctx context.Context, prID int64) (*dtos.Mergeability, error) {
+	pr, err := s.prRepo.FindByID(ctx, prID)
+	if errors.Is(err, pg.ErrPullRequestNotFound) {
+		return nil, ErrPRNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("find PR: %w", err)
+	}
+
+	status, err := s.statusName(ctx, pr.StatusID)
+	if err != nil {
+		return nil, err
+	}
+	check, err := s.checkMergePolicy(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+
+	unmet := check.unmet
+	if _, err := nextStatus(status, TransitionMerge); err != nil {
+		unmet = append([]string{fmt.Sprintf("pull request is %s", status)}, unmet...)
+	}
+
+	overrides, err := s.overrideRepo.FindByPullRequestID(ctx, prID)
+	if err != nil {
+		return nil, fmt.Errorf("find merge overrides: %w", err)
+	}
+
+	report := &dtos.Mergeability{
+		PullRequestId:      encoding.EncodeID(pr.ID),
+		Status:             dtos.PullRequestStatus(status),
+		Mergeable:          len(unmet) == 0,
+		Approvals:          check.approvals,
+		RequiredApprovals:  check.required,
+		ChangesRequestedBy: make([]string, len(check.changesRequestedBy)),
+		UnmetConditions:    append([]string{}, unmet...),
+		Overrides:          make([]dtos.MergeOverride, len(overrides)),
+	}
+	for i, id := range check.changesRequestedBy {
+		report.ChangesRequestedBy[i] = encoding.EncodeID(id)
+	}
+	for i, o := range overrides {
+		report.Overrides[i] = dtos.MergeOverride{
+			CreatedAt:       o.CreatedAt,
+			Reason:          o.Reason,
+			UnmetConditions: o.UnmetConditions,
+		}
+		if o.ForcedBy != nil {
+			report.Overrides[i].ForcedBy = encoding.EncodeID(*o.ForcedBy)
+		}
+	}
+
+	return report, nil
+}
+
+// MarkAsMerged merges an OPEN pull request once the team's merge policy is
+// met. A non-nil override forces the merge past unmet conditions and must
+// name the user forcing it; it is recorded along with the conditions, and
+// only if some were unmet. Merging a MERGED pull request is a no-op.
+func (s *PullRequestService) MarkAsMerged(ctx context.Context, prID int64,
+	override *dtos.MergeOverride) (*dtos.PullRequest, error) {
+	if override != nil && override.ForcedBy == "" {
+		return nil, ErrForcedByRequired
+	}
+	return s.merge(ctx, prID, override)
+}
+
+// RecordExternalMerge merges a pull request that was already merged on its
+// provider, so unmet conditions cannot block it. They are recorded as an
+// override with reason, forced by mergedBy when it is known.
+func (s *PullRequestService) RecordExternalMerge(ctx context.Context, prID int64, reason string,
+	mergedBy *int64) (*dtos.PullRequest, error) {
+	override := &dtos.MergeOverride{Reason: reason}
+	if mergedBy != nil {
+		override.ForcedBy = encoding.EncodeID(*mergedBy)
+	}
+	return s.merge(ctx, prID, override)
+}
+
+// merge runs the merge transition and evaluates the merge policy inside it,
+// on the locked pull request, so no review can change the outcome between the
+// check and the merge.
+func (s *PullRequestService) merge(ctx context.Context, prID int64,
+	override *dtos.MergeOverride) (*dtos.PullRequest, error) {
+	var forcedBy *int64
+	if override != nil && override.ForcedBy != "" {
+		id := encoding.DecodeID(override.ForcedBy)
+		if _, err := s.userRepo.FindByID(ctx, id); errors.Is(err, pg.ErrUserNotFound) {
+			return nil, fmt.Errorf("%w: user %s", ErrNotFound, override.ForcedBy)
+		} else if err != nil {
+			return nil, fmt.Errorf("find user: %w", err)
+		}
+		forcedBy = &id
+	}
+
+	dto, err := s.transition(ctx, prID, TransitionMerge, func(ctx context.Context, pr *models.PullRequest) error {
+		check, err := s.checkMergePolicy(ctx, pr)
+		if err != nil {
+			return err
+		}
+		if len(check.unmet) > 0 && override == nil {
+			return fmt.Errorf("%w: %s", ErrMergeBlocked, strings.Join(check.unmet, "; "))
+		}
+
+		now := time.Now()
+		pr.MergedAt = &now
+		if len(check.unmet) == 0 {
+			return nil
+		}
+		if err := s.overrideRepo.Create(ctx, &models.MergeOverride{
+			PullRequestID:   prID,
+			ForcedBy:        forcedBy,
+			Reason:          override.Reason,
+			UnmetConditions: check.unmet,
+		}); err != nil {
+			return fmt.Errorf("record merge override: %w", err)
+		}
+		return nil
+	})
+	if !errors.Is(err, ErrInvalidTransition) {
+		return dto, err
+	}
+
+	pr, findErr := s.prRepo.FindByID(ctx, prID)
+	if findErr != nil {
+		return nil, err
+	}
+	if status, statusErr := s.statusName(ctx, pr.StatusID); statusErr == nil && status == StatusMerged {
+		return dtos.ModelToPullRequestDTO(pr, StatusMerged), nil
+	}
+	return nil, err
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"strings"
+)
+
+var ErrInvalidChatChannel = errors.New("invalid chat channel")
+
+// NotificationService manages where teams get their chat notifications and
+// which notifications users opted out of.
+type NotificationService struct {
+	teamRepo    repositories.Team
+	userRepo    repositories.User
+	channelRepo repositories.TeamChatChannel
+	prefsRepo   repositories.NotificationPreferences
+	urls        URLChecker
+}
+
+func NewNotificationService(teamRepo repositories.Team, userRepo repositories.User,
+	channelRepo repositories.TeamChatChannel, prefsRepo repositories.NotificationPreferences,
+	urls URLChecker) (*NotificationService, error) {
+	if teamRepo == nil {
+		return nil, errors.New("teamRepository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+	if channelRepo == nil {
+		return nil, errors.New("teamChatChannelRepository cannot be nil")
+	}
+	if prefsRepo == nil {
+		return nil, errors.New("notificationPreferencesRepository cannot be nil")
+	}
+	if urls == nil {
+		return nil, errors.New("url checker cannot be nil")
+	}
+
+	return &NotificationService{
+		teamRepo:    teamRepo,
+		userRepo:    userRepo,
+		channelRepo: channelRepo,
+		prefsRepo:   prefsRepo,
+		urls:        urls,
+	}, nil
+}
+
+// SetTeamChannel points the team's notifications at the incoming webhook
+// webhookURL of provider, replacing the previous one. The URL must not point
+// at an internal address.
+func (s *NotificationService) SetTeamChannel(ctx context.Context, teamName, provider, webhookURL,
+	channel string) (*dtos.TeamChatChannel, error) {
+	if _, ok := chatFormats[provider]; !ok {
+		return nil, fmt.Errorf("%w: unknown provider %q", ErrInvalidChatChannel, provider)
+	}
+	u, err := url.Parse(webhookURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return nil, fmt.Errorf("%w: webhook_url must be an absolute http(s) URL", ErrInvalidChatChannel)
+	}
+	if err := s.urls.CheckURL(ctx, webhookURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidChatChannel, err)
+	}
+
+	team, err := s.findTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &models.TeamChatChannel{
+		TeamID:     team.ID,
+		Provider:   provider,
+		WebhookURL: webhookURL,
+		Channel:    strings.TrimSpace(channel),
+	}
+	if err := s.channelRepo.Upsert(ctx, c); err != nil {
+		return nil, fmt.Errorf("set chat channel: %w", err)
+	}
+
+	return toTeamChatChannelDTO(team, c), nil
+}
+
+func (s *NotificationService) GetTeamChannel(ctx context.Context, teamName string) (*dtos.TeamChatChannel, error) {
+	team, err := s.findTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := s.channelRepo.FindByTeamID(ctx, team.ID)
+	if errors.Is(err, pg.ErrTeamChatChannelNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find chat channel: %w", err)
+	}
+
+	return toTeamChatChannelDTO(team, c), nil
+}
+
+func (s *NotificationService) DeleteTeamChannel(ctx context.Context, teamName string) error {
+	team, err := s.findTeam(ctx, teamName)
+	if err != nil {
+		return err
+	}
+
+	err = s.channelRepo.DeleteByTeamID(ctx, team.ID)
+	if errors.Is(err, pg.ErrTeamChatChannelNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete chat channel: %w", err)
+	}
+	return nil
+}
+
+func (s *NotificationService) GetPreferences(ctx context.Context, userID int64) (*dtos.NotificationPreferences, error) {
+	prefs, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return toNotificationPreferencesDTO(prefs), nil
+}
+
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID int64,
+	update *dtos.NotificationPreferencesUpdate) (*dtos.NotificationPreferences, error) {
+	prefs, err := s.preferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if update.ChatEnabled != nil {
+		prefs.ChatEnabled = *update.ChatEnabled
+	}
+	if update.EmailEnabled != nil {
+		prefs.EmailEnabled = *update.EmailEnabled
+	}
+	if err := s.prefsRepo.Upsert(ctx, prefs); err != nil {
+		return nil, fmt.Errorf("set notification preferences: %w", err)
+	}
+
+	return toNotificationPreferencesDTO(prefs), nil
+}
+
+// preferences returns the user's stored preferences, or the defaults if they
+// never changed them.
+func (s *NotificationService) preferences(ctx context.Context, userID int64) (*models.NotificationPreferences, error) {
+	if _, err := s.userRepo.FindByID(ctx, userID); errors.Is(err, pg.ErrUserNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	prefs, err := s.prefsRepo.FindByUserID(ctx, userID)
+	if errors.Is(err, pg.ErrNotificationPreferencesNotFound) {
+		return defaultNotificationPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find notification preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+func (s *NotificationService) findTeam(ctx context.Context, teamName string) (*models.Team, error) {
+	team, err := s.teamRepo.FindByName(ctx, teamName)
+	if errors.Is(err, pg.ErrTeamNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find team: %w", err)
+	}
+	return team, nil
+}
+
+func defaultNotificationPreferences(userID int64) *models.NotificationPreferences {
+	return &models.NotificationPreferences{UserID: userID, ChatEnabled: true, EmailEnabled: true}
+}
+
+func toTeamChatChannelDTO(team *models.Team, c *models.TeamChatChannel) *dtos.TeamChatChannel {
+	return &dtos.TeamChatChannel{
+		TeamName:  team.Name,
+		Provider:  c.Provider,
+		Channel:   c.Channel,
+		UpdatedAt: c.UpdatedAt,
+	}
+}
+
+func toNotificationPreferencesDTO(p *models.NotificationPreferences) *dtos.NotificationPreferences {
+	return &dtos.NotificationPreferences{
+		UserId:       encoding.EncodeID(p.UserID),
+		ChatEnabled:  p.ChatEnabled,
+		EmailEnabled: p.EmailEnabled,
+	}
+}
@@ -0,0 +1,226 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"time"
+)
+
+// EventSink receives the events taken from the outbox. A sink is handed an
+// event again later as long as it returns an error, so it should return one
+// only when a retry may help. It may also see an event twice, when the
+// dispatcher stops before recording that the sink took it.
+type EventSink interface {
+	// Name identifies the sink in the outbox; it must not change between
+	// releases while events are pending.
+	Name() string
+	HandleEvent(ctx context.Context, eventType string, payload []byte) error
+}
+
+// DeadEventSink is an EventSink told when the outbox gives up on an event the
+// sink still failed on its last attempt. cause is that last error.
+type DeadEventSink interface {
+	EventSink
+	HandleDeadEvent(ctx context.Context, eventType string, payload []byte, attempts int, cause error) error
+}
+
+// Outbox is the EventPublisher of the services. It stores events in the
+// caller's transaction, so an event exists exactly when the change it
+// describes is committed.
+type Outbox struct {
+	outboxRepo repositories.Outbox
+}
+
+func NewOutbox(outboxRepo repositories.Outbox) (*Outbox, error) {
+	if outboxRepo == nil {
+		return nil, errors.New("outboxRepository cannot be nil")
+	}
+	return &Outbox{outboxRepo: outboxRepo}, nil
+}
+
+func (o *Outbox) Publish(ctx context.Context, event *dtos.Event) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encode %s event: %w", event.Type, err)
+	}
+
+	return o.outboxRepo.Create(ctx, &models.OutboxMessage{EventType: event.Type, Payload: payload})
+}
+
+type OutboxConfig struct {
+	PollInterval time.Duration
+	BatchSize    int
+	// ClaimTimeout is how long claimed events are hidden from the other
+	// dispatchers; it must cover handing a whole batch to the sinks.
+	ClaimTimeout time.Duration
+	// RetryDelay is how long an event a sink could not take waits before the
+	// next try; it doubles with every failed try up to MaxRetryDelay.
+	RetryDelay    time.Duration
+	MaxRetryDelay time.Duration
+	// MaxAttempts is how many times an event is tried before it is given up
+	// and kept as failed.
+	MaxAttempts int
+	// Retention is how long processed events are kept; 0 keeps them.
+	Retention time.Duration
+}
+
+var DefaultOutboxConfig = OutboxConfig{
+	PollInterval:  time.Second,
+	BatchSize:     100,
+	ClaimTimeout:  5 * time.Minute,
+	RetryDelay:    5 * time.Second,
+	MaxRetryDelay: 10 * time.Minute,
+	MaxAttempts:   12,
+	Retention:     7 * 24 * time.Hour,
+}
+
+// outboxPruneInterval is how often processed events past the retention are
+// deleted.
+const outboxPruneInterval = time.Hour
+
+// OutboxDispatcher hands stored events to the sinks. Batches are claimed with
+// FOR UPDATE SKIP LOCKED and a lease, so any number of service replicas can
+// dispatch at once without handing out an event twice. The sinks run after
+// the claim has committed, and which of them took an event is recorded one by
+// one, so a retry only goes to the sinks that still need it.
+type OutboxDispatcher struct {
+	outboxRepo repositories.Outbox
+	sinks      []EventSink
+	config     OutboxConfig
+}
+
+func NewOutboxDispatcher(outboxRepo repositories.Outbox, sinks []EventSink,
+	config OutboxConfig) (*OutboxDispatcher, error) {
+	if outboxRepo == nil {
+		return nil, errors.New("outboxRepository cannot be nil")
+	}
+	if config.PollInterval <= 0 || config.BatchSize < 1 || config.RetryDelay <= 0 || config.ClaimTimeout <= 0 {
+		return nil, errors.New("outbox poll interval, batch size, claim timeout and retry delay must be positive")
+	}
+	if config.MaxAttempts < 1 {
+		return nil, errors.New("outbox needs at least one attempt")
+	}
+	names := make(map[string]bool, len(sinks))
+	for _, sink := range sinks {
+		if names[sink.Name()] {
+			return nil, fmt.Errorf("duplicate event sink %q", sink.Name())
+		}
+		names[sink.Name()] = true
+	}
+
+	return &OutboxDispatcher{
+		outboxRepo: outboxRepo,
+		sinks:      sinks,
+		config:     config,
+	}, nil
+}
+
+// Run dispatches due events every poll interval until ctx is done. A full
+// batch is followed by the next one right away. Processed events past the
+// retention are pruned every outboxPruneInterval.
+func (d *OutboxDispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(d.config.PollInterval)
+	defer ticker.Stop()
+
+	var pruned time.Time
+	for {
+		for ctx.Err() == nil {
+			n, err := d.DispatchBatch(ctx)
+			if err != nil || n < d.config.BatchSize {
+				break
+			}
+		}
+		if d.config.Retention > 0 && time.Since(pruned) >= outboxPruneInterval {
+			if _, err := d.Prune(ctx); err == nil {
+				pruned = time.Now()
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// DispatchBatch claims one batch of due events and hands each of them to the
+// sinks that have not taken it yet, returning how many it claimed.
+func (d *OutboxDispatcher) DispatchBatch(ctx context.Context) (int, error) {
+	messages, err := d.outboxRepo.ClaimPending(ctx, d.config.BatchSize, d.config.ClaimTimeout)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, message := range messages {
+		if err := d.dispatch(ctx, message); err != nil {
+			return len(messages), err
+		}
+	}
+	return len(messages), nil
+}
+
+// Prune deletes the events processed longer than the retention ago. Failed
+// events are kept.
+func (d *OutboxDispatcher) Prune(ctx context.Context) (int64, error) {
+	return d.outboxRepo.DeleteProcessedBefore(ctx, time.Now().Add(-d.config.Retention))
+}
+
+// dispatch hands message to the sinks and records the outcome. It fails only
+// when the outcome could not be recorded.
+func (d *OutboxDispatcher) dispatch(ctx context.Context, message *models.OutboxMessage) error {
+	done := make(map[string]bool, len(message.DoneSinks))
+	for _, name := range message.DoneSinks {
+		done[name] = true
+	}
+
+	var errs []error
+	failed := make(map[EventSink]error)
+	for _, sink := range d.sinks {
+		if done[sink.Name()] {
+			continue
+		}
+
+		if err := sink.HandleEvent(ctx, message.EventType, message.Payload); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", sink.Name(), err))
+			failed[sink] = err
+			continue
+		}
+		if err := d.outboxRepo.MarkSinkDone(ctx, message.ID, sink.Name()); err != nil {
+			return err
+		}
+	}
+
+	if len(errs) == 0 {
+		return d.outboxRepo.MarkProcessed(ctx, message.ID)
+	}
+	cause := errors.Join(errs...).Error()
+	if message.Attempts+1 >= d.config.MaxAttempts {
+		for sink, err := range failed {
+			dead, ok := sink.(DeadEventSink)
+			if !ok {
+				continue
+			}
+			if err := dead.HandleDeadEvent(ctx, message.EventType, message.Payload, message.Attempts+1, err); err != nil {
+				return fmt.Errorf("%s: %w", sink.Name(), err)
+			}
+		}
+		return d.outboxRepo.MarkDead(ctx, message.ID, cause)
+	}
+	return d.outboxRepo.MarkFailed(ctx, message.ID, cause, time.Now().Add(d.retryDelay(message.Attempts)))
+}
+func (d *OutboxDispatcher) retryDelay(attempts int) time.Duration {
+	delay := d.config.RetryDelay
+	for i := 0; i < attempts; i++ {
+		delay *= 2
+		if d.config.MaxRetryDelay > 0 && delay >= d.config.MaxRetryDelay {
+			return d.config.MaxRetryDelay
+		}
+	}
+	return delay
+}
@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"time"
+)
+
+const (
+	StatusDraft  = "DRAFT"
+	StatusOpen   = "OPEN"
+	StatusMerged = "MERGED"
+	StatusClosed = "CLOSED"
+)
+
+var ErrInvalidTransition = errors.New("pull request status does not allow this operation")
+
+// Transition is an action that moves a pull request between statuses.
+type Transition string
+
+const (
+	TransitionReady  Transition = "ready"
+	TransitionDraft  Transition = "draft"
+	TransitionMerge  Transition = "merge"
+	TransitionClose  Transition = "close"
+	TransitionReopen Transition = "reopen"
+)
+
+// transitions lists, for every status, the actions allowed from it and the
+// status each of them leads to. MERGED is final.
+var transitions = map[string]map[Transition]string{
+	StatusDraft: {
+		TransitionReady: StatusOpen,
+		TransitionClose: StatusClosed,
+	},
+	StatusOpen: {
+		TransitionDraft: StatusDraft,
+		TransitionMerge: StatusMerged,
+		TransitionClose: StatusClosed,
+	},
+	StatusClosed: {
+		TransitionReopen: StatusOpen,
+	},
+	StatusMerged: {},
+}
+
+// nextStatus returns the status a pull request in current ends up in after t.
+func nextStatus(current string, t Transition) (string, error) {
+	next, ok := transitions[current][t]
+	if !ok {
+		return "", fmt.Errorf("%w: cannot %s a %s pull request", ErrInvalidTransition, t, current)
+	}
+	return next, nil
+}
+
+// MarkReady takes a DRAFT pull request out of draft and assigns its reviewers,
+// unless it was back in draft and still has the ones it had.
+func (s *PullRequestService) MarkReady(ctx context.Context, prID int64) (*dtos.PullRequest, error) {
+	var fallback []dtos.FallbackReviewer
+	dto, err := s.transition(ctx, prID, TransitionReady, func(ctx context.Context, pr *models.PullRequest) error {
+		if len(pr.ReviewersIDs) > 0 {
+			return nil
+		}
+
+		team, err := s.reviewTeam(ctx, pr)
+		if err != nil {
+			return err
+		}
+		if fallback, err = s.assignReviewers(ctx, team, pr); err != nil {
+			return err
+		}
+		return s.publisher.PublishReviewers(ctx, prID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto.FallbackReviewers = fallback
+	return dto, nil
+}
+
+// MarkDraft moves an OPEN pull request back to draft. Its reviewers stay
+// assigned, but as only OPEN pull requests are reviewed, nobody is reminded of
+// them until it is ready again.
+func (s *PullRequestService) MarkDraft(ctx context.Context, prID int64) (*dtos.PullRequest, error) {
+	return s.transition(ctx, prID, TransitionDraft, func(context.Context, *models.PullRequest) error {
+		return nil
+	})
+}
+
+// ClosePullRequest closes a DRAFT or OPEN pull request without merging it.
+// Its reviewers stay assigned, so reopening it picks the review up again.
+func (s *PullRequestService) ClosePullRequest(ctx context.Context, prID int64) (*dtos.PullRequest, error) {
+	return s.transition(ctx, prID, TransitionClose, func(ctx context.Context, pr *models.PullRequest) error {
+		now := time.Now()
+		pr.ClosedAt = &now
+		return nil
+	})
+}
+
+// ReopenPullRequest moves a CLOSED pull request back to OPEN. A pull request
+// closed while still a draft has no reviewers yet, so they are assigned here.
+func (s *PullRequestService) ReopenPullRequest(ctx context.Context, prID int64) (*dtos.PullRequest, error) {
+	var fallback []dtos.FallbackReviewer
+	dto, err := s.transition(ctx, prID, TransitionReopen, func(ctx context.Context, pr *models.PullRequest) error {
+		pr.ClosedAt = nil
+		if len(pr.ReviewersIDs) > 0 {
+			return nil
+		}
+
+		team, err := s.reviewTeam(ctx, pr)
+		if err != nil {
+			return err
+		}
+		if fallback, err = s.assignReviewers(ctx, team, pr); err != nil {
+			return err
+		}
+		return s.publisher.PublishReviewers(ctx, prID, nil)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto.FallbackReviewers = fallback
+	return dto, nil
+}
+
+// transition applies t to the pull request: it locks the pull request,
+// checks the move against the state machine, lets apply adjust the pull
+// request and stores the result, all in one transaction together with the
+// transition's event, and with EventPullRequestReviewersAssigned when apply
+// assigned the first reviewers.
+func (s *PullRequestService) transition(ctx context.Context, prID int64, t Transition,
+	apply func(ctx context.Context, pr *models.PullRequest) error) (*dtos.PullRequest, error) {
+	var dto *dtos.PullRequest
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := s.prRepo.LockByID(ctx, prID)
+		if errors.Is(err, pg.ErrPullRequestNotFound) {
+			return ErrPRNotFound
+		} else if err != nil {
+			return fmt.Errorf("lock PR: %w", err)
+		}
+
+		current, err := s.statusName(ctx, pr.StatusID)
+		if err != nil {
+			return err
+		}
+		next, err := nextStatus(current, t)
+		if err != nil {
+			return err
+		}
+		st, err := s.statusByName(ctx, next)
+		if err != nil {
+			return err
+		}
+
+		hadReviewers := len(pr.ReviewersIDs) > 0
+		pr.StatusID = st.ID
+		if err := apply(ctx, pr); err != nil {
+			return err
+		}
+
+		if err := s.prRepo.Update(ctx, pr); err != nil {
+			return fmt.Errorf("update PR: %w", err)
+		}
+
+		dto = dtos.ModelToPullRequestDTO(pr, st.Name)
+		if err := s.events.Publish(ctx, pullRequestEvent(transitionEvents[t], dto)); err != nil {
+			return err
+		}
+		if !hadReviewers && len(pr.ReviewersIDs) > 0 {
+			return s.events.Publish(ctx, pullRequestEvent(EventPullRequestReviewersAssigned, dto))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto, nil
+}
+
+func (s *PullRequestService) statusName(ctx context.Context, statusID int64) (string, error) {
+	st, err := s.statusRepo.FindByID(ctx, statusID)
+	if err != nil {
+		return "", fmt.Errorf("find pull request status %d: %w", statusID, err)
+	}
+	return st.Name, nil
+}
+
+func (s *PullRequestService) statusByName(ctx context.Context, name string) (*models.Status, error) {
+	statuses, err := s.statusRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get statuses: %w", err)
+	}
+	for _, st := range statuses {
+		if st.Name == name {
+			return st, nil
+		}
+	}
+	return nil, fmt.Errorf("status '%s' not found", name)
+}
@@ -0,0 +1,81 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"time"
+)
+
+var ErrInvalidDecision = errors.New("unknown review decision")
+
+// SubmitReview records the reviewer's decision on an OPEN pull request. A
+// later decision replaces the earlier one. The pull request is locked while
+// the decision is stored, so it cannot slip in while a merge checks the
+// policy.
+func (s *PullRequestService) SubmitReview(ctx context.Context, prID int64, reviewerID int64,
+	decision string) (*dtos.PullRequest, error) {
+	switch decision {
+	case models.ReviewDecisionApproved, models.ReviewDecisionChangesRequested, models.ReviewDecisionCommented:
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrInvalidDecision, decision)
+	}
+
+	var dto *dtos.PullRequest
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := s.prRepo.LockByID(ctx, prID)
+		if errors.Is(err, pg.ErrPullRequestNotFound) {
+			return ErrPRNotFound
+		} else if err != nil {
+			return fmt.Errorf("lock PR: %w", err)
+		}
+
+		currentStatus, err := s.statusName(ctx, pr.StatusID)
+		if err != nil {
+			return err
+		}
+		if currentStatus == StatusMerged {
+			return ErrPRAlreadyMerged
+		}
+		if currentStatus != StatusOpen {
+			return fmt.Errorf("%w: cannot review a %s pull request", ErrInvalidTransition, currentStatus)
+		}
+
+		err = s.prRepo.SetReviewDecision(ctx, prID, reviewerID, decision, time.Now())
+		if errors.Is(err, pg.ErrReviewNotFound) {
+			return ErrUserNotReviewer
+		} else if err != nil {
+			return fmt.Errorf("set review decision: %w", err)
+		}
+
+		pr, err = s.prRepo.FindByID(ctx, prID)
+		if err != nil {
+			return fmt.Errorf("find PR: %w", err)
+		}
+		dto = dtos.ModelToPullRequestDTO(pr, currentStatus)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return dto, nil
+}
+
+// awaitsAction reports whether the reviewer still has to act on a pull request
+// in the given status: it is OPEN and they have neither approved it nor
+// requested changes.
+func awaitsAction(status string, reviews []models.Review, reviewerID int64) bool {
+	if status != StatusOpen {
+		return false
+	}
+	for _, r := range reviews {
+		if r.ReviewerID == reviewerID {
+			return r.Decision == nil || *r.Decision == models.ReviewDecisionCommented
+		}
+	}
+	return false
+}
@@ -4,7 +4,6 @@ import (
 	"context"
 	"errors"
 	"fmt"
-	"math/rand/v2"
 	"pullrequest-inator/internal/api/dtos"
 	"pullrequest-inator/internal/infrastructure/encoding"
 	"pullrequest-inator/internal/infrastructure/models"
@@ -21,27 +20,68 @@ var (
 	ErrUserNotReviewer    = errors.New("user is not a reviewer")
 	ErrNoReviewCandidates = errors.New("no users available to review")
 	ErrPRAlreadyMerged    = errors.New("cannot change PR state because already merged")
+	ErrNotEnoughReviewers = errors.New("not enough users available to review")
+	ErrAuthorNotInTeam    = errors.New("author is not a member of the team")
+	ErrAmbiguousTeam      = errors.New("author belongs to several teams, team name required")
 )
 
 type PullRequestService struct {
-	userRepo   repositories.User
-	prRepo     repositories.PullRequest
-	teamRepo   repositories.Team
-	statusRepo repositories.Status
+	userRepo           repositories.User
+	prRepo             repositories.PullRequest
+	teamRepo           repositories.Team
+	statusRepo         repositories.Status
+	rotationRepo       repositories.Rotation
+	unavailabilityRepo repositories.Unavailability
+	overrideRepo       repositories.MergeOverride
+	publisher          ReviewerPublisher
+	events             EventPublisher
+	tx                 repositories.Transactor
+	selectors          map[string]ReviewerSelector
+	limits             ReviewerLimits
 }
 
 func NewPullRequestService(userRepo repositories.User, prRepo repositories.PullRequest,
-	teamRepo repositories.Team, statusRepo repositories.Status) (*PullRequestService, error) {
+	teamRepo repositories.Team, statusRepo repositories.Status, rotationRepo repositories.Rotation,
+	unavailabilityRepo repositories.Unavailability, overrideRepo repositories.MergeOverride,
+	publisher ReviewerPublisher, events EventPublisher, tx repositories.Transactor,
+	limits ReviewerLimits) (*PullRequestService, error) {
+	if publisher == nil {
+		return nil, errors.New("reviewer publisher cannot be nil")
+	}
+	if events == nil {
+		return nil, errors.New("event publisher cannot be nil")
+	}
+	if err := limits.Validate(); err != nil {
+		return nil, fmt.Errorf("default reviewer limits: %w", err)
+	}
+
+	selectors := make(map[string]ReviewerSelector)
+	for _, strategy := range []string{StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded, StrategyWeighted} {
+		selector, err := NewReviewerSelector(strategy, prRepo, rotationRepo)
+		if err != nil {
+			return nil, err
+		}
+		selectors[strategy] = selector
+	}
+
 	return &PullRequestService{
-		userRepo:   userRepo,
-		prRepo:     prRepo,
-		teamRepo:   teamRepo,
-		statusRepo: statusRepo,
+		userRepo:           userRepo,
+		prRepo:             prRepo,
+		teamRepo:           teamRepo,
+		statusRepo:         statusRepo,
+		rotationRepo:       rotationRepo,
+		unavailabilityRepo: unavailabilityRepo,
+		overrideRepo:       overrideRepo,
+		publisher:          publisher,
+		events:             events,
+		tx:                 tx,
+		selectors:          selectors,
+		limits:             limits,
 	}, nil
 }
 
 func (s *PullRequestService) CreateWithReviewers(ctx context.Context, prID int64,
-	prName string, authorID int64) (*dtos.PullRequest, error) {
+	prName string, authorID int64, teamName string, draft bool) (*dtos.PullRequest, error) {
 	existing, err := s.prRepo.FindByID(ctx, prID)
 	if err != nil && !errors.Is(err, pg.ErrPullRequestNotFound) {
 		return nil, fmt.Errorf("check for existing PR: %w", err)
@@ -56,126 +96,182 @@ func (s *PullRequestService) CreateWithReviewers(ctx context.Context, prID int64
 		return nil, fmt.Errorf("find author: %w", err)
 	}
 
-	team, err := s.teamRepo.FindByUserID(ctx, authorID)
-	if errors.Is(err, pg.ErrTeamNotFound) {
-		return nil, ErrTeamNotFound
-	} else if err != nil {
-		return nil, fmt.Errorf("find team for author: %w", err)
+	team, err := s.authorTeam(ctx, authorID, teamName)
+	if err != nil {
+		return nil, err
 	}
 
-	var activeUsers []int64
-	for _, id := range team.UserIDs {
-		if id == authorID {
-			continue
+	status := StatusOpen
+	if draft {
+		status = StatusDraft
+	}
+	st, err := s.statusByName(ctx, status)
+	if err != nil {
+		return nil, err
+	}
+
+	newPR := &models.PullRequest{
+		ID:       prID,
+		Title:    prName,
+		AuthorID: authorID,
+		TeamID:   &team.ID,
+		StatusID: st.ID,
+		MergedAt: nil,
+	}
+
+	var dto *dtos.PullRequest
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var fallback []dtos.FallbackReviewer
+		if !draft {
+			if fallback, err = s.assignReviewers(ctx, team, newPR); err != nil {
+				return err
+			}
 		}
-		u, err := s.userRepo.FindByID(ctx, id)
-		if errors.Is(err, pg.ErrUserNotFound) {
-			continue
-		} else if err != nil {
-			return nil, fmt.Errorf("find user %d: %w", id, err)
+
+		if err := s.prRepo.Create(ctx, newPR); err != nil {
+			return fmt.Errorf("create pull request: %w", err)
 		}
-		if u.IsActive {
-			activeUsers = append(activeUsers, id)
+
+		dto = dtos.ModelToPullRequestDTO(newPR, st.Name)
+		dto.FallbackReviewers = fallback
+		if draft {
+			return s.events.Publish(ctx, pullRequestEvent(EventPullRequestCreated, dto))
+		}
+		if err := s.publisher.PublishReviewers(ctx, prID, nil); err != nil {
+			return err
 		}
+		return publishAll(ctx, s.events, pullRequestEvent(EventPullRequestCreated, dto),
+			pullRequestEvent(EventPullRequestReviewersAssigned, dto))
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if len(activeUsers) == 0 {
-		return nil, ErrNoReviewCandidates
+	return dto, nil
+}
+
+// assignReviewers fills pr.ReviewersIDs with reviewers from team within the
+// team's limits, topping up from backup teams when the team alone can't
+// reach the minimum.
+func (s *PullRequestService) assignReviewers(ctx context.Context, team *models.Team,
+	pr *models.PullRequest) ([]dtos.FallbackReviewer, error) {
+	activeUsers, err := s.activeCandidates(ctx, team, []int64{pr.AuthorID})
+	if err != nil {
+		return nil, err
 	}
 
-	reviewers := chooseRandomUsers(activeUsers, 2)
+	limits := s.limits.ForTeam(team)
 
-	openStatus, err := s.getOpenStatus(ctx)
+	reviewers, err := s.selectReviewers(ctx, team, activeUsers, limits.Max)
 	if err != nil {
 		return nil, err
 	}
 
-	newPR := &models.PullRequest{
-		ID:           prID,
-		Title:        prName,
-		AuthorID:     authorID,
-		StatusID:     openStatus.ID,
-		MergedAt:     nil,
-		ReviewersIDs: reviewers,
+	var fallback []dtos.FallbackReviewer
+	if len(reviewers) < limits.Min {
+		var extra []int64
+		extra, fallback, err = s.fallbackReviewers(ctx, team, append([]int64{pr.AuthorID}, reviewers...),
+			limits.Min-len(reviewers))
+		if err != nil {
+			return nil, err
+		}
+		reviewers = append(reviewers, extra...)
 	}
 
-	if err := s.prRepo.Create(ctx, newPR); err != nil {
-		return nil, fmt.Errorf("create pull request: %w", err)
+	if len(reviewers) < limits.Min {
+		if len(reviewers) == 0 {
+			return nil, ErrNoReviewCandidates
+		}
+		return nil, fmt.Errorf("%w: team %s requires %d, %d available",
+			ErrNotEnoughReviewers, team.Name, limits.Min, len(reviewers))
 	}
 
-	return dtos.ModelToPullRequestDTO(newPR, openStatus.Name), nil
+	pr.ReviewersIDs = reviewers
+	return fallback, nil
 }
 
+// ReassignReviewer replaces the reviewer of an open pull request. The pull
+// request is locked for the change, so that a merge or close running at the
+// same time is not undone, and only its reviewer row is written.
 func (s *PullRequestService) ReassignReviewer(ctx context.Context, userID int64, prID int64) (*dtos.ReassignReviewerResponse, error) {
-	pr, err := s.prRepo.FindByID(ctx, prID)
-	if errors.Is(err, pg.ErrPullRequestNotFound) {
-		return nil, ErrPRNotFound
-	} else if err != nil {
-		return nil, fmt.Errorf("find PR: %w", err)
-	}
-
-	if pr.ReviewersIDs == nil {
-		pr.ReviewersIDs = make([]int64, 0)
-	}
-	currentStatus := "OPEN"
-	if pr.StatusID > 0 {
-		st, err := s.statusRepo.FindByID(ctx, pr.StatusID)
-		if err == nil && st != nil {
-			currentStatus = st.Name
+	var newReviewer int64
+	var fallback []dtos.FallbackReviewer
+	var dto *dtos.PullRequest
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := s.prRepo.LockByID(ctx, prID)
+		if errors.Is(err, pg.ErrPullRequestNotFound) {
+			return ErrPRNotFound
+		} else if err != nil {
+			return fmt.Errorf("lock PR: %w", err)
 		}
-	}
 
-	if currentStatus == "MERGED" {
-		return nil, ErrPRAlreadyMerged
-	}
-
-	reviewerIndex := -1
-	for i, rid := range pr.ReviewersIDs {
-		if rid == userID {
-			reviewerIndex = i
-			break
+		currentStatus, err := s.statusName(ctx, pr.StatusID)
+		if err != nil {
+			return err
 		}
-	}
-	if reviewerIndex == -1 {
-		return nil, ErrUserNotReviewer
-	}
-
-	team, err := s.teamRepo.FindByUserID(ctx, userID)
-	if errors.Is(err, pg.ErrTeamNotFound) {
-		return nil, ErrTeamNotFound
-	} else if err != nil {
-		return nil, fmt.Errorf("find team: %w", err)
-	}
-
-	var candidates []int64
-	for _, id := range team.UserIDs {
-		if id == pr.AuthorID || contains(pr.ReviewersIDs, id) {
-			continue
+		if currentStatus == StatusMerged {
+			return ErrPRAlreadyMerged
 		}
-		u, err := s.userRepo.FindByID(ctx, id)
-		if errors.Is(err, pg.ErrUserNotFound) {
-			continue
-		} else if err != nil {
-			return nil, fmt.Errorf("find user %d: %w", id, err)
+		if currentStatus != StatusOpen {
+			return fmt.Errorf("%w: cannot reassign reviewers of a %s pull request", ErrInvalidTransition, currentStatus)
 		}
-		if u.IsActive {
-			candidates = append(candidates, id)
+		if !contains(pr.ReviewersIDs, userID) {
+			return ErrUserNotReviewer
 		}
-	}
 
-	if len(candidates) == 0 {
-		return nil, ErrNoReviewCandidates
-	}
+		team, err := s.reviewTeam(ctx, pr)
+		if err != nil {
+			return err
+		}
 
-	newReviewer := candidates[rand.N(len(candidates))]
-	pr.ReviewersIDs[reviewerIndex] = newReviewer
+		exclude := append([]int64{pr.AuthorID}, pr.ReviewersIDs...)
+		candidates, err := s.activeCandidates(ctx, team, exclude)
+		if err != nil {
+			return err
+		}
+		chosen, err := s.selectReviewers(ctx, team, candidates, 1)
+		if err != nil {
+			return err
+		}
+		if len(chosen) == 0 {
+			chosen, fallback, err = s.fallbackReviewers(ctx, team, exclude, 1)
+			if err != nil {
+				return err
+			}
+		}
+		if len(chosen) == 0 {
+			return ErrNoReviewCandidates
+		}
+		newReviewer = chosen[0]
+
+		if err := s.prRepo.ReplaceReviewers(ctx, []models.ReviewerReplacement{{
+			PullRequestID: prID,
+			OldReviewerID: userID,
+			NewReviewerID: newReviewer,
+		}}); err != nil {
+			return fmt.Errorf("replace reviewer: %w", err)
+		}
+		if pr, err = s.prRepo.FindByID(ctx, prID); err != nil {
+			return fmt.Errorf("find PR: %w", err)
+		}
+		dto = dtos.ModelToPullRequestDTO(pr, currentStatus)
 
-	if err := s.prRepo.Update(ctx, pr); err != nil {
-		return nil, fmt.Errorf("update PR: %w", err)
+		if err := s.publisher.PublishReviewers(ctx, prID, []int64{userID}); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, newEvent(EventPullRequestReviewerReassigned, dtos.ReviewerReplacement{
+			PullRequestId: encoding.EncodeID(prID),
+			OldUserId:     encoding.EncodeID(userID),
+			NewUserId:     encoding.EncodeID(newReviewer),
+		}))
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	dto.FallbackReviewers = fallback
 	return &dtos.ReassignReviewerResponse{
-		Pr:         *dtos.ModelToPullRequestDTO(pr, currentStatus),
+		Pr:         *dto,
 		ReplacedBy: encoding.EncodeID(newReviewer),
 	}, nil
 }
@@ -199,53 +295,10 @@ func (s *PullRequestService) FindPullRequestsByReviewer(ctx context.Context, use
 	return dts, err
 }
 
-func (s *PullRequestService) MarkAsMerged(ctx context.Context, prID int64) (*dtos.PullRequest, error) {
-	pr, err := s.prRepo.FindByID(ctx, prID)
-	if errors.Is(err, pg.ErrPullRequestNotFound) {
-		return nil, ErrPRNotFound
-	} else if err != nil {
-		return nil, fmt.Errorf("find PR: %w", err)
-	}
-
-	currentStatus := "OPEN"
-	if pr.StatusID > 0 {
-		st, err := s.statusRepo.FindByID(ctx, pr.StatusID)
-		if err == nil && st != nil {
-			currentStatus = st.Name
-		}
-	}
-
-	if currentStatus == "MERGED" {
-		return dtos.ModelToPullRequestDTO(pr, "MERGED"), nil
-	}
-
-	mergedStatus, err := s.getMergedStatus(ctx)
-	if err != nil || mergedStatus == nil {
-		return nil, fmt.Errorf("cannot merge PR: merged status not found")
-	}
-
-	now := time.Now()
-	pr.StatusID = mergedStatus.ID
-	pr.MergedAt = &now
-
-	if err := s.prRepo.Update(ctx, pr); err != nil {
-		return nil, fmt.Errorf("update PR to merged: %w", err)
-	}
-
-	return dtos.ModelToPullRequestDTO(pr, "MERGED"), nil
-}
-
 func (s *PullRequestService) GetUserReviews(ctx context.Context, userID int64) (*dtos.UserGetReviewResponse, error) {
-	allPRs, err := s.prRepo.FindAll(ctx)
+	prs, err := s.prRepo.FindByReviewer(ctx, userID)
 	if err != nil {
-		return nil, fmt.Errorf("find all PRs: %w", err)
-	}
-
-	var prs []*models.PullRequest
-	for _, pr := range allPRs {
-		if isReviewer(pr.ReviewersIDs, userID) {
-			prs = append(prs, pr)
-		}
+		return nil, fmt.Errorf("find PRs by reviewer: %w", err)
 	}
 
 	pullRequests := make([]dtos.PullRequestShort, len(prs))
@@ -260,6 +313,7 @@ func (s *PullRequestService) GetUserReviews(ctx context.Context, userID int64) (
 			PullRequestName: pr.Title,
 			AuthorId:        encoding.EncodeID(pr.AuthorID),
 			Status:          dtos.PullRequestStatus(status.Name),
+			AwaitingAction:  awaitsAction(status.Name, pr.Reviews, userID),
 		}
 	}
 
@@ -269,112 +323,191 @@ func (s *PullRequestService) GetUserReviews(ctx context.Context, userID int64) (
 	}, nil
 }
 
-func isReviewer(reviewers []int64, userID int64) bool {
-	for _, r := range reviewers {
-		if r == userID {
-			return true
-		}
-	}
-	return false
-}
-
 func (s *PullRequestService) CreatePullRequest(ctx context.Context, req *dtos.PullRequest) (*dtos.PullRequest, error) {
 	prID := encoding.DecodeID(req.PullRequestId)
 	authorID := encoding.DecodeID(req.AuthorId)
 
-	return s.CreateWithReviewers(ctx, prID, req.PullRequestName, authorID)
+	return s.CreateWithReviewers(ctx, prID, req.PullRequestName, authorID, req.TeamName, req.Draft)
 }
 
-func (s *PullRequestService) GetStatistics(ctx context.Context) (*dtos.StatsResponse, error) {
-	statusCounts, err := s.prRepo.GetPRStatusCounts(ctx)
+// RenamePullRequest sets the title of the pull request. Setting the title it
+// already has changes nothing and publishes no event.
+func (s *PullRequestService) RenamePullRequest(ctx context.Context, prID int64,
+	title string) (*dtos.PullRequest, error) {
+	var dto *dtos.PullRequest
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		pr, err := s.prRepo.LockByID(ctx, prID)
+		if errors.Is(err, pg.ErrPullRequestNotFound) {
+			return ErrPRNotFound
+		} else if err != nil {
+			return fmt.Errorf("lock PR: %w", err)
+		}
+		status, err := s.statusName(ctx, pr.StatusID)
+		if err != nil {
+			return err
+		}
+
+		if pr.Title == title {
+			dto = dtos.ModelToPullRequestDTO(pr, status)
+			return nil
+		}
+		pr.Title = title
+		if err := s.prRepo.Update(ctx, pr); err != nil {
+			return fmt.Errorf("update PR: %w", err)
+		}
+		dto = dtos.ModelToPullRequestDTO(pr, status)
+		return s.events.Publish(ctx, pullRequestEvent(EventPullRequestRenamed, dto))
+	})
 	if err != nil {
-		return nil, fmt.Errorf("get pr status stats: %w", err)
+		return nil, err
 	}
 
-	total := 0
-	for _, count := range statusCounts {
-		total += count
-	}
+	return dto, nil
+}
 
-	reviewerCounts, err := s.prRepo.GetReviewerStats(ctx)
+// authorTeam resolves the team a new pull request is reviewed by. The team
+// name may be omitted only when the author belongs to exactly one team.
+func (s *PullRequestService) authorTeam(ctx context.Context, authorID int64, teamName string) (*models.Team, error) {
+	teams, err := s.teamRepo.FindAllByUserID(ctx, authorID)
 	if err != nil {
-		return nil, fmt.Errorf("get reviewer stats: %w", err)
+		return nil, fmt.Errorf("find teams for author: %w", err)
 	}
 
-	allUsers, err := s.userRepo.FindAll(ctx)
-	if err != nil {
-		return nil, fmt.Errorf("get users for stats: %w", err)
+	if teamName == "" {
+		switch len(teams) {
+		case 0:
+			return nil, ErrTeamNotFound
+		case 1:
+			return teams[0], nil
+		}
+		return nil, ErrAmbiguousTeam
 	}
 
-	userMap := make(map[int64]*models.User)
-	for _, u := range allUsers {
-		userMap[u.ID] = u
+	for _, t := range teams {
+		if t.Name == teamName {
+			return t, nil
+		}
 	}
 
-	var reviewerStats []dtos.ReviewerStats
-	for userID, count := range reviewerCounts {
-		username := "Unknown"
-		if u, ok := userMap[userID]; ok {
-			username = u.Username
-		}
+	if _, err := s.teamRepo.FindByName(ctx, teamName); errors.Is(err, pg.ErrTeamNotFound) {
+		return nil, ErrTeamNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("find team %s: %w", teamName, err)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrAuthorNotInTeam, teamName)
+}
 
-		reviewerStats = append(reviewerStats, dtos.ReviewerStats{
-			ReviewerID:    encoding.EncodeID(userID),
-			Username:      username,
-			AssignedCount: count,
-		})
+// reviewTeam returns the team the pull request was created for. Pull requests
+// created before teams were recorded fall back to the author's first team.
+func (s *PullRequestService) reviewTeam(ctx context.Context, pr *models.PullRequest) (*models.Team, error) {
+	if pr.TeamID != nil {
+		team, err := s.teamRepo.FindByID(ctx, *pr.TeamID)
+		if errors.Is(err, pg.ErrTeamNotFound) {
+			return nil, ErrTeamNotFound
+		} else if err != nil {
+			return nil, fmt.Errorf("find team %d: %w", *pr.TeamID, err)
+		}
+		return team, nil
 	}
 
-	return &dtos.StatsResponse{
-		TotalPullRequests:  total,
-		OpenPullRequests:   statusCounts["OPEN"],
-		MergedPullRequests: statusCounts["MERGED"],
-		ReviewerStats:      reviewerStats,
-	}, nil
+	teams, err := s.teamRepo.FindAllByUserID(ctx, pr.AuthorID)
+	if err != nil {
+		return nil, fmt.Errorf("find teams for author: %w", err)
+	}
+	if len(teams) == 0 {
+		return nil, ErrTeamNotFound
+	}
+	return teams[0], nil
 }
 
-func (s *PullRequestService) getOpenStatus(ctx context.Context) (*models.Status, error) {
-	statuses, err := s.statusRepo.FindAll(ctx)
+// activeCandidates returns the members of team that are active and not on a
+// scheduled unavailability period right now.
+func (s *PullRequestService) activeCandidates(ctx context.Context, team *models.Team, exclude []int64) ([]int64, error) {
+	var candidates []int64
+	for _, id := range team.UserIDs {
+		if contains(exclude, id) {
+			continue
+		}
+		u, err := s.userRepo.FindByID(ctx, id)
+		if errors.Is(err, pg.ErrUserNotFound) {
+			continue
+		} else if err != nil {
+			return nil, fmt.Errorf("find user %d: %w", id, err)
+		}
+		if u.IsActive {
+			candidates = append(candidates, id)
+		}
+	}
+	if len(candidates) == 0 {
+		return candidates, nil
+	}
+
+	unavailable, err := s.unavailabilityRepo.FindUnavailableAt(ctx, candidates, time.Now())
 	if err != nil {
-		return nil, fmt.Errorf("get statuses: %w", err)
+		return nil, fmt.Errorf("find unavailable users: %w", err)
 	}
-	for _, st := range statuses {
-		if st.Name == "OPEN" {
-			return st, nil
+
+	available := candidates[:0]
+	for _, id := range candidates {
+		if !unavailable[id] {
+			available = append(available, id)
 		}
 	}
-	return nil, fmt.Errorf("status 'OPEN' not found")
+	return available, nil
 }
 
-func (s *PullRequestService) getMergedStatus(ctx context.Context) (*models.Status, error) {
-	statuses, err := s.statusRepo.FindAll(ctx)
+// fallbackReviewers draws up to n reviewers from the backup teams of team,
+// trying them in their configured order.
+func (s *PullRequestService) fallbackReviewers(ctx context.Context, team *models.Team,
+	exclude []int64, n int) ([]int64, []dtos.FallbackReviewer, error) {
+	backups, err := s.teamRepo.FindBackupTeams(ctx, team.ID)
 	if err != nil {
-		return nil, fmt.Errorf("get statuses: %w", err)
+		return nil, nil, fmt.Errorf("find backup teams: %w", err)
 	}
-	for _, st := range statuses {
-		if st.Name == "MERGED" {
-			return st, nil
+
+	var reviewers []int64
+	var fallback []dtos.FallbackReviewer
+	for _, backup := range backups {
+		if len(reviewers) >= n {
+			break
+		}
+
+		candidates, err := s.activeCandidates(ctx, backup, append(exclude, reviewers...))
+		if err != nil {
+			return nil, nil, err
+		}
+
+		chosen, err := s.selectReviewers(ctx, backup, candidates, n-len(reviewers))
+		if err != nil {
+			return nil, nil, err
+		}
+		for _, id := range chosen {
+			reviewers = append(reviewers, id)
+			fallback = append(fallback, dtos.FallbackReviewer{
+				UserId:   encoding.EncodeID(id),
+				TeamName: backup.Name,
+			})
 		}
 	}
-	return nil, fmt.Errorf("status 'MERGED' not found")
+
+	return reviewers, fallback, nil
 }
 
-func chooseRandomUsers(userIDs []int64, max int64) []int64 {
-	if len(userIDs) == 0 {
-		return []int64{}
+func (s *PullRequestService) selectReviewers(ctx context.Context, team *models.Team, candidates []int64, n int) ([]int64, error) {
+	if len(candidates) == 0 {
+		return []int64{}, nil
 	}
-	n := int64(len(userIDs))
-	if n <= max {
-		cpy := make([]int64, len(userIDs))
-		copy(cpy, userIDs)
-		return cpy
+
+	selector, ok := s.selectors[team.ReviewerStrategy]
+	if !ok {
+		selector = s.selectors[DefaultReviewerStrategy]
 	}
-	selected := make([]int64, 0, max)
-	perm := rand.Perm(int(n))
-	for i := 0; i < int(max); i++ {
-		selected = append(selected, userIDs[perm[i]])
+
+	reviewers, err := selector.Select(ctx, team, candidates, n)
+	if err != nil {
+		return nil, fmt.Errorf("select reviewers for team %s: %w", team.Name, err)
 	}
-	return selected
+	return reviewers, nil
 }
 
 func contains(slice []int64, item int64) bool {
@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"time"
+)
+
+// ReminderConfig sets when reviewers are reminded. A reviewer who has not
+// acted on an open pull request for After is reminded, and reminded again
+// every After until they decide or the review goes to someone else. The
+// stale reviews are looked for every Interval.
+type ReminderConfig struct {
+	After    time.Duration
+	Interval time.Duration
+}
+
+var DefaultReminderConfig = ReminderConfig{
+	After:    24 * time.Hour,
+	Interval: 15 * time.Minute,
+}
+
+// reminderPruneInterval is how often the records of reminders about settled
+// reviews are deleted.
+const reminderPruneInterval = time.Hour
+
+// ReminderScheduler reminds the reviewers of stale reviews by publishing
+// EventPullRequestReviewReminder, which reaches the webhook subscribers and
+// the team's chat, and logs every reminder it sends. Only the replica holding
+// the leader lock looks for stale reviews; the others keep trying to take
+// the lock over in case the leader goes away.
+type ReminderScheduler struct {
+	reminderRepo repositories.ReviewReminder
+	lock         repositories.LeaderLock
+	events       EventPublisher
+	tx           repositories.Transactor
+	logger       *log.Logger
+	config       ReminderConfig
+}
+
+func NewReminderScheduler(reminderRepo repositories.ReviewReminder, lock repositories.LeaderLock,
+	events EventPublisher, tx repositories.Transactor, logger *log.Logger,
+	config ReminderConfig) (*ReminderScheduler, error) {
+	if reminderRepo == nil {
+		return nil, errors.New("reviewReminderRepository cannot be nil")
+	}
+	if lock == nil {
+		return nil, errors.New("leader lock cannot be nil")
+	}
+	if events == nil {
+		return nil, errors.New("event publisher cannot be nil")
+	}
+	if tx == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
+	if logger == nil {
+		return nil, errors.New("logger cannot be nil")
+	}
+	if config.After <= 0 || config.Interval <= 0 {
+		return nil, errors.New("reminder period and interval must be positive")
+	}
+
+	return &ReminderScheduler{
+		reminderRepo: reminderRepo,
+		lock:         lock,
+		events:       events,
+		tx:           tx,
+		logger:       logger,
+		config:       config,
+	}, nil
+}
+
+// Run sends the due reminders every interval while this replica leads, until
+// ctx is done, and prunes the reminder records every reminderPruneInterval.
+// It then gives the lead up so that another replica takes over without
+// waiting for the connection to time out.
+func (s *ReminderScheduler) Run(ctx context.Context) {
+	ticker := time.NewTicker(s.config.Interval)
+	defer ticker.Stop()
+
+	leading := false
+	var pruned time.Time
+	for {
+		leader, err := s.lock.TryAcquire(ctx)
+		if err != nil && ctx.Err() == nil {
+			s.logger.Printf("Reminder scheduler failed to take the lead: %v", err)
+		}
+		if leader != leading {
+			leading = leader
+			if leading {
+				s.logger.Printf("Reminder scheduler is leading")
+			} else {
+				s.logger.Printf("Reminder scheduler lost the lead")
+			}
+		}
+		if leading {
+			if _, err := s.RemindStale(ctx); err != nil && ctx.Err() == nil {
+				s.logger.Printf("Failed to send review reminders: %v", err)
+			}
+			if time.Since(pruned) >= reminderPruneInterval {
+				if _, err := s.Prune(ctx); err == nil {
+					pruned = time.Now()
+				} else if ctx.Err() == nil {
+					s.logger.Printf("Failed to prune review reminders: %v", err)
+				}
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			if err := s.lock.Release(releaseCtx); err != nil {
+				s.logger.Printf("Reminder scheduler failed to give the lead up: %v", err)
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// RemindStale sends the reminders due now and returns how many it sent.
+func (s *ReminderScheduler) RemindStale(ctx context.Context) (int, error) {
+	now := time.Now()
+	staleBefore := now.Add(-s.config.After)
+
+	due, err := s.reminderRepo.FindDue(ctx, staleBefore)
+	if err != nil {
+		return 0, fmt.Errorf("find due reminders: %w", err)
+	}
+
+	sent := 0
+	var errs []error
+	for _, p := range due {
+		reminder := &models.ReviewReminder{
+			PullRequestID: p.PullRequestID,
+			ReviewerID:    p.ReviewerID,
+			AssignedAt:    p.AssignedAt,
+		}
+		var data *dtos.ReviewReminder
+		err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+			due, err := s.reminderRepo.Record(ctx, reminder, staleBefore)
+			if err != nil || !due {
+				return err
+			}
+			data = &dtos.ReviewReminder{
+				PullRequestId:   encoding.EncodeID(p.PullRequestID),
+				PullRequestName: p.Title,
+				AuthorId:        encoding.EncodeID(p.AuthorID),
+				ReviewerId:      encoding.EncodeID(p.ReviewerID),
+				AssignedAt:      p.AssignedAt,
+				WaitingHours:    now.Sub(p.AssignedAt).Hours(),
+				Reminder:        reminder.Reminders,
+			}
+			return s.events.Publish(ctx, newEvent(EventPullRequestReviewReminder, data))
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if data == nil {
+			continue
+		}
+		sent++
+		s.logger.Printf("Reminded %s of %s waiting for review for %.1f hours (reminder %d)",
+			data.ReviewerId, data.PullRequestId, data.WaitingHours, data.Reminder)
+	}
+	return sent, errors.Join(errs...)
+}
+
+// Prune deletes the reminder records of reviews no longer awaiting a decision
+// and returns how many it deleted.
+func (s *ReminderScheduler) Prune(ctx context.Context) (int64, error) {
+	return s.reminderRepo.DeleteSettled(ctx)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"log"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"time"
+)
+
+// ReviewerReassigner hands a review over to another candidate.
+type ReviewerReassigner interface {
+	ReassignReviewer(ctx context.Context, userID int64, prID int64) (*dtos.ReassignReviewerResponse, error)
+}
+
+var DefaultSLACheckInterval = time.Minute
+
+// SLAChecker flags the reviews that went past their team's SLA. Every review
+// is flagged once: the flag and the EventPullRequestReviewOverdue event are
+// stored together, so with several instances checking only one of them
+// escalates. Reviews of teams with auto-reassign are handed over to another
+// candidate like a manual reassignment in the same transaction, so a failed
+// reassignment leaves the review to be flagged again on the next check; the
+// new reviewer's SLA starts anew.
+type SLAChecker struct {
+	slaService *ReviewSLAService
+	breachRepo repositories.ReviewSLABreach
+	reassigner ReviewerReassigner
+	events     EventPublisher
+	tx         repositories.Transactor
+	logger     *log.Logger
+	interval   time.Duration
+}
+
+func NewSLAChecker(slaService *ReviewSLAService, breachRepo repositories.ReviewSLABreach,
+	reassigner ReviewerReassigner, events EventPublisher, tx repositories.Transactor, logger *log.Logger,
+	interval time.Duration) (*SLAChecker, error) {
+	if slaService == nil {
+		return nil, errors.New("slaService cannot be nil")
+	}
+	if breachRepo == nil {
+		return nil, errors.New("reviewSLABreachRepository cannot be nil")
+	}
+	if reassigner == nil {
+		return nil, errors.New("reviewer reassigner cannot be nil")
+	}
+	if events == nil {
+		return nil, errors.New("event publisher cannot be nil")
+	}
+	if tx == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
+	if logger == nil {
+		return nil, errors.New("logger cannot be nil")
+	}
+	if interval <= 0 {
+		return nil, errors.New("SLA check interval must be positive")
+	}
+
+	return &SLAChecker{
+		slaService: slaService,
+		breachRepo: breachRepo,
+		reassigner: reassigner,
+		events:     events,
+		tx:         tx,
+		logger:     logger,
+		interval:   interval,
+	}, nil
+}
+
+// Run checks the SLAs every interval until ctx is done.
+func (c *SLAChecker) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := c.Check(ctx); err != nil && ctx.Err() == nil {
+			c.logger.Printf("Failed to check review SLAs: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Check flags the overdue reviews not flagged yet and returns how many it
+// flagged. A review that cannot be reassigned, because nobody else is
+// available or the pull request changed meanwhile, stays with its reviewer.
+func (c *SLAChecker) Check(ctx context.Context) (int, error) {
+	overdue, err := c.slaService.overdue(ctx, nil, time.Now())
+	if err != nil {
+		return 0, err
+	}
+
+	flagged := 0
+	var errs []error
+	for _, o := range overdue {
+		created := false
+		err := c.tx.WithinTx(ctx, func(ctx context.Context) error {
+			var err error
+			created, err = c.breachRepo.Create(ctx, &models.ReviewSLABreach{
+				PullRequestID: o.review.PullRequestID,
+				ReviewerID:    o.review.ReviewerID,
+				AssignedAt:    o.review.AssignedAt,
+			})
+			if err != nil || !created {
+				return err
+			}
+			if err := c.events.Publish(ctx, newEvent(EventPullRequestReviewOverdue, o.dto)); err != nil {
+				return err
+			}
+			if !o.sla.AutoReassign {
+				return nil
+			}
+
+			_, err = c.reassigner.ReassignReviewer(ctx, o.review.ReviewerID, o.review.PullRequestID)
+			if errors.Is(err, ErrNoReviewCandidates) || errors.Is(err, ErrUserNotReviewer) ||
+				errors.Is(err, ErrPRAlreadyMerged) || errors.Is(err, ErrInvalidTransition) {
+				return nil
+			}
+			return err
+		})
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if created {
+			flagged++
+		}
+	}
+	return flagged, errors.Join(errs...)
+}
@@ -0,0 +1,365 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"sort"
+	"strings"
+	"time"
+)
+
+var ErrInvalidSLA = errors.New("invalid review SLA")
+
+// Severities of an overdue review, by how many times its SLA it has waited.
+const (
+	SeverityMinor    = "minor"
+	SeverityMajor    = "major"
+	SeverityCritical = "critical"
+)
+
+// defaultWorkingHours are the working hours of an SLA that does not set them:
+// 09:00 to 18:00 UTC, Monday to Friday.
+var defaultWorkingHours = models.TeamReviewSLA{
+	WorkStartMinutes: 9 * 60,
+	WorkEndMinutes:   18 * 60,
+	WorkDays:         []int{1, 2, 3, 4, 5},
+	Timezone:         "UTC",
+}
+
+// ReviewSLAService manages the teams' review SLAs and finds the reviews that
+// went past them.
+type ReviewSLAService struct {
+	teamRepo repositories.Team
+	prRepo   repositories.PullRequest
+	slaRepo  repositories.TeamReviewSLA
+}
+
+func NewReviewSLAService(teamRepo repositories.Team, prRepo repositories.PullRequest,
+	slaRepo repositories.TeamReviewSLA) (*ReviewSLAService, error) {
+	if teamRepo == nil {
+		return nil, errors.New("teamRepository cannot be nil")
+	}
+	if prRepo == nil {
+		return nil, errors.New("prRepository cannot be nil")
+	}
+	if slaRepo == nil {
+		return nil, errors.New("teamReviewSLARepository cannot be nil")
+	}
+
+	return &ReviewSLAService{teamRepo: teamRepo, prRepo: prRepo, slaRepo: slaRepo}, nil
+}
+
+// SetTeamSLA replaces the review SLA of the team.
+func (s *ReviewSLAService) SetTeamSLA(ctx context.Context, update *dtos.TeamReviewSLAUpdate) (*dtos.TeamReviewSLA, error) {
+	sla, err := newTeamReviewSLA(update)
+	if err != nil {
+		return nil, err
+	}
+
+	team, err := s.findTeam(ctx, update.TeamName)
+	if err != nil {
+		return nil, err
+	}
+
+	sla.TeamID = team.ID
+	if err := s.slaRepo.Upsert(ctx, sla); err != nil {
+		return nil, fmt.Errorf("set review SLA: %w", err)
+	}
+
+	return toTeamReviewSLADTO(team, sla), nil
+}
+
+func (s *ReviewSLAService) GetTeamSLA(ctx context.Context, teamName string) (*dtos.TeamReviewSLA, error) {
+	team, err := s.findTeam(ctx, teamName)
+	if err != nil {
+		return nil, err
+	}
+
+	sla, err := s.slaRepo.FindByTeamID(ctx, team.ID)
+	if errors.Is(err, pg.ErrTeamReviewSLANotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find review SLA: %w", err)
+	}
+
+	return toTeamReviewSLADTO(team, sla), nil
+}
+
+func (s *ReviewSLAService) DeleteTeamSLA(ctx context.Context, teamName string) error {
+	team, err := s.findTeam(ctx, teamName)
+	if err != nil {
+		return err
+	}
+
+	err = s.slaRepo.DeleteByTeamID(ctx, team.ID)
+	if errors.Is(err, pg.ErrTeamReviewSLANotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete review SLA: %w", err)
+	}
+	return nil
+}
+
+// ListOverdue returns the reviews of teamName, or of all teams if it is
+// empty, that went past their team's SLA, the most overdue first.
+func (s *ReviewSLAService) ListOverdue(ctx context.Context, teamName string) ([]*dtos.OverdueReview, error) {
+	var teamID *int64
+	if teamName != "" {
+		team, err := s.findTeam(ctx, teamName)
+		if err != nil {
+			return nil, err
+		}
+		teamID = &team.ID
+	}
+
+	overdue, err := s.overdue(ctx, teamID, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([]*dtos.OverdueReview, len(overdue))
+	for i, o := range overdue {
+		out[i] = o.dto
+	}
+	return out, nil
+}
+
+// overdueReview is a pending review past its team's SLA. ratio is how many
+// times the SLA it has waited.
+type overdueReview struct {
+	review *models.PendingReview
+	sla    *models.TeamReviewSLA
+	ratio  float64
+	dto    *dtos.OverdueReview
+}
+
+// overdue finds the pending reviews of the team with teamID, or of every team
+// with an SLA if it is nil, that are past the SLA at now. They are sorted by
+// how many times their SLA they have waited.
+func (s *ReviewSLAService) overdue(ctx context.Context, teamID *int64, now time.Time) ([]*overdueReview, error) {
+	slaList, err := s.slaRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find review SLAs: %w", err)
+	}
+	slas := make(map[int64]*models.TeamReviewSLA, len(slaList))
+	for _, sla := range slaList {
+		if teamID == nil || sla.TeamID == *teamID {
+			slas[sla.TeamID] = sla
+		}
+	}
+	if len(slas) == 0 {
+		return nil, nil
+	}
+
+	pending, err := s.prRepo.FindPendingReviews(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("find pending reviews: %w", err)
+	}
+
+	teams := make(map[int64]*models.Team)
+	var overdue []*overdueReview
+	for _, p := range pending {
+		if p.TeamID == nil {
+			continue
+		}
+		sla, ok := slas[*p.TeamID]
+		if !ok {
+			continue
+		}
+
+		limit := time.Duration(sla.FirstDecisionSeconds) * time.Second
+		working := workingTime(sla, p.AssignedAt, now)
+		if working < limit {
+			continue
+		}
+
+		team, ok := teams[sla.TeamID]
+		if !ok {
+			team, err = s.teamRepo.FindByID(ctx, sla.TeamID)
+			if err != nil {
+				return nil, fmt.Errorf("find team: %w", err)
+			}
+			teams[sla.TeamID] = team
+		}
+
+		ratio := float64(working) / float64(limit)
+		overdue = append(overdue, &overdueReview{
+			review: p,
+			sla:    sla,
+			ratio:  ratio,
+			dto: &dtos.OverdueReview{
+				PullRequestId:   encoding.EncodeID(p.PullRequestID),
+				PullRequestName: p.Title,
+				TeamName:        team.Name,
+				ReviewerId:      encoding.EncodeID(p.ReviewerID),
+				AssignedAt:      p.AssignedAt,
+				AgeHours:        now.Sub(p.AssignedAt).Hours(),
+				WorkingHours:    working.Hours(),
+				SlaHours:        limit.Hours(),
+				Severity:        severity(ratio),
+			},
+		})
+	}
+
+	sort.SliceStable(overdue, func(i, j int) bool {
+		return overdue[i].ratio > overdue[j].ratio
+	})
+	return overdue, nil
+}
+
+func (s *ReviewSLAService) findTeam(ctx context.Context, teamName string) (*models.Team, error) {
+	team, err := s.teamRepo.FindByName(ctx, teamName)
+	if errors.Is(err, pg.ErrTeamNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find team: %w", err)
+	}
+	return team, nil
+}
+
+func newTeamReviewSLA(update *dtos.TeamReviewSLAUpdate) (*models.TeamReviewSLA, error) {
+	seconds := math.Round(update.FirstDecisionHours * 3600)
+	if seconds < 1 || seconds > math.MaxInt32 {
+		return nil, fmt.Errorf("%w: first_decision_hours must be at least a second", ErrInvalidSLA)
+	}
+
+	sla := defaultWorkingHours
+	sla.FirstDecisionSeconds = int(seconds)
+	if update.WorkStart != nil {
+		minutes, err := parseClock(*update.WorkStart)
+		if err != nil {
+			return nil, fmt.Errorf("%w: work_start: %w", ErrInvalidSLA, err)
+		}
+		sla.WorkStartMinutes = minutes
+	}
+	if update.WorkEnd != nil {
+		minutes, err := parseClock(*update.WorkEnd)
+		if err != nil {
+			return nil, fmt.Errorf("%w: work_end: %w", ErrInvalidSLA, err)
+		}
+		sla.WorkEndMinutes = minutes
+	}
+	if sla.WorkStartMinutes >= sla.WorkEndMinutes {
+		return nil, fmt.Errorf("%w: work_start must be before work_end", ErrInvalidSLA)
+	}
+
+	if update.WorkDays != nil {
+		if len(update.WorkDays) == 0 {
+			return nil, fmt.Errorf("%w: work_days cannot be empty", ErrInvalidSLA)
+		}
+		seen := make(map[int]bool, len(update.WorkDays))
+		for _, day := range update.WorkDays {
+			if day < 1 || day > 7 {
+				return nil, fmt.Errorf("%w: work day %d is not an ISO weekday", ErrInvalidSLA, day)
+			}
+			if seen[day] {
+				return nil, fmt.Errorf("%w: work day %d listed twice", ErrInvalidSLA, day)
+			}
+			seen[day] = true
+		}
+		sla.WorkDays = append([]int(nil), update.WorkDays...)
+		sort.Ints(sla.WorkDays)
+	}
+
+	if update.Timezone != nil {
+		timezone := strings.TrimSpace(*update.Timezone)
+		if _, err := time.LoadLocation(timezone); err != nil || timezone == "" {
+			return nil, fmt.Errorf("%w: unknown timezone %q", ErrInvalidSLA, timezone)
+		}
+		sla.Timezone = timezone
+	}
+	if update.AutoReassign != nil {
+		sla.AutoReassign = *update.AutoReassign
+	}
+	return &sla, nil
+}
+
+// workingTime returns how much of the time from from to to falls into the
+// working hours of sla.
+func workingTime(sla *models.TeamReviewSLA, from, to time.Time) time.Duration {
+	loc, err := time.LoadLocation(sla.Timezone)
+	if err != nil {
+		loc = time.UTC
+	}
+	workDays := make(map[time.Weekday]bool, len(sla.WorkDays))
+	for _, day := range sla.WorkDays {
+		workDays[time.Weekday(day%7)] = true
+	}
+
+	var total time.Duration
+	from, to = from.In(loc), to.In(loc)
+	for day := time.Date(from.Year(), from.Month(), from.Day(), 0, 0, 0, 0, loc); day.Before(to); day = day.AddDate(0, 0, 1) {
+		if !workDays[day.Weekday()] {
+			continue
+		}
+		start := atMinute(day, sla.WorkStartMinutes)
+		end := atMinute(day, sla.WorkEndMinutes)
+		if start.Before(from) {
+			start = from
+		}
+		if end.After(to) {
+			end = to
+		}
+		if end.After(start) {
+			total += end.Sub(start)
+		}
+	}
+	return total
+}
+
+// atMinute returns the wall clock time minutes after midnight on day, so
+// working hours stay put on days when the clocks change.
+func atMinute(day time.Time, minutes int) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), minutes/60, minutes%60, 0, 0, day.Location())
+}
+
+func severity(ratio float64) string {
+	switch {
+	case ratio >= 3:
+		return SeverityCritical
+	case ratio >= 2:
+		return SeverityMajor
+	}
+	return SeverityMinor
+}
+
+// parseClock parses a "15:04" time of day into minutes after midnight;
+// "24:00" is the end of the day.
+func parseClock(s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if s == "24:00" {
+		return 24 * 60, nil
+	}
+	t, err := time.Parse("15:04", s)
+	if err != nil {
+		return 0, fmt.Errorf("%q is not a HH:MM time", s)
+	}
+	return t.Hour()*60 + t.Minute(), nil
+}
+
+func formatClock(minutes int) string {
+	return fmt.Sprintf("%02d:%02d", minutes/60, minutes%60)
+}
+
+func toTeamReviewSLADTO(team *models.Team, sla *models.TeamReviewSLA) *dtos.TeamReviewSLA {
+	return &dtos.TeamReviewSLA{
+		TeamName:           team.Name,
+		FirstDecisionHours: float64(sla.FirstDecisionSeconds) / 3600,
+		WorkStart:          formatClock(sla.WorkStartMinutes),
+		WorkEnd:            formatClock(sla.WorkEndMinutes),
+		WorkDays:           sla.WorkDays,
+		Timezone:           sla.Timezone,
+		AutoReassign:       sla.AutoReassign,
+		UpdatedAt:          sla.UpdatedAt,
+	}
+}
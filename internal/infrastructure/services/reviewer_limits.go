@@ -0,0 +1,52 @@
+package services
+
+import (
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+)
+
+// ReviewerLimits bounds the number of reviewers assigned to a pull request.
+type ReviewerLimits struct {
+	Min int
+	Max int
+}
+
+var DefaultReviewerLimits = ReviewerLimits{Min: 1, Max: 2}
+
+func (l ReviewerLimits) Validate() error {
+	if l.Min < 0 {
+		return fmt.Errorf("%w: min reviewers must not be negative", ErrInvalidSettings)
+	}
+	if l.Max < 1 {
+		return fmt.Errorf("%w: max reviewers must be at least 1", ErrInvalidSettings)
+	}
+	if l.Min > l.Max {
+		return fmt.Errorf("%w: min reviewers %d exceeds max reviewers %d", ErrInvalidSettings, l.Min, l.Max)
+	}
+	return nil
+}
+
+// ForTeam resolves the team limits, falling back to l for values the team
+// did not set. A max below the min is raised to it.
+func (l ReviewerLimits) ForTeam(team *models.Team) ReviewerLimits {
+	limits := l.teamLimits(team)
+	if limits.Max < limits.Min {
+		limits.Max = limits.Min
+	}
+	return limits
+}
+
+// teamLimits is ForTeam raising only a fallback max, so that a min and a max
+// the team set against each other are left for Validate to reject.
+func (l ReviewerLimits) teamLimits(team *models.Team) ReviewerLimits {
+	limits := l
+	if team.MinReviewers != nil {
+		limits.Min = *team.MinReviewers
+	}
+	if team.MaxReviewers != nil {
+		limits.Max = *team.MaxReviewers
+	} else if limits.Max < limits.Min {
+		limits.Max = limits.Min
+	}
+	return limits
+}
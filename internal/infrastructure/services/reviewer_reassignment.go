@@ -0,0 +1,362 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"slices"
+	"time"
+)
+
+var ErrUserNotInTeam = errors.New("user is not a member of the team")
+
+// DeactivateTeamMembers deactivates the given members of the team, or all of
+// them when userIDs is empty, and moves their OPEN reviews to the remaining
+// active candidates in a single transaction.
+func (s *PullRequestService) DeactivateTeamMembers(ctx context.Context, teamName string,
+	userIDs []int64) (*dtos.DeactivationReport, error) {
+	team, err := s.teamRepo.FindByName(ctx, teamName)
+	if errors.Is(err, pg.ErrTeamNotFound) {
+		return nil, ErrTeamNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("find team: %w", err)
+	}
+
+	if len(userIDs) == 0 {
+		userIDs = team.UserIDs
+	}
+	for _, id := range userIDs {
+		if !contains(team.UserIDs, id) {
+			return nil, fmt.Errorf("%w: %s", ErrUserNotInTeam, encoding.EncodeID(id))
+		}
+	}
+
+	var reassigned []models.ReviewerReplacement
+	var report *dtos.DeactivationReport
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.userRepo.SetActive(ctx, userIDs, false); err != nil {
+			return fmt.Errorf("deactivate users: %w", err)
+		}
+
+		var unassigned []models.ReviewerReplacement
+		reassigned, unassigned, err = s.reassignOpenReviews(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+
+		report = &dtos.DeactivationReport{
+			TeamName:         team.Name,
+			DeactivatedUsers: make([]string, len(userIDs)),
+			Reassigned:       toReviewerReplacementDTOs(reassigned),
+			Unassigned:       toUnassignedReviewDTOs(unassigned),
+		}
+		for i, id := range userIDs {
+			report.DeactivatedUsers[i] = encoding.EncodeID(id)
+		}
+		if err := s.publishReplacements(ctx, reassigned); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, newEvent(EventTeamMembersDeactivated, report))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// ReassignOpenReviews replaces the given users on every OPEN pull request they
+// review, see reassignOpenReviews.
+func (s *PullRequestService) ReassignOpenReviews(ctx context.Context, userIDs []int64) (*dtos.ReviewReassignment, error) {
+	var reassigned, unassigned []models.ReviewerReplacement
+	err := s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+		reassigned, unassigned, err = s.reassignOpenReviews(ctx, userIDs)
+		if err != nil {
+			return err
+		}
+		return s.publishReplacements(ctx, reassigned)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &dtos.ReviewReassignment{
+		Reassigned: toReviewerReplacementDTOs(reassigned),
+		Unassigned: toUnassignedReviewDTOs(unassigned),
+	}, nil
+}
+
+// publishReplacements publishes the reviewers of every pull request touched by
+// a reassignment, once per pull request.
+func (s *PullRequestService) publishReplacements(ctx context.Context, reassigned []models.ReviewerReplacement) error {
+	removed := make(map[int64][]int64)
+	var order []int64
+	for _, r := range reassigned {
+		if _, ok := removed[r.PullRequestID]; !ok {
+			order = append(order, r.PullRequestID)
+		}
+		removed[r.PullRequestID] = append(removed[r.PullRequestID], r.OldReviewerID)
+	}
+	for _, prID := range order {
+		if err := s.publisher.PublishReviewers(ctx, prID, removed[prID]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// reassignOpenReviews replaces the given users on every OPEN pull request
+// they review. Replacements come from the pull request's team, the author's
+// team for pull requests created before teams were recorded, and then its
+// backup teams, skipping inactive and currently unavailable users, and are
+// picked by the reviewer strategy of the team they come from, like on
+// assignment. The whole reassignment is planned in memory, see
+// replacementPlan, and stored at once; as the round robin cursors stay
+// locked until then, it must run inside a transaction. Reviews with no
+// eligible replacement, including all reviews of a pull request with no team
+// to draw from, are left as they are and returned as unassigned.
+func (s *PullRequestService) reassignOpenReviews(ctx context.Context,
+	userIDs []int64) ([]models.ReviewerReplacement, []models.ReviewerReplacement, error) {
+	prs, err := s.prRepo.FindOpenByReviewers(ctx, userIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find open reviews: %w", err)
+	}
+	if len(prs) == 0 {
+		return nil, nil, nil
+	}
+
+	leaving := make(map[int64]bool, len(userIDs))
+	for _, id := range userIDs {
+		leaving[id] = true
+	}
+
+	pools := make(map[int64][]*models.Team)
+	var memberIDs []int64
+	for _, pr := range prs {
+		if pr.TeamID == nil {
+			team, err := s.reviewTeam(ctx, pr)
+			if errors.Is(err, ErrTeamNotFound) {
+				continue
+			} else if err != nil {
+				return nil, nil, err
+			}
+			pr.TeamID = &team.ID
+		}
+		if _, ok := pools[*pr.TeamID]; ok {
+			continue
+		}
+
+		team, err := s.teamRepo.FindByID(ctx, *pr.TeamID)
+		if errors.Is(err, pg.ErrTeamNotFound) {
+			pools[*pr.TeamID] = nil
+			continue
+		} else if err != nil {
+			return nil, nil, fmt.Errorf("find team %d: %w", *pr.TeamID, err)
+		}
+		backups, err := s.teamRepo.FindBackupTeams(ctx, team.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("find backup teams: %w", err)
+		}
+
+		pools[team.ID] = append([]*models.Team{team}, backups...)
+		for _, t := range pools[team.ID] {
+			memberIDs = append(memberIDs, t.UserIDs...)
+		}
+	}
+
+	members, err := s.userRepo.FindByIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find candidates: %w", err)
+	}
+	active := make(map[int64]bool, len(members))
+	var activeIDs []int64
+	for _, u := range members {
+		if u.IsActive && !leaving[u.ID] {
+			active[u.ID] = true
+			activeIDs = append(activeIDs, u.ID)
+		}
+	}
+
+	unavailable, err := s.unavailabilityRepo.FindUnavailableAt(ctx, activeIDs, time.Now())
+	if err != nil {
+		return nil, nil, fmt.Errorf("find unavailable users: %w", err)
+	}
+	for id := range unavailable {
+		delete(active, id)
+	}
+
+	plan, err := s.newReplacementPlan(ctx, pools, activeIDs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var reassigned, unassigned []models.ReviewerReplacement
+	for _, pr := range prs {
+		var pool []*models.Team
+		if pr.TeamID != nil {
+			pool = pools[*pr.TeamID]
+		}
+		for i, reviewerID := range pr.ReviewersIDs {
+			if !leaving[reviewerID] {
+				continue
+			}
+
+			replacement := models.ReviewerReplacement{PullRequestID: pr.ID, OldReviewerID: reviewerID}
+			replacement.NewReviewerID = plan.pick(pool, active,
+				append([]int64{pr.AuthorID}, pr.ReviewersIDs...))
+			if replacement.NewReviewerID == 0 {
+				unassigned = append(unassigned, replacement)
+				continue
+			}
+
+			pr.ReviewersIDs[i] = replacement.NewReviewerID
+			reassigned = append(reassigned, replacement)
+		}
+	}
+
+	if err := s.prRepo.ReplaceReviewers(ctx, reassigned); err != nil {
+		return nil, nil, fmt.Errorf("replace reviewers: %w", err)
+	}
+	if err := plan.save(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	for _, r := range toReviewerReplacementDTOs(reassigned) {
+		if err := s.events.Publish(ctx, newEvent(EventPullRequestReviewerReassigned, r)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	return reassigned, unassigned, nil
+}
+
+// replacementPlan picks the replacements of a whole reassignment without a
+// query per review. The open review counts of the candidates and the rotation
+// cursors of the round robin teams are read once, the cursors locked, and
+// both are kept up to date in memory as replacements are picked.
+type replacementPlan struct {
+	rotationRepo repositories.Rotation
+	loads        map[int64]int
+	cursors      map[int64]int64
+	moved        map[int64]bool
+}
+
+func (s *PullRequestService) newReplacementPlan(ctx context.Context, pools map[int64][]*models.Team,
+	candidateIDs []int64) (*replacementPlan, error) {
+	loads, err := s.prRepo.CountOpenReviews(ctx, candidateIDs)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer load: %w", err)
+	}
+	if loads == nil {
+		loads = make(map[int64]int)
+	}
+
+	plan := &replacementPlan{
+		rotationRepo: s.rotationRepo,
+		loads:        loads,
+		cursors:      make(map[int64]int64),
+		moved:        make(map[int64]bool),
+	}
+
+	// Locked in ID order, so that concurrent reassignments cannot deadlock.
+	var rotating []int64
+	for _, pool := range pools {
+		for _, team := range pool {
+			if teamStrategy(team) == StrategyRoundRobin && !contains(rotating, team.ID) {
+				rotating = append(rotating, team.ID)
+			}
+		}
+	}
+	slices.Sort(rotating)
+	for _, teamID := range rotating {
+		cursor, err := s.rotationRepo.LockCursor(ctx, teamID)
+		if err != nil {
+			return nil, fmt.Errorf("lock rotation cursor: %w", err)
+		}
+		plan.cursors[teamID] = cursor
+	}
+
+	return plan, nil
+}
+
+// pick picks a replacement reviewer from the first team in pool that has an
+// active member outside exclude, with that team's strategy. It returns 0 if
+// no team has one.
+func (p *replacementPlan) pick(pool []*models.Team, active map[int64]bool, exclude []int64) int64 {
+	for _, team := range pool {
+		var candidates []int64
+		for _, id := range team.UserIDs {
+			if active[id] && !contains(exclude, id) {
+				candidates = append(candidates, id)
+			}
+		}
+		if len(candidates) == 0 {
+			continue
+		}
+
+		var chosen int64
+		switch teamStrategy(team) {
+		case StrategyRandom:
+			chosen = shuffled(candidates)[0]
+		case StrategyRoundRobin:
+			chosen = rotate(candidates, p.cursors[team.ID], 1)[0]
+			p.cursors[team.ID] = chosen
+			p.moved[team.ID] = true
+		case StrategyWeighted:
+			chosen = weighted(candidates, p.loads, 1)[0]
+		default:
+			chosen = leastLoaded(candidates, p.loads, 1)[0]
+		}
+		p.loads[chosen]++
+		return chosen
+	}
+	return 0
+}
+
+// save stores the rotation cursors that moved.
+func (p *replacementPlan) save(ctx context.Context) error {
+	for teamID := range p.moved {
+		if err := p.rotationRepo.AdvanceCursor(ctx, teamID, p.cursors[teamID]); err != nil {
+			return fmt.Errorf("advance rotation cursor: %w", err)
+		}
+	}
+	return nil
+}
+
+// teamStrategy returns the reviewer strategy of the team, the default one for
+// a strategy this version does not know.
+func teamStrategy(team *models.Team) string {
+	if IsKnownStrategy(team.ReviewerStrategy) {
+		return team.ReviewerStrategy
+	}
+	return DefaultReviewerStrategy
+}
+
+func toReviewerReplacementDTOs(replacements []models.ReviewerReplacement) []dtos.ReviewerReplacement {
+	out := make([]dtos.ReviewerReplacement, len(replacements))
+	for i, r := range replacements {
+		out[i] = dtos.ReviewerReplacement{
+			PullRequestId: encoding.EncodeID(r.PullRequestID),
+			OldUserId:     encoding.EncodeID(r.OldReviewerID),
+			NewUserId:     encoding.EncodeID(r.NewReviewerID),
+		}
+	}
+	return out
+}
+
+func toUnassignedReviewDTOs(replacements []models.ReviewerReplacement) []dtos.UnassignedReview {
+	out := make([]dtos.UnassignedReview, len(replacements))
+	for i, r := range replacements {
+		out[i] = dtos.UnassignedReview{
+			PullRequestId: encoding.EncodeID(r.PullRequestID),
+			UserId:        encoding.EncodeID(r.OldReviewerID),
+		}
+	}
+	return out
+}
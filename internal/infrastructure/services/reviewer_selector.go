@@ -0,0 +1,188 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"sort"
+)
+
+const (
+	StrategyRandom      = "random"
+	StrategyRoundRobin  = "round_robin"
+	StrategyLeastLoaded = "least_loaded"
+	StrategyWeighted    = "weighted"
+
+	DefaultReviewerStrategy = StrategyLeastLoaded
+)
+
+var ErrUnknownStrategy = errors.New("unknown reviewer strategy")
+
+// ReviewerSelector picks up to n reviewers of the team out of the given
+// candidates.
+type ReviewerSelector interface {
+	Select(ctx context.Context, team *models.Team, candidates []int64, n int) ([]int64, error)
+}
+
+func NewReviewerSelector(strategy string, prRepo repositories.PullRequest,
+	rotationRepo repositories.Rotation) (ReviewerSelector, error) {
+	switch strategy {
+	case StrategyRandom:
+		return RandomSelector{}, nil
+	case StrategyRoundRobin:
+		return &RoundRobinSelector{rotationRepo: rotationRepo}, nil
+	case StrategyLeastLoaded:
+		return &LeastLoadedSelector{prRepo: prRepo}, nil
+	case StrategyWeighted:
+		return &WeightedSelector{prRepo: prRepo}, nil
+	}
+	return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, strategy)
+}
+
+func IsKnownStrategy(strategy string) bool {
+	switch strategy {
+	case StrategyRandom, StrategyRoundRobin, StrategyLeastLoaded, StrategyWeighted:
+		return true
+	}
+	return false
+}
+
+type RandomSelector struct{}
+
+func (RandomSelector) Select(_ context.Context, _ *models.Team, candidates []int64, n int) ([]int64, error) {
+	return limit(shuffled(candidates), n), nil
+}
+
+// RoundRobinSelector hands out reviews in user ID order, starting after the
+// last user assigned in the team. The cursor is persisted per team and locked
+// for the surrounding transaction, so Select must run in the same
+// transaction as the pull request write.
+type RoundRobinSelector struct {
+	rotationRepo repositories.Rotation
+}
+
+func (s *RoundRobinSelector) Select(ctx context.Context, team *models.Team, candidates []int64, n int) ([]int64, error) {
+	if len(candidates) == 0 || n <= 0 {
+		return []int64{}, nil
+	}
+
+	cursor, err := s.rotationRepo.LockCursor(ctx, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("lock rotation cursor: %w", err)
+	}
+
+	selected := rotate(candidates, cursor, n)
+
+	if err := s.rotationRepo.AdvanceCursor(ctx, team.ID, selected[len(selected)-1]); err != nil {
+		return nil, fmt.Errorf("advance rotation cursor: %w", err)
+	}
+	return selected, nil
+}
+
+// rotate returns up to n candidates in ascending ID order, starting with the
+// first one after cursor and wrapping around.
+func rotate(candidates []int64, cursor int64, n int) []int64 {
+	ordered := make([]int64, len(candidates))
+	copy(ordered, candidates)
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i] < ordered[j]
+	})
+
+	start := sort.Search(len(ordered), func(i int) bool {
+		return ordered[i] > cursor
+	})
+
+	selected := make([]int64, 0, n)
+	for i := 0; i < len(ordered) && len(selected) < n; i++ {
+		selected = append(selected, ordered[(start+i)%len(ordered)])
+	}
+	return selected
+}
+
+// LeastLoadedSelector picks the candidates with the fewest OPEN reviews,
+// breaking ties randomly.
+type LeastLoadedSelector struct {
+	prRepo repositories.PullRequest
+}
+
+func (s *LeastLoadedSelector) Select(ctx context.Context, _ *models.Team, candidates []int64, n int) ([]int64, error) {
+	loads, err := s.prRepo.CountOpenReviews(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer load: %w", err)
+	}
+	return leastLoaded(candidates, loads, n), nil
+}
+
+// leastLoaded returns up to n of the candidates with the fewest reviews by
+// loads, breaking ties randomly.
+func leastLoaded(candidates []int64, loads map[int64]int, n int) []int64 {
+	ordered := shuffled(candidates)
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return loads[ordered[i]] < loads[ordered[j]]
+	})
+
+	return limit(ordered, n)
+}
+
+// WeightedSelector draws candidates at random with a probability inversely
+// proportional to their current OPEN review load.
+type WeightedSelector struct {
+	prRepo repositories.PullRequest
+}
+
+func (s *WeightedSelector) Select(ctx context.Context, _ *models.Team, candidates []int64, n int) ([]int64, error) {
+	loads, err := s.prRepo.CountOpenReviews(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer load: %w", err)
+	}
+	return weighted(candidates, loads, n), nil
+}
+
+// weighted draws up to n of the candidates at random, with a probability
+// inversely proportional to their review count by loads.
+func weighted(candidates []int64, loads map[int64]int, n int) []int64 {
+	pool := make([]int64, len(candidates))
+	copy(pool, candidates)
+
+	selected := make([]int64, 0, n)
+	for len(selected) < n && len(pool) > 0 {
+		var total float64
+		for _, id := range pool {
+			total += 1 / float64(1+loads[id])
+		}
+
+		pick := len(pool) - 1
+		r := rand.Float64() * total
+		for i, id := range pool {
+			r -= 1 / float64(1+loads[id])
+			if r < 0 {
+				pick = i
+				break
+			}
+		}
+
+		selected = append(selected, pool[pick])
+		pool = append(pool[:pick], pool[pick+1:]...)
+	}
+
+	return selected
+}
+
+func shuffled(ids []int64) []int64 {
+	out := make([]int64, len(ids))
+	copy(out, ids)
+	rand.Shuffle(len(out), func(i, j int) {
+		out[i], out[j] = out[j], out[i]
+	})
+	return out
+}
+
+func limit(ids []int64, n int) []int64 {
+	if len(ids) > n {
+		return ids[:n]
+	}
+	return ids
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/remote"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+)
+
+// ReviewerPublisher is told about every change of a pull request's reviewers.
+// It is called inside the transaction making the change and must take part
+// in it, like EventPublisher. removed lists the reviewers taken off the pull
+// request.
+type ReviewerPublisher interface {
+	PublishReviewers(ctx context.Context, prID int64, removed []int64) error
+}
+
+// ProviderClient writes reviewers back to a code hosting provider. Logins are
+// the provider's usernames.
+type ProviderClient interface {
+	SyncReviewers(ctx context.Context, repository string, number int, reviewers, removed []string) error
+}
+
+// eventReviewersChanged is stored in the outbox for ReviewerSync alone; it is
+// not one of the events subscribers can ask for.
+const eventReviewersChanged = "pull_request.reviewers_changed"
+
+type reviewersChangedEvent struct {
+	PullRequestID int64   `json:"pull_request_id"`
+	Removed       []int64 `json:"removed,omitempty"`
+}
+
+// ReviewerSync publishes reviewers of pull requests mirrored from a provider
+// back to that provider. Changes go through the outbox, so the provider is
+// told only about committed changes and a slow or failing provider never
+// holds up assignment. Temporary errors are left to the outbox retries; a
+// write-back the provider rejects for good, or one still failing when the
+// outbox gives up, is stored as ReviewerSyncFailure.
+type ReviewerSync struct {
+	prRepo       repositories.PullRequest
+	externalRepo repositories.ExternalPullRequest
+	failureRepo  repositories.ReviewerSyncFailure
+	identities   IdentityResolver
+	clients      map[string]ProviderClient
+	events       EventPublisher
+}
+
+// NewReviewerSync creates a ReviewerSync writing to the given clients, keyed
+// by provider. Providers without a client are skipped.
+func NewReviewerSync(prRepo repositories.PullRequest, externalRepo repositories.ExternalPullRequest,
+	failureRepo repositories.ReviewerSyncFailure, identities IdentityResolver,
+	clients map[string]ProviderClient, events EventPublisher) (*ReviewerSync, error) {
+	if prRepo == nil {
+		return nil, errors.New("prRepository cannot be nil")
+	}
+	if externalRepo == nil {
+		return nil, errors.New("externalPullRequestRepository cannot be nil")
+	}
+	if failureRepo == nil {
+		return nil, errors.New("reviewerSyncFailureRepository cannot be nil")
+	}
+	if identities == nil {
+		return nil, errors.New("identity resolver cannot be nil")
+	}
+	if events == nil {
+		return nil, errors.New("event publisher cannot be nil")
+	}
+
+	return &ReviewerSync{
+		prRepo:       prRepo,
+		externalRepo: externalRepo,
+		failureRepo:  failureRepo,
+		identities:   identities,
+		clients:      clients,
+		events:       events,
+	}, nil
+}
+
+// PublishReviewers stores the change in the outbox with ctx, so it is sent
+// only once the caller's transaction commits. Pull requests not mirrored from
+// a provider with a client are left alone.
+func (s *ReviewerSync) PublishReviewers(ctx context.Context, prID int64, removed []int64) error {
+	link, err := s.externalRepo.FindByPullRequestID(ctx, prID)
+	if errors.Is(err, pg.ErrExternalPullRequestNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if _, ok := s.clients[link.Provider]; !ok {
+		return nil
+	}
+
+	return s.events.Publish(ctx, newEvent(eventReviewersChanged,
+		reviewersChangedEvent{PullRequestID: prID, Removed: removed}))
+}
+
+func (s *ReviewerSync) Name() string {
+	return "reviewer_sync"
+}
+
+// HandleEvent writes the current reviewers of the pull request back to its
+// provider. It returns the errors a retry may fix and records the others.
+func (s *ReviewerSync) HandleEvent(ctx context.Context, eventType string, payload []byte) error {
+	if eventType != eventReviewersChanged {
+		return nil
+	}
+	var data reviewersChangedEvent
+	if err := decodeEventData(payload, &data); err != nil {
+		return nil
+	}
+
+	link, err := s.externalRepo.FindByPullRequestID(ctx, data.PullRequestID)
+	if errors.Is(err, pg.ErrExternalPullRequestNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	client, ok := s.clients[link.Provider]
+	if !ok {
+		return nil
+	}
+
+	pr, err := s.prRepo.FindByID(ctx, data.PullRequestID)
+	if err != nil {
+		return fmt.Errorf("find PR: %w", err)
+	}
+	reviewers, err := s.identities.Logins(ctx, link.Provider, pr.ReviewersIDs)
+	if err != nil {
+		return err
+	}
+	removed, err := s.identities.Logins(ctx, link.Provider, data.Removed)
+	if err != nil {
+		return err
+	}
+
+	err = client.SyncReviewers(ctx, link.Repository, link.Number, reviewers, removed)
+	if err == nil || remote.Temporary(err) {
+		return err
+	}
+	return s.failureRepo.Create(ctx, &models.ReviewerSyncFailure{
+		PullRequestID: link.PullRequestID,
+		Provider:      link.Provider,
+		Reviewers:     reviewers,
+		Removed:       removed,
+		Attempts:      1,
+		Error:         err.Error(),
+	})
+}
+
+// HandleDeadEvent records the write-back the outbox gave up on after attempts
+// temporary failures. The logins are the ones that can still be resolved.
+func (s *ReviewerSync) HandleDeadEvent(ctx context.Context, eventType string, payload []byte,
+	attempts int, cause error) error {
+	if eventType != eventReviewersChanged {
+		return nil
+	}
+	var data reviewersChangedEvent
+	if err := decodeEventData(payload, &data); err != nil {
+		return nil
+	}
+
+	link, err := s.externalRepo.FindByPullRequestID(ctx, data.PullRequestID)
+	if errors.Is(err, pg.ErrExternalPullRequestNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	failure := &models.ReviewerSyncFailure{
+		PullRequestID: link.PullRequestID,
+		Provider:      link.Provider,
+		Attempts:      attempts,
+		Error:         cause.Error(),
+	}
+	if pr, err := s.prRepo.FindByID(ctx, data.PullRequestID); err == nil {
+		failure.Reviewers, _ = s.identities.Logins(ctx, link.Provider, pr.ReviewersIDs)
+	}
+	failure.Removed, _ = s.identities.Logins(ctx, link.Provider, data.Removed)
+	return s.failureRepo.Create(ctx, failure)
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"sort"
+	"time"
+)
+
+var ErrInvalidStatsPeriod = errors.New("invalid statistics period")
+
+// maxStatsPeriod bounds the period of a filter, as throughput lists each of
+// its days.
+const maxStatsPeriod = 2 * 366 * 24 * time.Hour
+
+// StatsService reports on pull requests and review load. Everything is
+// aggregated by the database, so the cost does not grow with the rows loaded.
+type StatsService struct {
+	statsRepo repositories.Stats
+	teamRepo  repositories.Team
+	userRepo  repositories.User
+}
+
+func NewStatsService(statsRepo repositories.Stats, teamRepo repositories.Team,
+	userRepo repositories.User) (*StatsService, error) {
+	if statsRepo == nil {
+		return nil, errors.New("statsRepository cannot be nil")
+	}
+	if teamRepo == nil {
+		return nil, errors.New("teamRepository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+
+	return &StatsService{statsRepo: statsRepo, teamRepo: teamRepo, userRepo: userRepo}, nil
+}
+
+// GetStatistics sums up the pull requests created in the period of filter and
+// the reviews assigned in it. Time to merge covers the pull requests merged in
+// the period, and throughput lists every UTC day of it. Reassignments are
+// recorded only since migration 020, so earlier ones are not counted.
+func (s *StatsService) GetStatistics(ctx context.Context, filter *dtos.StatsFilter) (*dtos.StatsResponse, error) {
+	f, err := s.modelFilter(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	stats, err := s.statsRepo.GetPullRequestStats(ctx, *f)
+	if err != nil {
+		return nil, fmt.Errorf("get pr stats: %w", err)
+	}
+	throughput, err := s.statsRepo.GetThroughput(ctx, *f)
+	if err != nil {
+		return nil, fmt.Errorf("get throughput: %w", err)
+	}
+	assignments, err := s.statsRepo.GetReviewerAssignments(ctx, *f)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer stats: %w", err)
+	}
+
+	days := make([]dtos.DailyThroughput, 0, len(throughput))
+	for _, d := range throughput {
+		days = append(days, dtos.DailyThroughput{
+			Date:   d.Day.Format("2006-01-02"),
+			Opened: d.Opened,
+			Merged: d.Merged,
+		})
+	}
+	reviewerStats := make([]dtos.ReviewerStats, 0, len(assignments))
+	for _, a := range assignments {
+		reviewerStats = append(reviewerStats, dtos.ReviewerStats{
+			ReviewerID:    encoding.EncodeID(a.ReviewerID),
+			Username:      a.Username,
+			AssignedCount: a.Assigned,
+		})
+	}
+
+	return &dtos.StatsResponse{
+		From:               filter.From,
+		To:                 filter.To,
+		TeamName:           filter.TeamName,
+		TotalPullRequests:  stats.Total,
+		DraftPullRequests:  stats.Draft,
+		OpenPullRequests:   stats.Open,
+		MergedPullRequests: stats.Merged,
+		ClosedPullRequests: stats.Closed,
+		TimeToFirstReview:  toLatencyStats(stats.FirstReview),
+		TimeToMerge:        toLatencyStats(stats.Merge),
+		Reassignments: dtos.ReassignmentStats{
+			Total:        stats.Reassignments,
+			PullRequests: stats.ReassignedPullRequests,
+		},
+		Throughput:    days,
+		ReviewerStats: reviewerStats,
+	}, nil
+}
+
+// GetTeamWorkloads returns the review workload of every reviewer of the team
+// named teamName, or of every team if it is empty. The fairness of a team is
+// the Gini coefficient of the reviews assigned to its active reviewers and to
+// anyone else who was assigned some: 0 when everybody got as many, nearing 1
+// when a single reviewer got them all.
+func (s *StatsService) GetTeamWorkloads(ctx context.Context, teamName string) ([]*dtos.TeamWorkload, error) {
+	var teamID *int64
+	if teamName != "" {
+		team, err := s.teamRepo.FindByName(ctx, teamName)
+		if errors.Is(err, pg.ErrTeamNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("find team: %w", err)
+		}
+		teamID = &team.ID
+	}
+
+	loads, err := s.statsRepo.GetReviewerLoads(ctx, teamID, nil)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer loads: %w", err)
+	}
+
+	var workloads []*dtos.TeamWorkload
+	assigned := make(map[string][]int)
+	for _, l := range loads {
+		if len(workloads) == 0 || workloads[len(workloads)-1].TeamName != l.TeamName {
+			workloads = append(workloads, &dtos.TeamWorkload{TeamName: l.TeamName})
+		}
+		w := workloads[len(workloads)-1]
+		w.Reviewers = append(w.Reviewers, dtos.ReviewerLoad{
+			ReviewerID:                encoding.EncodeID(l.ReviewerID),
+			Username:                  l.Username,
+			IsActive:                  l.IsActive,
+			OpenReviews:               l.Open,
+			MergedReviews:             l.Merged,
+			AssignedReviews:           l.Assigned,
+			AverageOpenReviewAgeHours: l.AverageOpenAgeHours,
+		})
+		if l.IsActive || l.Assigned > 0 {
+			assigned[l.TeamName] = append(assigned[l.TeamName], l.Assigned)
+		}
+	}
+	for _, w := range workloads {
+		w.AssignmentsGini = gini(assigned[w.TeamName])
+	}
+	if len(workloads) == 0 && teamName != "" {
+		workloads = append(workloads, &dtos.TeamWorkload{TeamName: teamName, Reviewers: []dtos.ReviewerLoad{}})
+	}
+	return workloads, nil
+}
+
+// GetReviewerWorkload returns the review workload of the user in each team
+// they review for, with their share of the reviews assigned in the team.
+func (s *StatsService) GetReviewerWorkload(ctx context.Context, reviewerID int64) (*dtos.ReviewerWorkload, error) {
+	user, err := s.userRepo.FindByID(ctx, reviewerID)
+	if errors.Is(err, pg.ErrUserNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find user: %w", err)
+	}
+
+	loads, err := s.statsRepo.GetReviewerLoads(ctx, nil, &reviewerID)
+	if err != nil {
+		return nil, fmt.Errorf("get reviewer loads: %w", err)
+	}
+
+	workload := &dtos.ReviewerWorkload{
+		ReviewerID: encoding.EncodeID(user.ID),
+		Username:   user.Username,
+		Teams:      make([]dtos.ReviewerTeamLoad, 0, len(loads)),
+	}
+	for _, l := range loads {
+		var share float64
+		if l.TeamAssigned > 0 {
+			share = float64(l.Assigned) / float64(l.TeamAssigned)
+		}
+		workload.Teams = append(workload.Teams, dtos.ReviewerTeamLoad{
+			TeamName:                  l.TeamName,
+			OpenReviews:               l.Open,
+			MergedReviews:             l.Merged,
+			AssignedReviews:           l.Assigned,
+			AverageOpenReviewAgeHours: l.AverageOpenAgeHours,
+			AssignmentShare:           share,
+		})
+		workload.OpenReviews += l.Open
+		workload.MergedReviews += l.Merged
+		workload.AssignedReviews += l.Assigned
+	}
+	return workload, nil
+}
+
+// ExportPullRequests calls fn for each pull request created in the period of
+// filter, oldest first, as the rows are read. The filter is checked before fn
+// is first called, so an error returned before then is the caller's.
+func (s *StatsService) ExportPullRequests(ctx context.Context, filter *dtos.StatsFilter,
+	fn func(*dtos.PullRequestExport) error) error {
+	f, err := s.modelFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return s.statsRepo.ExportPullRequests(ctx, *f, func(pr *models.PullRequestExport) error {
+		return fn(dtos.ModelToPullRequestExportDTO(pr))
+	})
+}
+
+// ExportReviewAssignments is ExportPullRequests for the reviews assigned on the
+// pull requests, including those of the reviewers who were replaced.
+func (s *StatsService) ExportReviewAssignments(ctx context.Context, filter *dtos.StatsFilter,
+	fn func(*dtos.ReviewAssignmentExport) error) error {
+	f, err := s.modelFilter(ctx, filter)
+	if err != nil {
+		return err
+	}
+
+	return s.statsRepo.ExportReviewAssignments(ctx, *f, func(a *models.ReviewAssignmentExport) error {
+		return fn(dtos.ModelToReviewAssignmentExportDTO(a))
+	})
+}
+
+func (s *StatsService) modelFilter(ctx context.Context, filter *dtos.StatsFilter) (*models.StatsFilter, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, fmt.Errorf("%w: from must be before to", ErrInvalidStatsPeriod)
+	}
+
+	// Without from the period starts with the first pull request, so only an
+	// end far in the future can stretch it.
+	now := time.Now()
+	end := now
+	if filter.To != nil {
+		end = *filter.To
+	}
+	if (filter.From != nil && end.Sub(*filter.From) > maxStatsPeriod) || end.Sub(now) > maxStatsPeriod {
+		return nil, fmt.Errorf("%w: period must not be longer than %d days", ErrInvalidStatsPeriod,
+			maxStatsPeriod/(24*time.Hour))
+	}
+
+	f := &models.StatsFilter{From: filter.From, To: filter.To}
+	if filter.TeamName != "" {
+		team, err := s.teamRepo.FindByName(ctx, filter.TeamName)
+		if errors.Is(err, pg.ErrTeamNotFound) {
+			return nil, ErrTeamNotFound
+		}
+		if err != nil {
+			return nil, fmt.Errorf("find team: %w", err)
+		}
+		f.TeamID = &team.ID
+	}
+	return f, nil
+}
+
+func toLatencyStats(l models.Latency) dtos.LatencyStats {
+	stats := dtos.LatencyStats{Count: l.Count}
+	if l.Count > 0 && len(l.Percentiles) == 3 {
+		stats.P50Hours = &l.Percentiles[0]
+		stats.P90Hours = &l.Percentiles[1]
+		stats.P99Hours = &l.Percentiles[2]
+	}
+	return stats
+}
+
+// gini returns the Gini coefficient of counts, 0 for no counts or only zeros.
+func gini(counts []int) float64 {
+	sorted := append([]int(nil), counts...)
+	sort.Ints(sorted)
+
+	n := len(sorted)
+	sum, weighted := 0, 0
+	for i, c := range sorted {
+		sum += c
+		weighted += (i + 1) * c
+	}
+	if n == 0 || sum == 0 {
+		return 0
+	}
+	return 2*float64(weighted)/(float64(n)*float64(sum)) - float64(n+1)/float64(n)
+}
@@ -0,0 +1,334 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/remote"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"pullrequest-inator/internal/infrastructure/secrets"
+	"time"
+	"unicode/utf8"
+)
+
+var ErrInvalidSubscription = errors.New("invalid webhook subscription")
+
+// EventSender posts a signed event body to a subscriber. It returns the
+// response status, or 0 when there was no response.
+type EventSender interface {
+	Send(ctx context.Context, url, secret, deliveryID, eventType string, body []byte) (int, error)
+}
+
+// URLChecker tells whether events may be sent to a URL.
+type URLChecker interface {
+	CheckURL(ctx context.Context, rawURL string) error
+}
+
+const (
+	eventDeliveryTimeout = time.Minute
+	// eventDeliveryAttempts is how many times a delivery is attempted before
+	// it is marked failed.
+	eventDeliveryAttempts = 5
+	maxSubscriptionSecret = 1024
+)
+
+// SubscriptionService manages webhook subscriptions and is the EventSink
+// delivering events to them. Deliveries are sent while the outbox hands the
+// event over and retried with its retries, and every attempt is recorded on
+// the WebhookDelivery so it can be inspected and redelivered. Subscription
+// secrets are stored sealed by box.
+type SubscriptionService struct {
+	subscriptionRepo repositories.WebhookSubscription
+	deliveryRepo     repositories.WebhookDelivery
+	sender           EventSender
+	urls             URLChecker
+	box              *secrets.Box
+}
+
+func NewSubscriptionService(subscriptionRepo repositories.WebhookSubscription,
+	deliveryRepo repositories.WebhookDelivery, sender EventSender, urls URLChecker,
+	box *secrets.Box) (*SubscriptionService, error) {
+	if subscriptionRepo == nil {
+		return nil, errors.New("webhookSubscriptionRepository cannot be nil")
+	}
+	if deliveryRepo == nil {
+		return nil, errors.New("webhookDeliveryRepository cannot be nil")
+	}
+	if sender == nil {
+		return nil, errors.New("event sender cannot be nil")
+	}
+	if urls == nil {
+		return nil, errors.New("url checker cannot be nil")
+	}
+	if box == nil {
+		return nil, errors.New("secret box cannot be nil")
+	}
+
+	return &SubscriptionService{
+		subscriptionRepo: subscriptionRepo,
+		deliveryRepo:     deliveryRepo,
+		sender:           sender,
+		urls:             urls,
+		box:              box,
+	}, nil
+}
+
+// CreateSubscription registers rawURL to receive events of the given types,
+// or of every type when events is empty, signed with secret. The URL must not
+// point at an internal address.
+func (s *SubscriptionService) CreateSubscription(ctx context.Context, rawURL, secret string,
+	events []string) (*dtos.WebhookSubscription, error) {
+	if err := s.urls.CheckURL(ctx, rawURL); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSubscription, err)
+	}
+	if secret == "" {
+		return nil, fmt.Errorf("%w: secret is required", ErrInvalidSubscription)
+	}
+	if utf8.RuneCountInString(secret) > maxSubscriptionSecret {
+		return nil, fmt.Errorf("%w: secret must not be longer than %d characters", ErrInvalidSubscription,
+			maxSubscriptionSecret)
+	}
+
+	filter := make([]string, 0, len(events))
+	seen := make(map[string]bool, len(events))
+	for _, event := range events {
+		if !IsKnownEvent(event) {
+			return nil, fmt.Errorf("%w: unknown event %q", ErrInvalidSubscription, event)
+		}
+		if !seen[event] {
+			seen[event] = true
+			filter = append(filter, event)
+		}
+	}
+
+	sealed, err := s.box.Seal(secret)
+	if err != nil {
+		return nil, fmt.Errorf("seal secret: %w", err)
+	}
+	subscription := &models.WebhookSubscription{URL: rawURL, Secret: sealed, Events: filter}
+	if err := s.subscriptionRepo.Create(ctx, subscription); err != nil {
+		return nil, fmt.Errorf("create subscription: %w", err)
+	}
+
+	return toWebhookSubscriptionDTO(subscription), nil
+}
+
+// SealStoredSecrets seals the secrets stored before they were encrypted.
+func (s *SubscriptionService) SealStoredSecrets(ctx context.Context) error {
+	subscriptions, err := s.subscriptionRepo.FindAll(ctx)
+	if err != nil {
+		return fmt.Errorf("find subscriptions: %w", err)
+	}
+
+	for _, subscription := range subscriptions {
+		if secrets.IsSealed(subscription.Secret) {
+			continue
+		}
+		sealed, err := s.box.Seal(subscription.Secret)
+		if err != nil {
+			return fmt.Errorf("seal secret: %w", err)
+		}
+		if err := s.subscriptionRepo.UpdateSecret(ctx, subscription.ID, sealed); err != nil {
+			return fmt.Errorf("update secret: %w", err)
+		}
+	}
+	return nil
+}
+
+func (s *SubscriptionService) ListSubscriptions(ctx context.Context) ([]*dtos.WebhookSubscription, error) {
+	subscriptions, err := s.subscriptionRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("find subscriptions: %w", err)
+	}
+
+	out := make([]*dtos.WebhookSubscription, len(subscriptions))
+	for i, subscription := range subscriptions {
+		out[i] = toWebhookSubscriptionDTO(subscription)
+	}
+	return out, nil
+}
+
+func (s *SubscriptionService) DeleteSubscription(ctx context.Context, id int64) error {
+	err := s.subscriptionRepo.DeleteByID(ctx, id)
+	if errors.Is(err, pg.ErrWebhookSubscriptionNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete subscription: %w", err)
+	}
+	return nil
+}
+
+// ListDeliveries returns the delivery log of the subscription, newest first.
+func (s *SubscriptionService) ListDeliveries(ctx context.Context, subscriptionID int64) ([]*dtos.WebhookDelivery, error) {
+	if _, err := s.subscriptionRepo.FindByID(ctx, subscriptionID); errors.Is(err, pg.ErrWebhookSubscriptionNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("find subscription: %w", err)
+	}
+
+	deliveries, err := s.deliveryRepo.FindBySubscriptionID(ctx, subscriptionID)
+	if err != nil {
+		return nil, fmt.Errorf("find deliveries: %w", err)
+	}
+
+	out := make([]*dtos.WebhookDelivery, len(deliveries))
+	for i, delivery := range deliveries {
+		out[i] = toWebhookDeliveryDTO(delivery)
+	}
+	return out, nil
+}
+
+// Redeliver sends a logged delivery again, once and right away, with the same
+// body and delivery ID, and returns it with the outcome.
+func (s *SubscriptionService) Redeliver(ctx context.Context, deliveryID int64) (*dtos.WebhookDelivery, error) {
+	delivery, err := s.deliveryRepo.FindByID(ctx, deliveryID)
+	if errors.Is(err, pg.ErrWebhookDeliveryNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("find delivery: %w", err)
+	}
+
+	subscription, err := s.subscriptionRepo.FindByID(ctx, delivery.SubscriptionID)
+	if errors.Is(err, pg.ErrWebhookSubscriptionNotFound) {
+		return nil, ErrNotFound
+	} else if err != nil {
+		return nil, fmt.Errorf("find subscription: %w", err)
+	}
+
+	if err := s.attempt(ctx, subscription, delivery); err != nil {
+		delivery.Status = models.DeliveryFailed
+	}
+	if err := s.deliveryRepo.RecordAttempt(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("record delivery attempt: %w", err)
+	}
+
+	return toWebhookDeliveryDTO(delivery), nil
+}
+
+func (s *SubscriptionService) Name() string {
+	return "webhooks"
+}
+
+// HandleEvent sends the event to every subscription interested in it,
+// logging a delivery for each. It fails when a delivery could not be logged
+// or failed for a temporary reason, so the outbox hands the event over again;
+// subscriptions whose delivery was logged as delivered or failed are skipped
+// then.
+func (s *SubscriptionService) HandleEvent(ctx context.Context, eventType string, payload []byte) error {
+	if !IsKnownEvent(eventType) {
+		return nil
+	}
+	subscriptions, err := s.subscriptionRepo.FindByEvent(ctx, eventType)
+	if err != nil {
+		return fmt.Errorf("find subscriptions: %w", err)
+	}
+
+	var errs []error
+	for _, subscription := range subscriptions {
+		delivery, err := s.deliveryRepo.FindByEvent(ctx, subscription.ID, eventType, payload)
+		if errors.Is(err, pg.ErrWebhookDeliveryNotFound) {
+			delivery = &models.WebhookDelivery{
+				SubscriptionID: subscription.ID,
+				EventType:      eventType,
+				Payload:        payload,
+			}
+			if err := s.deliveryRepo.Create(ctx, delivery); err != nil {
+				return fmt.Errorf("log delivery: %w", err)
+			}
+		} else if err != nil {
+			return fmt.Errorf("find delivery: %w", err)
+		}
+		if delivery.Status != models.DeliveryPending {
+			continue
+		}
+
+		if err := s.deliver(ctx, subscription, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("deliver to subscription %d: %w", subscription.ID, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// deliver attempts the delivery once and records the attempt. It returns the
+// error of an attempt worth retrying; a delivery rejected by the subscriber
+// as final or out of attempts is marked failed instead.
+func (s *SubscriptionService) deliver(ctx context.Context, subscription *models.WebhookSubscription,
+	delivery *models.WebhookDelivery) error {
+	attemptCtx, cancel := context.WithTimeout(ctx, eventDeliveryTimeout)
+	defer cancel()
+
+	err := s.attempt(attemptCtx, subscription, delivery)
+	retry := err != nil && remote.Temporary(err) && delivery.Attempts < eventDeliveryAttempts
+	if err != nil && !retry {
+		delivery.Status = models.DeliveryFailed
+	}
+	if err := s.deliveryRepo.RecordAttempt(context.WithoutCancel(ctx), delivery); err != nil {
+		return fmt.Errorf("record delivery attempt: %w", err)
+	}
+	if retry {
+		return err
+	}
+	return nil
+}
+
+// attempt sends the delivery once and updates it with the outcome, leaving it
+// pending when the attempt failed.
+func (s *SubscriptionService) attempt(ctx context.Context, subscription *models.WebhookSubscription,
+	delivery *models.WebhookDelivery) error {
+	secret, err := s.box.Open(subscription.Secret)
+	if err != nil {
+		return fmt.Errorf("open secret: %w", err)
+	}
+	status, err := s.sender.Send(ctx, subscription.URL, secret, encoding.EncodeID(delivery.ID),
+		delivery.EventType, delivery.Payload)
+
+	delivery.Attempts++
+	delivery.ResponseStatus = nil
+	if status != 0 {
+		delivery.ResponseStatus = &status
+	}
+	if err != nil {
+		msg := err.Error()
+		delivery.Status = models.DeliveryPending
+		delivery.LastError = &msg
+		return err
+	}
+
+	now := time.Now()
+	delivery.Status = models.DeliveryDelivered
+	delivery.LastError = nil
+	delivery.DeliveredAt = &now
+	return nil
+}
+
+func toWebhookSubscriptionDTO(s *models.WebhookSubscription) *dtos.WebhookSubscription {
+	return &dtos.WebhookSubscription{
+		Id:        encoding.EncodeID(s.ID),
+		Url:       s.URL,
+		Events:    s.Events,
+		CreatedAt: s.CreatedAt,
+	}
+}
+
+func toWebhookDeliveryDTO(d *models.WebhookDelivery) *dtos.WebhookDelivery {
+	dto := &dtos.WebhookDelivery{
+		Id:             encoding.EncodeID(d.ID),
+		SubscriptionId: encoding.EncodeID(d.SubscriptionID),
+		Event:          d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		ResponseStatus: d.ResponseStatus,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
+		DeliveredAt:    d.DeliveredAt,
+	}
+	if d.LastError != nil {
+		dto.LastError = *d.LastError
+	}
+	return dto
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"pullrequest-inator/internal/api/dtos"
 	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
 	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
 	"pullrequest-inator/internal/infrastructure/repositories/pg"
 )
@@ -14,24 +15,50 @@ var (
 	ErrTeamExists      = errors.New("team already exists")
 	ErrNotFound        = errors.New("resource not found")
 	ErrFalseUserInTeam = errors.New("detected deleted user in team")
+	ErrInvalidSettings = errors.New("invalid team settings")
 )
 
+// ReviewReassigner moves the OPEN reviews of the given users to other
+// candidates. It must take part in the transaction carried by ctx.
+type ReviewReassigner interface {
+	ReassignOpenReviews(ctx context.Context, userIDs []int64) (*dtos.ReviewReassignment, error)
+}
+
 type TeamService struct {
-	teamRepo repositories.Team
-	userRepo repositories.User
+	teamRepo   repositories.Team
+	userRepo   repositories.User
+	tx         repositories.Transactor
+	reassigner ReviewReassigner
+	events     EventPublisher
+	limits     ReviewerLimits
 }
 
-func NewTeamService(teamRepo repositories.Team, userRepo repositories.User) (*TeamService, error) {
+func NewTeamService(teamRepo repositories.Team, userRepo repositories.User,
+	tx repositories.Transactor, reassigner ReviewReassigner, events EventPublisher,
+	limits ReviewerLimits) (*TeamService, error) {
 	if teamRepo == nil {
 		return nil, errors.New("teamRepository cannot be nil")
 	}
 	if userRepo == nil {
 		return nil, errors.New("userRepository cannot be nil")
 	}
+	if tx == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
+	if reassigner == nil {
+		return nil, errors.New("reassigner cannot be nil")
+	}
+	if events == nil {
+		return nil, errors.New("event publisher cannot be nil")
+	}
 
 	return &TeamService{
-		teamRepo: teamRepo,
-		userRepo: userRepo,
+		teamRepo:   teamRepo,
+		userRepo:   userRepo,
+		tx:         tx,
+		reassigner: reassigner,
+		events:     events,
+		limits:     limits,
 	}, nil
 }
 
@@ -40,7 +67,12 @@ func (s *TeamService) CreateTeamWithUsers(ctx context.Context, teamReq *dtos.Tea
 		return ErrTeamExists
 	}
 
-	return s.teamRepo.CreateWithUsers(ctx, teamReq)
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.teamRepo.CreateWithUsers(ctx, teamReq); err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, newEvent(EventTeamCreated, teamReq))
+	})
 }
 
 func (s *TeamService) GetTeamByName(ctx context.Context, teamName string) (*dtos.Team, error) {
@@ -75,33 +107,180 @@ func (s *TeamService) GetTeamByName(ctx context.Context, teamName string) (*dtos
 	}, nil
 }
 
-func (s *TeamService) SetUserActiveByID(ctx context.Context, userID int64, active bool) (*dtos.User, error) {
+// SetUserActiveByID updates the activity flag of the user. When the user is
+// deactivated with reassignOpenReviews set, their OPEN reviews are moved to
+// other candidates in the same transaction and the result is returned;
+// otherwise the returned reassignment is nil.
+func (s *TeamService) SetUserActiveByID(ctx context.Context, userID int64, active bool,
+	reassignOpenReviews bool) (*dtos.User, *dtos.ReviewReassignment, error) {
 	user, err := s.userRepo.FindByID(ctx, userID)
 	if errors.Is(err, pg.ErrUserNotFound) {
-		return nil, ErrNotFound
+		return nil, nil, ErrNotFound
 	}
 	if err != nil {
-		return nil, fmt.Errorf("find user: %w", err)
+		return nil, nil, fmt.Errorf("find user: %w", err)
 	}
 
-	user.IsActive = active
-	if err := s.userRepo.Update(ctx, user); err != nil {
-		return nil, fmt.Errorf("update user: %w", err)
+	var reassignment *dtos.ReviewReassignment
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		user.IsActive = active
+		if err := s.userRepo.Update(ctx, user); err != nil {
+			return fmt.Errorf("update user: %w", err)
+		}
+
+		if active || !reassignOpenReviews {
+			return nil
+		}
+		reassignment, err = s.reassigner.ReassignOpenReviews(ctx, []int64{userID})
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
 	}
 
-	team, err := s.teamRepo.FindByUserID(ctx, userID)
-	var teamName string
-	if err != nil && !errors.Is(err, pg.ErrTeamNotFound) {
-		return nil, fmt.Errorf("find team for user: %w", err)
+	teams, err := s.teamRepo.FindAllByUserID(ctx, userID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("find teams for user: %w", err)
 	}
-	if team != nil {
-		teamName = team.Name
+
+	teamNames := make([]string, len(teams))
+	for i, t := range teams {
+		teamNames[i] = t.Name
 	}
 
 	return &dtos.User{
-		UserId:   encoding.EncodeID(user.ID),
-		Username: user.Username,
-		IsActive: user.IsActive,
-		TeamName: teamName,
+		UserId:    encoding.EncodeID(user.ID),
+		Username:  user.Username,
+		IsActive:  user.IsActive,
+		TeamNames: teamNames,
+	}, reassignment, nil
+}
+
+func (s *TeamService) GetTeamSettings(ctx context.Context, teamName string) (*dtos.TeamSettings, error) {
+	team, err := s.teamRepo.FindByName(ctx, teamName)
+	if errors.Is(err, pg.ErrTeamNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find team: %w", err)
+	}
+
+	return s.teamSettingsDTO(ctx, team)
+}
+
+func (s *TeamService) UpdateTeamSettings(ctx context.Context, req *dtos.TeamSettingsUpdate) (*dtos.TeamSettings, error) {
+	team, err := s.teamRepo.FindByName(ctx, req.TeamName)
+	if errors.Is(err, pg.ErrTeamNotFound) {
+		return nil, ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("find team: %w", err)
+	}
+
+	if req.ReviewerStrategy != nil {
+		if !IsKnownStrategy(*req.ReviewerStrategy) {
+			return nil, fmt.Errorf("%w: %w: %q", ErrInvalidSettings, ErrUnknownStrategy, *req.ReviewerStrategy)
+		}
+		team.ReviewerStrategy = *req.ReviewerStrategy
+	}
+	if req.ResetMinReviewers {
+		team.MinReviewers = nil
+	} else if req.MinReviewers != nil {
+		team.MinReviewers = req.MinReviewers
+	}
+	if req.ResetMaxReviewers {
+		team.MaxReviewers = nil
+	} else if req.MaxReviewers != nil {
+		team.MaxReviewers = req.MaxReviewers
+	}
+	if req.RequiredApprovals != nil {
+		team.RequiredApprovals = *req.RequiredApprovals
+	}
+	limits := s.limits.teamLimits(team)
+	if err := limits.Validate(); err != nil {
+		return nil, err
+	}
+	if team.RequiredApprovals < 0 || team.RequiredApprovals > limits.Max {
+		return nil, fmt.Errorf("%w: required approvals must be between 0 and max reviewers (%d)",
+			ErrInvalidSettings, limits.Max)
+	}
+
+	var backupIDs []int64
+	if req.BackupTeams != nil {
+		backupIDs, err = s.resolveBackupTeams(ctx, team, *req.BackupTeams)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var settings *dtos.TeamSettings
+	err = s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		if err := s.teamRepo.UpdateSettings(ctx, team); err != nil {
+			return fmt.Errorf("update team settings: %w", err)
+		}
+
+		if req.BackupTeams != nil {
+			if err := s.teamRepo.SetBackupTeams(ctx, team.ID, backupIDs); err != nil {
+				return fmt.Errorf("update backup teams: %w", err)
+			}
+		}
+
+		settings, err = s.teamSettingsDTO(ctx, team)
+		if err != nil {
+			return err
+		}
+		return s.events.Publish(ctx, newEvent(EventTeamSettingsUpdated, settings))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return settings, nil
+}
+
+func (s *TeamService) resolveBackupTeams(ctx context.Context, team *models.Team, names []string) ([]int64, error) {
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		if name == team.Name {
+			return nil, fmt.Errorf("%w: team cannot be its own backup", ErrInvalidSettings)
+		}
+
+		backup, err := s.teamRepo.FindByName(ctx, name)
+		if errors.Is(err, pg.ErrTeamNotFound) {
+			return nil, fmt.Errorf("%w: backup team %q not found", ErrInvalidSettings, name)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("find backup team: %w", err)
+		}
+
+		for _, id := range ids {
+			if id == backup.ID {
+				return nil, fmt.Errorf("%w: backup team %q listed twice", ErrInvalidSettings, name)
+			}
+		}
+		ids = append(ids, backup.ID)
+	}
+	return ids, nil
+}
+
+func (s *TeamService) teamSettingsDTO(ctx context.Context, team *models.Team) (*dtos.TeamSettings, error) {
+	backups, err := s.teamRepo.FindBackupTeams(ctx, team.ID)
+	if err != nil {
+		return nil, fmt.Errorf("find backup teams: %w", err)
+	}
+
+	backupNames := make([]string, len(backups))
+	for i, backup := range backups {
+		backupNames[i] = backup.Name
+	}
+
+	limits := s.limits.ForTeam(team)
+	return &dtos.TeamSettings{
+		TeamName:          team.Name,
+		ReviewerStrategy:  team.ReviewerStrategy,
+		MinReviewers:      limits.Min,
+		MaxReviewers:      limits.Max,
+		RequiredApprovals: team.RequiredApprovals,
+		BackupTeams:       backupNames,
 	}, nil
 }
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/calendar"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"time"
+)
+
+var (
+	ErrInvalidPeriod   = errors.New("invalid unavailability period")
+	ErrInvalidCalendar = errors.New("invalid calendar file")
+)
+
+// calendarImportHorizon is how far ahead the occurrences of recurring events
+// are imported.
+const calendarImportHorizon = 366 * 24 * time.Hour
+
+type UnavailabilityService struct {
+	unavailabilityRepo repositories.Unavailability
+	userRepo           repositories.User
+}
+
+func NewUnavailabilityService(unavailabilityRepo repositories.Unavailability,
+	userRepo repositories.User) (*UnavailabilityService, error) {
+	if unavailabilityRepo == nil {
+		return nil, errors.New("unavailabilityRepository cannot be nil")
+	}
+	if userRepo == nil {
+		return nil, errors.New("userRepository cannot be nil")
+	}
+	return &UnavailabilityService{unavailabilityRepo: unavailabilityRepo, userRepo: userRepo}, nil
+}
+
+func (s *UnavailabilityService) AddPeriod(ctx context.Context, userID int64, startsAt, endsAt time.Time,
+	reason string) (*dtos.Unavailability, error) {
+	if !endsAt.After(startsAt) {
+		return nil, fmt.Errorf("%w: ends_at must be after starts_at", ErrInvalidPeriod)
+	}
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	period := &models.Unavailability{
+		UserID:   userID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Reason:   reason,
+		Source:   models.UnavailabilitySourceManual,
+	}
+	if err := s.unavailabilityRepo.Create(ctx, period); err != nil {
+		return nil, fmt.Errorf("create unavailability: %w", err)
+	}
+
+	return toUnavailabilityDTO(period), nil
+}
+
+func (s *UnavailabilityService) ListPeriods(ctx context.Context, userID int64) ([]*dtos.Unavailability, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	periods, err := s.unavailabilityRepo.FindByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("find unavailability: %w", err)
+	}
+
+	out := make([]*dtos.Unavailability, len(periods))
+	for i, p := range periods {
+		out[i] = toUnavailabilityDTO(p)
+	}
+	return out, nil
+}
+
+func (s *UnavailabilityService) DeletePeriod(ctx context.Context, userID int64, periodID int64) error {
+	err := s.unavailabilityRepo.DeleteByID(ctx, userID, periodID)
+	if errors.Is(err, pg.ErrUnavailabilityNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("delete unavailability: %w", err)
+	}
+	return nil
+}
+
+// ImportCalendar syncs the user's imported periods with the events of an
+// iCalendar export: events are matched by UID, events that already ended are
+// skipped, and previously imported periods missing from the file are removed.
+// Recurring events are imported as one period per occurrence within a year.
+// Manually created periods are never touched.
+func (s *UnavailabilityService) ImportCalendar(ctx context.Context, userID int64,
+	r io.Reader) (*dtos.CalendarImportResult, error) {
+	if err := s.ensureUser(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	events, err := calendar.Parse(r, now.Add(calendarImportHorizon))
+	if errors.Is(err, calendar.ErrInvalidCalendar) {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidCalendar, err)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("parse calendar: %w", err)
+	}
+
+	periods := make([]*models.Unavailability, 0, len(events))
+	for _, e := range events {
+		if !e.End.After(now) {
+			continue
+		}
+		uid := e.UID
+		periods = append(periods, &models.Unavailability{
+			UserID:      userID,
+			StartsAt:    e.Start,
+			EndsAt:      e.End,
+			Reason:      e.Summary,
+			Source:      models.UnavailabilitySourceICS,
+			ExternalUID: &uid,
+		})
+	}
+
+	removed, err := s.unavailabilityRepo.SyncImported(ctx, userID, periods)
+	if err != nil {
+		return nil, fmt.Errorf("sync imported unavailability: %w", err)
+	}
+
+	return &dtos.CalendarImportResult{
+		UserId:   encoding.EncodeID(userID),
+		Imported: len(periods),
+		Removed:  int(removed),
+	}, nil
+}
+
+func (s *UnavailabilityService) ensureUser(ctx context.Context, userID int64) error {
+	_, err := s.userRepo.FindByID(ctx, userID)
+	if errors.Is(err, pg.ErrUserNotFound) {
+		return ErrNotFound
+	}
+	if err != nil {
+		return fmt.Errorf("find user: %w", err)
+	}
+	return nil
+}
+
+func toUnavailabilityDTO(p *models.Unavailability) *dtos.Unavailability {
+	return &dtos.Unavailability{
+		Id:       encoding.EncodeID(p.ID),
+		UserId:   encoding.EncodeID(p.UserID),
+		StartsAt: p.StartsAt,
+		EndsAt:   p.EndsAt,
+		Reason:   p.Reason,
+		Source:   p.Source,
+	}
+}
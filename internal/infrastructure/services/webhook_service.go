@@ -0,0 +1,392 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"pullrequest-inator/internal/api/dtos"
+	"pullrequest-inator/internal/infrastructure/encoding"
+	"pullrequest-inator/internal/infrastructure/github"
+	"pullrequest-inator/internal/infrastructure/gitlab"
+	"pullrequest-inator/internal/infrastructure/models"
+	"pullrequest-inator/internal/infrastructure/repositories/interfaces"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+)
+
+var (
+	ErrInvalidSignature = errors.New("webhook signature or token rejected")
+	ErrInvalidPayload   = errors.New("malformed webhook payload")
+)
+
+const (
+	WebhookProcessed = "processed"
+	WebhookIgnored   = "ignored"
+)
+
+// PullRequestLifecycle is the part of PullRequestService driven by provider
+// webhooks.
+type PullRequestLifecycle interface {
+	CreateWithReviewers(ctx context.Context, prID int64, prName string, authorID int64, teamName string,
+		draft bool) (*dtos.PullRequest, error)
+	MarkReady(ctx context.Context, prID int64) (*dtos.PullRequest, error)
+	MarkDraft(ctx context.Context, prID int64) (*dtos.PullRequest, error)
+	RenamePullRequest(ctx context.Context, prID int64, title string) (*dtos.PullRequest, error)
+	RecordExternalMerge(ctx context.Context, prID int64, reason string, mergedBy *int64) (*dtos.PullRequest, error)
+	ClosePullRequest(ctx context.Context, prID int64) (*dtos.PullRequest, error)
+	ReopenPullRequest(ctx context.Context, prID int64) (*dtos.PullRequest, error)
+}
+
+// GitLabUsers looks up GitLab usernames by user ID.
+type GitLabUsers interface {
+	Username(ctx context.Context, id int) (string, error)
+}
+
+type WebhookConfig struct {
+	GitHubSecret string
+	GitLabToken  string
+}
+
+type WebhookService struct {
+	prService    PullRequestLifecycle
+	identities   IdentityResolver
+	externalRepo repositories.ExternalPullRequest
+	publisher    ReviewerPublisher
+	tx           repositories.Transactor
+	gitlabUsers  GitLabUsers
+	config       WebhookConfig
+}
+
+// NewWebhookService creates a WebhookService. gitlabUsers may be nil when no
+// GitLab API is configured; GitLab users are then only known by name when they
+// triggered the event themselves.
+func NewWebhookService(prService PullRequestLifecycle, identities IdentityResolver,
+	externalRepo repositories.ExternalPullRequest, publisher ReviewerPublisher, tx repositories.Transactor,
+	gitlabUsers GitLabUsers, config WebhookConfig) (*WebhookService, error) {
+	if prService == nil {
+		return nil, errors.New("prService cannot be nil")
+	}
+	if identities == nil {
+		return nil, errors.New("identity resolver cannot be nil")
+	}
+	if externalRepo == nil {
+		return nil, errors.New("externalPullRequestRepository cannot be nil")
+	}
+	if publisher == nil {
+		return nil, errors.New("reviewer publisher cannot be nil")
+	}
+	if tx == nil {
+		return nil, errors.New("transactor cannot be nil")
+	}
+
+	return &WebhookService{
+		prService:    prService,
+		identities:   identities,
+		externalRepo: externalRepo,
+		publisher:    publisher,
+		tx:           tx,
+		gitlabUsers:  gitlabUsers,
+		config:       config,
+	}, nil
+}
+
+// externalAction is what a provider event means for the mirrored pull request.
+type externalAction int
+
+const (
+	externalOpen externalAction = iota
+	externalReady
+	externalDraft
+	externalRetitle
+	externalMerge
+	externalClose
+	externalReopen
+)
+
+// externalEvent is a provider event reduced to what the pull request flows
+// need. Logins are the provider's usernames. retitled is set when the title
+// changed along with the action.
+type externalEvent struct {
+	action        externalAction
+	link          models.ExternalPullRequest
+	title         string
+	retitled      bool
+	draft         bool
+	authorLogin   string
+	mergedByLogin string
+}
+
+// HandleGitHub verifies a GitHub delivery and applies pull_request events to
+// the mirrored pull request. Events and actions without a counterpart here,
+// and transitions the pull request has already gone through, are reported as
+// ignored so that redeliveries are harmless.
+func (s *WebhookService) HandleGitHub(ctx context.Context, event, signature string,
+	body []byte) (*dtos.WebhookResult, error) {
+	if err := github.VerifySignature([]byte(s.config.GitHubSecret), signature, body); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	result := &dtos.WebhookResult{Event: event, Outcome: WebhookIgnored}
+	if event != github.EventPullRequest {
+		return result, nil
+	}
+
+	payload, err := github.ParsePullRequestEvent(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	result.Action = payload.Action
+
+	ev := &externalEvent{
+		link: models.ExternalPullRequest{
+			Provider:   models.ProviderGitHub,
+			Repository: payload.Repository.FullName,
+			Number:     payload.PullRequest.Number,
+			URL:        payload.PullRequest.HTMLURL,
+		},
+		title:       payload.PullRequest.Title,
+		draft:       payload.PullRequest.Draft,
+		authorLogin: payload.PullRequest.User.Login,
+	}
+	if payload.PullRequest.MergedBy != nil {
+		ev.mergedByLogin = payload.PullRequest.MergedBy.Login
+	}
+
+	switch payload.Action {
+	case github.ActionOpened:
+		ev.action = externalOpen
+	case github.ActionReadyForReview:
+		ev.action = externalReady
+	case github.ActionConvertedToDraft:
+		ev.action = externalDraft
+	case github.ActionEdited:
+		if payload.Changes.Title == nil {
+			return result, nil
+		}
+		ev.action, ev.retitled = externalRetitle, true
+	case github.ActionClosed:
+		ev.action = externalClose
+		if payload.PullRequest.Merged {
+			ev.action = externalMerge
+		}
+	case github.ActionReopened:
+		ev.action = externalReopen
+	default:
+		return result, nil
+	}
+
+	return s.apply(ctx, ev, result)
+}
+
+// HandleGitLab verifies a GitLab delivery and applies Merge Request Hook
+// events the same way HandleGitHub does. Of updates, only those moving the
+// merge request in or out of draft or changing its title are applied. The
+// author and the person who merged are looked up by their IDs, as the user of
+// the event is whoever triggered it.
+func (s *WebhookService) HandleGitLab(ctx context.Context, event, token string,
+	body []byte) (*dtos.WebhookResult, error) {
+	if err := gitlab.VerifyToken(s.config.GitLabToken, token); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	result := &dtos.WebhookResult{Event: event, Outcome: WebhookIgnored}
+	if event != gitlab.EventMergeRequest {
+		return result, nil
+	}
+
+	payload, err := gitlab.ParseMergeRequestEvent(body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPayload, err)
+	}
+	mr := payload.ObjectAttributes
+	result.Action = mr.Action
+
+	ev := &externalEvent{
+		link: models.ExternalPullRequest{
+			Provider:   models.ProviderGitLab,
+			Repository: payload.Project.PathWithNamespace,
+			Number:     mr.IID,
+			URL:        mr.URL,
+		},
+		title:    mr.Title,
+		draft:    mr.Draft || mr.WorkInProgress,
+		retitled: payload.Retitled(),
+	}
+
+	switch mr.Action {
+	case gitlab.ActionOpen:
+		ev.action = externalOpen
+		if ev.authorLogin, err = s.gitlabLogin(ctx, payload, mr.AuthorID); err != nil {
+			return nil, err
+		}
+	case gitlab.ActionUpdate:
+		switch {
+		case payload.LeftDraft():
+			ev.action = externalReady
+		case payload.EnteredDraft():
+			ev.action = externalDraft
+		case ev.retitled:
+			ev.action = externalRetitle
+		default:
+			return result, nil
+		}
+	case gitlab.ActionMerge:
+		ev.action = externalMerge
+		// Older GitLab versions leave merge_user_id out, the person merging is
+		// then the one who triggered the event.
+		mergedBy := mr.MergeUserID
+		if mergedBy == 0 {
+			mergedBy = payload.User.ID
+		}
+		if login, err := s.gitlabLogin(ctx, payload, mergedBy); err == nil {
+			ev.mergedByLogin = login
+		}
+	case gitlab.ActionClose:
+		ev.action = externalClose
+	case gitlab.ActionReopen:
+		ev.action = externalReopen
+	default:
+		return result, nil
+	}
+
+	return s.apply(ctx, ev, result)
+}
+
+// apply runs the pull request flow matching ev and fills in result. Pull
+// requests are found through their link to the external one; events about an
+// external pull request that was never opened here are ignored.
+func (s *WebhookService) apply(ctx context.Context, ev *externalEvent,
+	result *dtos.WebhookResult) (*dtos.WebhookResult, error) {
+	if ev.action == externalOpen {
+		authorID, err := s.identities.ResolveUser(ctx, ev.link.Provider, ev.authorLogin)
+		if err != nil {
+			return nil, err
+		}
+		if err := s.openPullRequest(ctx, ev.title, authorID, ev.draft, &ev.link); err != nil {
+			return nil, err
+		}
+		result.PullRequestId = encoding.EncodeID(ev.link.PullRequestID)
+		result.Outcome = WebhookProcessed
+		return result, nil
+	}
+
+	link, err := s.externalRepo.FindByExternal(ctx, ev.link.Provider, ev.link.Repository, ev.link.Number)
+	if errors.Is(err, pg.ErrExternalPullRequestNotFound) {
+		result.Reason = ErrPRNotFound.Error()
+		return result, nil
+	} else if err != nil {
+		return nil, err
+	}
+	prID := link.PullRequestID
+	result.PullRequestId = encoding.EncodeID(prID)
+
+	if ev.retitled {
+		_, err = s.prService.RenamePullRequest(ctx, prID, ev.title)
+	}
+	switch {
+	case err != nil:
+	case ev.action == externalReady:
+		_, err = s.prService.MarkReady(ctx, prID)
+	case ev.action == externalDraft:
+		_, err = s.prService.MarkDraft(ctx, prID)
+	case ev.action == externalMerge:
+		var mergedBy *int64
+		if ev.mergedByLogin != "" {
+			if id, err := s.identities.ResolveUser(ctx, ev.link.Provider, ev.mergedByLogin); err == nil {
+				mergedBy = &id
+			}
+		}
+		_, err = s.prService.RecordExternalMerge(ctx, prID, fmt.Sprintf("merged on %s", ev.link.Provider), mergedBy)
+	case ev.action == externalClose:
+		_, err = s.prService.ClosePullRequest(ctx, prID)
+	case ev.action == externalReopen:
+		_, err = s.prService.ReopenPullRequest(ctx, prID)
+	}
+
+	if errors.Is(err, ErrPRNotFound) || errors.Is(err, ErrInvalidTransition) {
+		result.Reason = err.Error()
+		return result, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Outcome = WebhookProcessed
+	return result, nil
+}
+
+// gitlabLogin returns the username of the GitLab user with the given ID: the
+// one of the user of the event when it is them, or else the one the GitLab API
+// knows.
+func (s *WebhookService) gitlabLogin(ctx context.Context, payload *gitlab.MergeRequestEvent,
+	id int) (string, error) {
+	if id == payload.User.ID {
+		return payload.User.Username, nil
+	}
+	if s.gitlabUsers == nil {
+		return "", fmt.Errorf("%w: GitLab user %d did not trigger the event and no GitLab API is configured",
+			ErrIdentityNotFound, id)
+	}
+
+	login, err := s.gitlabUsers.Username(ctx, id)
+	if err != nil {
+		return "", fmt.Errorf("look up GitLab user %d: %w", id, err)
+	}
+	return login, nil
+}
+
+// externalIDProbes is how many IDs openPullRequest tries for a new pull
+// request before it gives up.
+const externalIDProbes = 16
+
+// openPullRequest creates the pull request and links it to its external
+// counterpart in one transaction, filling in link.PullRequestID. A
+// redelivered event finds the link already there and changes nothing. The
+// link only exists after the reviewers are assigned, so they are published
+// from here rather than by CreateWithReviewers.
+//
+// The ID of a new pull request is derived from the external one; when it is
+// taken, by a pull request created through the API or by a hash collision,
+// the following IDs are tried.
+func (s *WebhookService) openPullRequest(ctx context.Context, title string, authorID int64, draft bool,
+	link *models.ExternalPullRequest) error {
+	return s.tx.WithinTx(ctx, func(ctx context.Context) error {
+		existing, err := s.externalRepo.FindByExternal(ctx, link.Provider, link.Repository, link.Number)
+		if err == nil {
+			link.PullRequestID = existing.PullRequestID
+			return nil
+		} else if !errors.Is(err, pg.ErrExternalPullRequestNotFound) {
+			return err
+		}
+
+		prID := externalPullRequestID(link.Provider, link.Repository, link.Number)
+		for probe := 1; ; probe++ {
+			_, err = s.prService.CreateWithReviewers(ctx, prID, title, authorID, "", draft)
+			if !errors.Is(err, ErrPRAlreadyExists) || probe == externalIDProbes {
+				break
+			}
+			prID = prID%math.MaxInt64 + 1
+		}
+		if err != nil {
+			return err
+		}
+
+		link.PullRequestID = prID
+		if err := s.externalRepo.Link(ctx, link); err != nil {
+			return fmt.Errorf("link external pull request: %w", err)
+		}
+		if draft {
+			return nil
+		}
+		return s.publisher.PublishReviewers(ctx, prID, nil)
+	})
+}
+
+// externalPullRequestID derives the preferred ID of the pull request
+// mirroring an external one.
+func externalPullRequestID(provider, repository string, number int) int64 {
+	h := fnv.New64a()
+	_, _ = fmt.Fprintf(h, "%s:%s#%d", provider, repository, number)
+	return int64(h.Sum64()&math.MaxInt64) | 1
+}
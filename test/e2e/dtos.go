@@ -17,6 +17,8 @@ type CreatePRRequest struct {
 	PullRequestId   string `json:"pull_request_id"`
 	PullRequestName string `json:"pull_request_name"`
 	AuthorId        string `json:"author_id"`
+	TeamName        string `json:"team_name,omitempty"`
+	Draft           bool   `json:"draft,omitempty"`
 }
 
 type PullRequest struct {
@@ -27,10 +29,57 @@ type PullRequest struct {
 	AssignedReviewers []string   `json:"assigned_reviewers"`
 	CreatedAt         *time.Time `json:"createdAt"`
 	MergedAt          *time.Time `json:"mergedAt"`
+	ClosedAt          *time.Time `json:"closedAt"`
+	FallbackReviewers []struct {
+		UserID   string `json:"user_id"`
+		TeamName string `json:"team_name"`
+	} `json:"fallback_reviewers"`
+	Reviews  []Review `json:"reviews"`
+	TeamName string   `json:"team_name"`
+}
+
+type Review struct {
+	UserId     string     `json:"user_id"`
+	AssignedAt *time.Time `json:"assigned_at"`
+	Decision   string     `json:"decision"`
+	DecidedAt  *time.Time `json:"decided_at"`
+}
+
+type ReviewRequest struct {
+	PullRequestId string `json:"pull_request_id"`
+	UserId        string `json:"user_id"`
+	Decision      string `json:"decision"`
+}
+
+type UserReviews struct {
+	UserId       string `json:"user_id"`
+	PullRequests []struct {
+		PullRequestId  string `json:"pull_request_id"`
+		Status         string `json:"status"`
+		AwaitingAction bool   `json:"awaiting_action"`
+	} `json:"pull_requests"`
 }
 
 type MergePRRequest struct {
 	PullRequestId string `json:"pull_request_id"`
+	Force         bool   `json:"force,omitempty"`
+	ForcedBy      string `json:"forced_by,omitempty"`
+	Reason        string `json:"reason,omitempty"`
+}
+
+type Mergeability struct {
+	PullRequestId      string   `json:"pull_request_id"`
+	Status             string   `json:"status"`
+	Mergeable          bool     `json:"mergeable"`
+	Approvals          int      `json:"approvals"`
+	RequiredApprovals  int      `json:"required_approvals"`
+	ChangesRequestedBy []string `json:"changes_requested_by"`
+	UnmetConditions    []string `json:"unmet_conditions"`
+	Overrides          []struct {
+		ForcedBy        string   `json:"forced_by"`
+		Reason          string   `json:"reason"`
+		UnmetConditions []string `json:"unmet_conditions"`
+	} `json:"overrides"`
 }
 
 type ReassignRequest struct {
@@ -44,19 +93,25 @@ type ReassignResponse struct {
 }
 
 type SetActiveRequest struct {
-	UserId   string `json:"user_id"`
-	IsActive bool   `json:"is_active"`
+	UserId              string `json:"user_id"`
+	IsActive            bool   `json:"is_active"`
+	ReassignOpenReviews bool   `json:"reassign_open_reviews,omitempty"`
 }
 
 type SetActiveResponse struct {
-	User UserDTO `json:"user"`
+	User       UserDTO `json:"user"`
+	Reassigned []struct {
+		PullRequestId string `json:"pull_request_id"`
+		OldUserId     string `json:"old_user_id"`
+		NewUserId     string `json:"new_user_id"`
+	} `json:"reassigned"`
 }
 
 type UserDTO struct {
-	UserId   string `json:"user_id"`
-	Username string `json:"username"`
-	IsActive bool   `json:"is_active"`
-	TeamName string `json:"team_name"`
+	UserId   string   `json:"user_id"`
+	Username string   `json:"username"`
+	IsActive bool     `json:"is_active"`
+	Teams    []string `json:"teams"`
 }
 
 type CreatePRResponseWrapper struct {
@@ -69,3 +124,182 @@ type ErrorResponse struct {
 		Message string `json:"message"`
 	} `json:"error"`
 }
+
+type TeamSettings struct {
+	TeamName          string   `json:"team_name"`
+	ReviewerStrategy  string   `json:"reviewer_strategy,omitempty"`
+	MinReviewers      *int     `json:"min_reviewers,omitempty"`
+	MaxReviewers      *int     `json:"max_reviewers,omitempty"`
+	RequiredApprovals *int     `json:"required_approvals,omitempty"`
+	BackupTeams       []string `json:"backup_teams,omitempty"`
+}
+
+type DeactivateRequest struct {
+	TeamName string   `json:"team_name"`
+	UserIds  []string `json:"user_ids,omitempty"`
+}
+
+type DeactivationReport struct {
+	TeamName         string   `json:"team_name"`
+	DeactivatedUsers []string `json:"deactivated_users"`
+	Reassigned       []struct {
+		PullRequestId string `json:"pull_request_id"`
+		OldUserId     string `json:"old_user_id"`
+		NewUserId     string `json:"new_user_id"`
+	} `json:"reassigned"`
+	Unassigned []struct {
+		PullRequestId string `json:"pull_request_id"`
+		UserId        string `json:"user_id"`
+	} `json:"unassigned"`
+}
+
+type UnavailabilityRequest struct {
+	UserId   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason,omitempty"`
+}
+
+type Unavailability struct {
+	Id       string    `json:"id"`
+	UserId   string    `json:"user_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Reason   string    `json:"reason"`
+	Source   string    `json:"source"`
+}
+
+type UnavailabilityList struct {
+	UserId  string           `json:"user_id"`
+	Periods []Unavailability `json:"periods"`
+}
+
+type CalendarImportResult struct {
+	UserId   string `json:"user_id"`
+	Imported int    `json:"imported"`
+	Removed  int    `json:"removed"`
+}
+
+type UserIdentityRequest struct {
+	UserId     string `json:"user_id"`
+	Provider   string `json:"provider"`
+	ExternalId string `json:"external_id"`
+}
+
+type UserIdentity struct {
+	UserId     string    `json:"user_id"`
+	Provider   string    `json:"provider"`
+	ExternalId string    `json:"external_id"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type UserIdentityList struct {
+	UserId     string         `json:"user_id"`
+	Identities []UserIdentity `json:"identities"`
+}
+
+type TeamReviewSLA struct {
+	TeamName           string  `json:"team_name"`
+	FirstDecisionHours float64 `json:"first_decision_hours"`
+	WorkStart          string  `json:"work_start,omitempty"`
+	WorkEnd            string  `json:"work_end,omitempty"`
+	WorkDays           []int   `json:"work_days,omitempty"`
+	Timezone           string  `json:"timezone,omitempty"`
+	AutoReassign       bool    `json:"auto_reassign"`
+}
+
+type OverdueReview struct {
+	PullRequestId   string    `json:"pull_request_id"`
+	PullRequestName string    `json:"pull_request_name"`
+	TeamName        string    `json:"team_name"`
+	ReviewerId      string    `json:"reviewer_id"`
+	AssignedAt      time.Time `json:"assigned_at"`
+	AgeHours        float64   `json:"age_hours"`
+	WorkingHours    float64   `json:"working_hours"`
+	SlaHours        float64   `json:"sla_hours"`
+	Severity        string    `json:"severity"`
+}
+
+type OverdueReviewList struct {
+	Reviews []OverdueReview `json:"reviews"`
+}
+
+type LatencyStats struct {
+	Count    int      `json:"count"`
+	P50Hours *float64 `json:"p50_hours"`
+	P90Hours *float64 `json:"p90_hours"`
+	P99Hours *float64 `json:"p99_hours"`
+}
+
+type StatsResponse struct {
+	TeamName           string       `json:"team_name"`
+	TotalPullRequests  int          `json:"total_pull_requests"`
+	DraftPullRequests  int          `json:"draft_pull_requests"`
+	OpenPullRequests   int          `json:"open_pull_requests"`
+	MergedPullRequests int          `json:"merged_pull_requests"`
+	ClosedPullRequests int          `json:"closed_pull_requests"`
+	TimeToFirstReview  LatencyStats `json:"time_to_first_review"`
+	TimeToMerge        LatencyStats `json:"time_to_merge"`
+	Reassignments      struct {
+		Total        int `json:"total"`
+		PullRequests int `json:"pull_requests"`
+	} `json:"reassignments"`
+	Throughput []struct {
+		Date   string `json:"date"`
+		Opened int    `json:"opened"`
+		Merged int    `json:"merged"`
+	} `json:"throughput"`
+	ReviewerStats []struct {
+		ReviewerId    string `json:"reviewer_id"`
+		Username      string `json:"username"`
+		AssignedCount int    `json:"assigned_count"`
+	} `json:"reviewer_stats"`
+}
+
+type ReviewerLoad struct {
+	ReviewerId                string   `json:"reviewer_id"`
+	Username                  string   `json:"username"`
+	IsActive                  bool     `json:"is_active"`
+	OpenReviews               int      `json:"open_reviews"`
+	MergedReviews             int      `json:"merged_reviews"`
+	AssignedReviews           int      `json:"assigned_reviews"`
+	AverageOpenReviewAgeHours *float64 `json:"average_open_review_age_hours"`
+}
+
+type TeamWorkload struct {
+	TeamName        string         `json:"team_name"`
+	AssignmentsGini float64        `json:"assignments_gini"`
+	Reviewers       []ReviewerLoad `json:"reviewers"`
+}
+
+type ReviewerWorkload struct {
+	ReviewerId      string `json:"reviewer_id"`
+	Username        string `json:"username"`
+	OpenReviews     int    `json:"open_reviews"`
+	MergedReviews   int    `json:"merged_reviews"`
+	AssignedReviews int    `json:"assigned_reviews"`
+	Teams           []struct {
+		TeamName        string  `json:"team_name"`
+		OpenReviews     int     `json:"open_reviews"`
+		MergedReviews   int     `json:"merged_reviews"`
+		AssignedReviews int     `json:"assigned_reviews"`
+		AssignmentShare float64 `json:"assignment_share"`
+	} `json:"teams"`
+}
+
+type PullRequestExport struct {
+	PullRequestId string     `json:"pull_request_id"`
+	AuthorId      string     `json:"author_id"`
+	TeamName      string     `json:"team_name"`
+	Status        string     `json:"status"`
+	Reviewers     []string   `json:"reviewers"`
+	MergedAt      *time.Time `json:"merged_at"`
+	Reassignments int        `json:"reassignments"`
+}
+
+type ReviewAssignmentExport struct {
+	PullRequestId string `json:"pull_request_id"`
+	ReviewerId    string `json:"reviewer_id"`
+	Decision      string `json:"decision"`
+	ReplacedBy    string `json:"replaced_by"`
+}
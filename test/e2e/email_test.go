@@ -0,0 +1,308 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// smtpStubAddr is where docker-compose.test.yml points SMTP_HOST and SMTP_PORT.
+const smtpStubAddr = ":18025"
+
+type receivedEmail struct {
+	From    string
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// smtpStub is a minimal SMTP server keeping the mails sent to recipients.
+// Mails to anyone else, sent for tests running in parallel, are accepted and
+// dropped.
+type smtpStub struct {
+	recipients map[string]bool
+
+	mu       sync.Mutex
+	received []receivedEmail
+}
+
+func startSMTPStub(t *testing.T, recipients []string) *smtpStub {
+	t.Helper()
+
+	stub := &smtpStub{recipients: make(map[string]bool, len(recipients))}
+	for _, r := range recipients {
+		stub.recipients[r] = true
+	}
+	ln, err := net.Listen("tcp", smtpStubAddr)
+	if err != nil {
+		t.Fatalf("Failed to listen on %s: %v", smtpStubAddr, err)
+	}
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go stub.serve(conn)
+		}
+	}()
+	t.Cleanup(func() {
+		_ = ln.Close()
+	})
+	return stub
+}
+
+func (s *smtpStub) serve(conn net.Conn) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(30 * time.Second))
+
+	text := textproto.NewConn(conn)
+	reply := func(code int, msg string) {
+		_ = text.PrintfLine("%d %s", code, msg)
+	}
+
+	reply(220, "e2e.test ESMTP stub")
+	var from string
+	var to []string
+	for {
+		line, err := text.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			reply(250, "e2e.test")
+		case "MAIL":
+			from = smtpPath(arg)
+			to = nil
+			reply(250, "OK")
+		case "RCPT":
+			to = append(to, smtpPath(arg))
+			reply(250, "OK")
+		case "DATA":
+			reply(354, "End data with <CR><LF>.<CR><LF>")
+			data, err := text.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			for _, rcpt := range to {
+				if s.recipients[rcpt] {
+					s.keep(from, rcpt, data)
+				}
+			}
+			reply(250, "OK")
+		case "RSET":
+			from, to = "", nil
+			reply(250, "OK")
+		case "NOOP":
+			reply(250, "OK")
+		case "QUIT":
+			reply(221, "Bye")
+			return
+		default:
+			reply(502, "Command not implemented")
+		}
+	}
+}
+
+// smtpPath returns the address of a "FROM:<addr>" or "TO:<addr>" argument.
+func smtpPath(arg string) string {
+	_, path, _ := strings.Cut(arg, ":")
+	path, _, _ = strings.Cut(strings.TrimSpace(path), " ")
+	return strings.ToLower(strings.Trim(path, "<>"))
+}
+
+func (s *smtpStub) keep(from, to string, data []byte) {
+	received := receivedEmail{From: from, To: to}
+	msg, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err == nil {
+		received.Subject, _ = new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+		_, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+		parts := multipart.NewReader(msg.Body, params["boundary"])
+		for {
+			part, err := parts.NextPart()
+			if err != nil {
+				break
+			}
+			body, _ := io.ReadAll(part)
+			mediaType, _, _ := mime.ParseMediaType(part.Header.Get("Content-Type"))
+			switch mediaType {
+			case "text/plain":
+				received.Text = string(body)
+			case "text/html":
+				received.HTML = string(body)
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.received = append(s.received, received)
+	s.mu.Unlock()
+}
+
+// waitFor returns the first mail to the recipient whose subject contains
+// substr.
+func (s *smtpStub) waitFor(t *testing.T, to, substr string) receivedEmail {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		for _, msg := range s.received {
+			if msg.To == to && strings.Contains(msg.Subject, substr) {
+				s.mu.Unlock()
+				return msg
+			}
+		}
+		s.mu.Unlock()
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	t.Fatalf("Expected a mail to %s with subject containing %q, got %+v", to, substr, s.received)
+	return receivedEmail{}
+}
+
+func TestEmailNotifications(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "emA" + generateRandomString(4), Username: "EmA", IsActive: true}
+	members := []TeamMember{author}
+	for i := 0; i < 3; i++ {
+		members = append(members, TeamMember{
+			UserID:   "emR" + generateRandomString(5),
+			Username: "EmR" + generateRandomString(6),
+			IsActive: true,
+		})
+	}
+	teamName := "EmailTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, members)
+
+	usernames := make(map[string]string)
+	addresses := make(map[string]string)
+	var recipients []string
+	for _, m := range members[1:] {
+		usernames[m.UserID] = m.Username
+		addresses[m.UserID] = strings.ToLower(m.UserID) + "@e2e.test"
+		recipients = append(recipients, addresses[m.UserID])
+		mustPostJSON(t, ctx, "/users/identities", UserIdentityRequest{
+			UserId: m.UserID, Provider: "email", ExternalId: addresses[m.UserID],
+		})
+	}
+	smtp := startSMTPStub(t, recipients)
+
+	var prefs NotificationPreferences
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/users/notifications?user_id="+members[1].UserID), &prefs); err != nil {
+		t.Fatalf("Failed to unmarshal preferences: %v", err)
+	}
+	if !prefs.EmailEnabled || !prefs.ChatEnabled {
+		t.Fatalf("Expected all notifications to be on by default, got %+v", prefs)
+	}
+
+	createPR := func(title string) PullRequest {
+		var resp CreatePRResponseWrapper
+		body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+			PullRequestId:   "prEm" + generateRandomString(5),
+			PullRequestName: title,
+			AuthorId:        author.UserID,
+		})
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("Failed to unmarshal created PR: %v", err)
+		}
+		if len(resp.Pr.AssignedReviewers) != 2 {
+			t.Fatalf("Expected 2 reviewers, got %+v", resp.Pr)
+		}
+		return resp.Pr
+	}
+
+	first := createPR("Emailed " + generateRandomString(8))
+	for _, id := range first.AssignedReviewers {
+		msg := smtp.waitFor(t, addresses[id], "Review requested: "+first.PullRequestName)
+		if msg.From != "inator@e2e.test" {
+			t.Fatalf("Expected mail from the configured sender, got %q", msg.From)
+		}
+		if !strings.Contains(msg.Text, "Hi "+usernames[id]) || !strings.Contains(msg.Text, first.PullRequestId) ||
+			!strings.Contains(msg.Text, "by "+author.Username) {
+			t.Fatalf("Expected a text part naming the reviewer, PR and author, got %q", msg.Text)
+		}
+		if !strings.Contains(msg.HTML, "<strong>"+first.PullRequestName+"</strong>") {
+			t.Fatalf("Expected an HTML part with the PR title, got %q", msg.HTML)
+		}
+	}
+
+	replaced, kept := first.AssignedReviewers[0], first.AssignedReviewers[1]
+	var spare string
+	for _, m := range members[1:] {
+		if m.UserID != replaced && m.UserID != kept {
+			spare = m.UserID
+		}
+	}
+
+	mustPostJSON(t, ctx, "/pullRequest/reassign", ReassignRequest{PullRequestId: first.PullRequestId, OldUserId: replaced})
+	msg := smtp.waitFor(t, addresses[spare], "Review requested: "+first.PullRequestName)
+	if !strings.Contains(msg.Text, "take over the review from "+usernames[replaced]) {
+		t.Fatalf("Expected the new reviewer to learn whom they replace, got %q", msg.Text)
+	}
+	msg = smtp.waitFor(t, addresses[replaced], "Review reassigned: "+first.PullRequestName)
+	if !strings.Contains(msg.Text, "reassigned to "+usernames[spare]) {
+		t.Fatalf("Expected the old reviewer to learn who took over, got %q", msg.Text)
+	}
+
+	second := createPR("Emailed later " + generateRandomString(8))
+
+	body := mustPostJSON(t, ctx, "/users/notifications", map[string]any{"user_id": spare, "email_enabled": false})
+	if err := json.Unmarshal(body, &prefs); err != nil || prefs.EmailEnabled || !prefs.ChatEnabled {
+		t.Fatalf("Expected only email notifications to be turned off, got %s", body)
+	}
+	var result struct {
+		Sent int `json:"sent"`
+	}
+	body = mustPostJSON(t, ctx, "/users/notifications/digest", map[string]string{"user_id": spare})
+	if err := json.Unmarshal(body, &result); err != nil || result.Sent != 0 {
+		t.Fatalf("Expected no digest for a user who opted out, got %s", body)
+	}
+
+	// The digest may already have gone out if the daily run happened to
+	// start meanwhile; it must arrive either way.
+	body = mustPostJSON(t, ctx, "/users/notifications/digest", map[string]string{"user_id": kept})
+	if err := json.Unmarshal(body, &result); err != nil || result.Sent > 1 {
+		t.Fatalf("Expected at most one digest, got %s", body)
+	}
+	msg = smtp.waitFor(t, addresses[kept], "pending review")
+	firstAt := strings.Index(msg.Text, first.PullRequestId)
+	if firstAt < 0 {
+		t.Fatalf("Expected the digest to list %s, got %q", first.PullRequestId, msg.Text)
+	}
+	for _, id := range second.AssignedReviewers {
+		if id != kept {
+			continue
+		}
+		if secondAt := strings.Index(msg.Text, second.PullRequestId); secondAt < firstAt {
+			t.Fatalf("Expected the digest to list the older PR first, got %q", msg.Text)
+		}
+	}
+
+	body = mustPostJSON(t, ctx, "/users/notifications/digest", map[string]string{"user_id": kept})
+	if err := json.Unmarshal(body, &result); err != nil || result.Sent != 0 {
+		t.Fatalf("Expected the digest to go out once a day, got %s", body)
+	}
+
+	status, _ := postJSON(t, ctx, "/users/notifications/digest", map[string]string{"user_id": "NoSuchUser"})
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown user, got %d", status)
+	}
+}
@@ -0,0 +1,107 @@
+package e2e
+
+import (
+	"context"
+	"io"
+	"log"
+	"pullrequest-inator/internal/infrastructure/repositories/pg"
+	"pullrequest-inator/internal/infrastructure/services"
+	"testing"
+	"time"
+)
+
+// The reminder scheduler of the service holds its own lock, so these tests
+// elect their leaders under names nobody else uses.
+
+func TestLeaderElection(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	db := openDB(t, ctx)
+	name := "e2e-leader-" + generateRandomString(6)
+	first, second := pg.NewAdvisoryLock(db, name), pg.NewAdvisoryLock(db, name)
+	t.Cleanup(func() {
+		_ = first.Release(ctx)
+		_ = second.Release(ctx)
+	})
+
+	if leader, err := first.TryAcquire(ctx); err != nil || !leader {
+		t.Fatalf("Expected the first replica to take the lead, got %v, %v", leader, err)
+	}
+	if leader, err := second.TryAcquire(ctx); err != nil || leader {
+		t.Fatalf("Expected the second replica not to lead while the first does, got %v, %v", leader, err)
+	}
+	if leader, err := first.TryAcquire(ctx); err != nil || !leader {
+		t.Fatalf("Expected the first replica to keep the lead, got %v, %v", leader, err)
+	}
+
+	if err := first.Release(ctx); err != nil {
+		t.Fatalf("Failed to give the lead up: %v", err)
+	}
+	if leader, err := second.TryAcquire(ctx); err != nil || !leader {
+		t.Fatalf("Expected the second replica to take the lead over, got %v, %v", leader, err)
+	}
+}
+
+func TestReminderSchedulerGivesLeadUpOnShutdown(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	db := openDB(t, ctx)
+	name := "e2e-reminders-" + generateRandomString(6)
+	outbox, err := services.NewOutbox(pg.NewOutboxRepository(db))
+	if err != nil {
+		t.Fatalf("Failed to init outbox: %v", err)
+	}
+	// Nothing is ever due, the scheduler only has to lead.
+	scheduler, err := services.NewReminderScheduler(pg.NewReviewReminderRepository(db), pg.NewAdvisoryLock(db, name),
+		outbox, pg.NewTransactor(db), log.New(io.Discard, "", 0), services.ReminderConfig{
+			After:    100 * 365 * 24 * time.Hour,
+			Interval: 100 * time.Millisecond,
+		})
+	if err != nil {
+		t.Fatalf("Failed to init reminder scheduler: %v", err)
+	}
+
+	runCtx, stop := context.WithCancel(ctx)
+	defer stop()
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		scheduler.Run(runCtx)
+	}()
+
+	other := pg.NewAdvisoryLock(db, name)
+	t.Cleanup(func() {
+		_ = other.Release(ctx)
+	})
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		leader, err := other.TryAcquire(ctx)
+		if err != nil {
+			t.Fatalf("Failed to try the lock: %v", err)
+		}
+		if !leader {
+			break
+		}
+		// Taken before the scheduler got to it; hand it over.
+		if err := other.Release(ctx); err != nil {
+			t.Fatalf("Failed to give the lead up: %v", err)
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected the scheduler to take the lead")
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+
+	stop()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("Expected the scheduler to stop once cancelled")
+	}
+
+	if leader, err := other.TryAcquire(ctx); err != nil || !leader {
+		t.Fatalf("Expected the lead to be free right after shutdown, got %v, %v", leader, err)
+	}
+}
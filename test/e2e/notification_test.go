@@ -0,0 +1,251 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type TeamChatChannel struct {
+	TeamName string `json:"team_name"`
+	Provider string `json:"provider"`
+	Channel  string `json:"channel"`
+}
+
+type NotificationPreferences struct {
+	UserId       string `json:"user_id"`
+	ChatEnabled  bool   `json:"chat_enabled"`
+	EmailEnabled bool   `json:"email_enabled"`
+}
+
+type chatMessage struct {
+	Channel  string `json:"channel"`
+	Text     string `json:"text"`
+	Username string `json:"username"`
+}
+
+// chatWebhook is an incoming webhook stub keeping the messages that mention
+// marker. Messages of other tests running in parallel are dropped.
+type chatWebhook struct {
+	marker string
+
+	mu       sync.Mutex
+	received []chatMessage
+}
+
+func startChatWebhook(t *testing.T, marker string) (*chatWebhook, string) {
+	t.Helper()
+
+	hook := &chatWebhook{marker: marker}
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	srv := &http.Server{Handler: hook}
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+	return hook, fmt.Sprintf("http://host.docker.internal:%d/hooks/chat", ln.Addr().(*net.TCPAddr).Port)
+}
+
+func (h *chatWebhook) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var msg chatMessage
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	if strings.Contains(msg.Text, h.marker) {
+		h.mu.Lock()
+		h.received = append(h.received, msg)
+		h.mu.Unlock()
+	}
+	_, _ = w.Write([]byte("ok"))
+}
+
+// waitFor returns the first received message containing substr.
+func (h *chatWebhook) waitFor(t *testing.T, substr string) chatMessage {
+	t.Helper()
+
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		h.mu.Lock()
+		for _, msg := range h.received {
+			if strings.Contains(msg.Text, substr) {
+				h.mu.Unlock()
+				return msg
+			}
+		}
+		h.mu.Unlock()
+		time.Sleep(100 * time.Millisecond)
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	t.Fatalf("Expected a chat message containing %q, got %+v", substr, h.received)
+	return chatMessage{}
+}
+
+func TestChatNotifications(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "ntA" + generateRandomString(4), Username: "NtA", IsActive: true}
+	members := []TeamMember{author}
+	for i := 0; i < 3; i++ {
+		members = append(members, TeamMember{
+			UserID:   "ntR" + generateRandomString(5),
+			Username: "NtR" + generateRandomString(6),
+			IsActive: true,
+		})
+	}
+	teamName := "ChatTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, members)
+
+	usernames := make(map[string]string)
+	slackIDs := make(map[string]string)
+	for _, m := range members[1:] {
+		usernames[m.UserID] = m.Username
+		slackIDs[m.UserID] = "U" + generateRandomString(8)
+		mustPostJSON(t, ctx, "/users/identities", UserIdentityRequest{
+			UserId: m.UserID, Provider: "slack", ExternalId: slackIDs[m.UserID],
+		})
+	}
+
+	title := "Chatty " + generateRandomString(8)
+	hook, url := startChatWebhook(t, title)
+
+	status, body := postJSON(t, ctx, "/team/notifications", map[string]string{
+		"team_name": teamName, "provider": "telegram", "webhook_url": url,
+	})
+	if status != http.StatusBadRequest || !strings.Contains(string(body), "INVALID_CHAT_CHANNEL") {
+		t.Fatalf("Expected 400 INVALID_CHAT_CHANNEL for an unknown chat, got %d: %s", status, body)
+	}
+	status, body = postJSON(t, ctx, "/team/notifications", map[string]string{
+		"team_name": teamName, "provider": "slack", "webhook_url": "http://127.0.0.1:8080/hooks",
+	})
+	if status != http.StatusBadRequest || !strings.Contains(string(body), "INVALID_CHAT_CHANNEL") {
+		t.Fatalf("Expected 400 INVALID_CHAT_CHANNEL for an internal URL, got %d: %s", status, body)
+	}
+	status, body = postJSON(t, ctx, "/team/notifications", map[string]string{
+		"team_name": "NoSuchTeam" + generateRandomString(6), "provider": "slack", "webhook_url": url,
+	})
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown team, got %d: %s", status, body)
+	}
+
+	var channel TeamChatChannel
+	body = mustPostJSON(t, ctx, "/team/notifications", map[string]string{
+		"team_name": teamName, "provider": "slack", "webhook_url": url, "channel": "#reviews",
+	})
+	if err := json.Unmarshal(body, &channel); err != nil {
+		t.Fatalf("Failed to unmarshal chat channel: %v", err)
+	}
+	if channel.Provider != "slack" || channel.Channel != "#reviews" || strings.Contains(string(body), url) {
+		t.Fatalf("Expected the slack channel without its webhook URL, got %s", body)
+	}
+
+	optedOut := members[1].UserID
+	var prefs NotificationPreferences
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/users/notifications?user_id="+optedOut), &prefs); err != nil {
+		t.Fatalf("Failed to unmarshal preferences: %v", err)
+	}
+	if !prefs.ChatEnabled {
+		t.Fatalf("Expected chat notifications to be on by default, got %+v", prefs)
+	}
+	body = mustPostJSON(t, ctx, "/users/notifications", map[string]any{"user_id": optedOut, "chat_enabled": false})
+	if err := json.Unmarshal(body, &prefs); err != nil || prefs.ChatEnabled {
+		t.Fatalf("Expected chat notifications to be turned off, got %s", body)
+	}
+
+	// name is how a reviewer appears in the messages: the user who opted out
+	// is named without a mention.
+	name := func(userID string, mention func(string) string) string {
+		if userID == optedOut {
+			return usernames[userID]
+		}
+		return mention(userID)
+	}
+	slack := func(userID string) string { return "<@" + slackIDs[userID] + ">" }
+
+	var createResp CreatePRResponseWrapper
+	prID := "prNt" + generateRandomString(5)
+	body = mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   prID,
+		PullRequestName: title,
+		AuthorId:        author.UserID,
+	})
+	if err := json.Unmarshal(body, &createResp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+	reviewers := createResp.Pr.AssignedReviewers
+	if len(reviewers) != 2 {
+		t.Fatalf("Expected 2 reviewers, got %+v", createResp.Pr)
+	}
+
+	msg := hook.waitFor(t, "Review requested")
+	want := fmt.Sprintf("Review requested from %s, %s: *%s* (%s)",
+		name(reviewers[0], slack), name(reviewers[1], slack), title, prID)
+	if msg.Text != want || msg.Channel != "#reviews" || msg.Username != "pullrequest-inator" {
+		t.Fatalf("Expected assignment message %q to #reviews, got %+v", want, msg)
+	}
+
+	// The reviewer left out is the only candidate to replace one of them.
+	replaced, kept := reviewers[0], reviewers[1]
+	if replaced == optedOut {
+		replaced, kept = kept, replaced
+	}
+	var spare string
+	for _, m := range members[1:] {
+		if m.UserID != replaced && m.UserID != kept {
+			spare = m.UserID
+		}
+	}
+
+	mustPostJSON(t, ctx, "/pullRequest/reassign", ReassignRequest{PullRequestId: prID, OldUserId: replaced})
+	msg = hook.waitFor(t, "now reviews")
+	want = fmt.Sprintf("%s now reviews *%s* (%s) instead of %s", name(spare, slack), title, prID, slack(replaced))
+	if msg.Text != want {
+		t.Fatalf("Expected reassignment message %q, got %+v", want, msg)
+	}
+
+	mattermostIDs := make(map[string]string)
+	for _, m := range members[1:] {
+		mattermostIDs[m.UserID] = "mm" + generateRandomString(8)
+		mustPostJSON(t, ctx, "/users/identities", UserIdentityRequest{
+			UserId: m.UserID, Provider: "mattermost", ExternalId: mattermostIDs[m.UserID],
+		})
+	}
+	mustPostJSON(t, ctx, "/team/notifications", map[string]string{
+		"team_name": teamName, "provider": "mattermost", "webhook_url": url,
+	})
+	mattermost := func(userID string) string { return "@" + mattermostIDs[userID] }
+
+	mustPostJSON(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prID})
+	msg = hook.waitFor(t, "was merged")
+	for _, id := range []string{kept, spare} {
+		if !strings.Contains(msg.Text, name(id, mattermost)) {
+			t.Fatalf("Expected the merge message to name %s as %q, got %+v", id, name(id, mattermost), msg)
+		}
+	}
+	if !strings.HasPrefix(msg.Text, "**"+title+"**") || msg.Channel != "" {
+		t.Fatalf("Expected a mattermost message to the webhook's own channel, got %+v", msg)
+	}
+
+	status, body = postJSON(t, ctx, "/team/notifications/delete", map[string]string{"team_name": teamName})
+	if status != http.StatusNoContent {
+		t.Fatalf("Expected 204 on delete, got %d: %s", status, body)
+	}
+	status, _ = postJSON(t, ctx, "/team/notifications/delete", map[string]string{"team_name": teamName})
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 when no channel is configured, got %d", status)
+	}
+}
@@ -146,3 +146,541 @@ func TestPRAssignmentLessThanTwo(t *testing.T) {
 		t.Fatalf("Wrong reviewer assigned. Expected %s, got %s", rev1.UserID, createResp.Pr.AssignedReviewers[0])
 	}
 }
+
+func TestPRAssignmentPrefersLeastLoaded(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authL" + generateRandomString(4), Username: "AuthL", IsActive: true}
+	rev1 := TeamMember{UserID: "revL1" + generateRandomString(4), Username: "RevL1", IsActive: true}
+	rev2 := TeamMember{UserID: "revL2" + generateRandomString(4), Username: "RevL2", IsActive: true}
+	rev3 := TeamMember{UserID: "revL3" + generateRandomString(4), Username: "RevL3", IsActive: true}
+
+	teamName := "LoadTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, rev1, rev2, rev3})
+
+	var first CreatePRResponseWrapper
+	bodyFirst := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prL1" + generateRandomString(4),
+		PullRequestName: "First",
+		AuthorId:        author.UserID,
+	})
+	if err := json.Unmarshal(bodyFirst, &first); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+
+	idle := ""
+	for _, m := range []TeamMember{rev1, rev2, rev3} {
+		assigned := false
+		for _, id := range first.Pr.AssignedReviewers {
+			if id == m.UserID {
+				assigned = true
+			}
+		}
+		if !assigned {
+			idle = m.UserID
+		}
+	}
+	if idle == "" {
+		t.Fatalf("Expected one idle reviewer after first PR, got %v", first.Pr.AssignedReviewers)
+	}
+
+	var second CreatePRResponseWrapper
+	bodySecond := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prL2" + generateRandomString(4),
+		PullRequestName: "Second",
+		AuthorId:        author.UserID,
+	})
+	if err := json.Unmarshal(bodySecond, &second); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+
+	found := false
+	for _, id := range second.Pr.AssignedReviewers {
+		if id == idle {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("Expected least loaded reviewer %s to be assigned, got %v", idle, second.Pr.AssignedReviewers)
+	}
+}
+
+func TestPRAssignmentRespectsTeamLimits(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authM" + generateRandomString(4), Username: "AuthM", IsActive: true}
+	rev1 := TeamMember{UserID: "revM1" + generateRandomString(4), Username: "RevM1", IsActive: true}
+	rev2 := TeamMember{UserID: "revM2" + generateRandomString(4), Username: "RevM2", IsActive: true}
+	rev3 := TeamMember{UserID: "revM3" + generateRandomString(4), Username: "RevM3", IsActive: false}
+
+	teamName := "LimitTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, rev1, rev2, rev3})
+
+	three := 3
+	mustPostJSON(t, ctx, "/team/settings", TeamSettings{TeamName: teamName, MinReviewers: &three, MaxReviewers: &three})
+
+	t.Log("1. Expecting rejection while only 2 reviewers are active...")
+	status, body := postJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prM1" + generateRandomString(4),
+		PullRequestName: "Needs three",
+		AuthorId:        author.UserID,
+	})
+	if status != http.StatusConflict {
+		t.Fatalf("Expected 409 Conflict, got %d: %s", status, body)
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil {
+		t.Fatalf("Failed to unmarshal error response: %v", err)
+	}
+	if errResp.Error.Code != "NOT_ENOUGH_REVIEWERS" {
+		t.Fatalf("Expected NOT_ENOUGH_REVIEWERS, got %s", errResp.Error.Code)
+	}
+
+	t.Log("2. Activating third reviewer and retrying...")
+	mustPostJSON(t, ctx, "/users/setIsActive", SetActiveRequest{UserId: rev3.UserID, IsActive: true})
+
+	var createResp CreatePRResponseWrapper
+	bodyPR := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prM2" + generateRandomString(4),
+		PullRequestName: "Has three",
+		AuthorId:        author.UserID,
+	})
+	if err := json.Unmarshal(bodyPR, &createResp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+	if len(createResp.Pr.AssignedReviewers) != 3 {
+		t.Fatalf("Expected 3 reviewers, got %v", createResp.Pr.AssignedReviewers)
+	}
+}
+
+func TestPRAssignmentRoundRobinRotation(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authR" + generateRandomString(4), Username: "AuthR", IsActive: true}
+	rev1 := TeamMember{UserID: "revR1" + generateRandomString(4), Username: "RevR1", IsActive: true}
+	rev2 := TeamMember{UserID: "revR2" + generateRandomString(4), Username: "RevR2", IsActive: true}
+	rev3 := TeamMember{UserID: "revR3" + generateRandomString(4), Username: "RevR3", IsActive: true}
+	inactive := TeamMember{UserID: "inaR" + generateRandomString(4), Username: "InaR", IsActive: false}
+
+	teamName := "RotationTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, rev1, rev2, rev3, inactive})
+
+	one := 1
+	mustPostJSON(t, ctx, "/team/settings", TeamSettings{TeamName: teamName, ReviewerStrategy: "round_robin", MaxReviewers: &one})
+
+	var assigned []string
+	for i := 0; i < 4; i++ {
+		body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+			PullRequestId:   "prR" + generateRandomString(6),
+			PullRequestName: "Rotation",
+			AuthorId:        author.UserID,
+		})
+		var createResp CreatePRResponseWrapper
+		if err := json.Unmarshal(body, &createResp); err != nil {
+			t.Fatalf("Failed to unmarshal created PR: %v", err)
+		}
+		if len(createResp.Pr.AssignedReviewers) != 1 {
+			t.Fatalf("Expected 1 reviewer, got %v", createResp.Pr.AssignedReviewers)
+		}
+		assigned = append(assigned, createResp.Pr.AssignedReviewers[0])
+	}
+
+	seen := map[string]bool{}
+	for _, id := range assigned[:3] {
+		if id == author.UserID || id == inactive.UserID {
+			t.Fatalf("Rotation assigned author or inactive user: %v", assigned)
+		}
+		seen[id] = true
+	}
+	if len(seen) != 3 {
+		t.Fatalf("Expected rotation through all 3 reviewers, got %v", assigned)
+	}
+	if assigned[3] != assigned[0] {
+		t.Fatalf("Expected rotation to wrap around to %s, got %s", assigned[0], assigned[3])
+	}
+}
+
+func TestPRAssignmentFallsBackToBackupTeam(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authF" + generateRandomString(4), Username: "AuthF", IsActive: true}
+	away := TeamMember{UserID: "awayF" + generateRandomString(4), Username: "AwayF", IsActive: false}
+	helper := TeamMember{UserID: "helpF" + generateRandomString(4), Username: "HelpF", IsActive: true}
+
+	teamName := "MainTeam" + generateRandomString(4)
+	backupName := "BackupTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, away})
+	createTeamHelper(t, ctx, backupName, []TeamMember{helper})
+
+	mustPostJSON(t, ctx, "/team/settings", TeamSettings{TeamName: teamName, BackupTeams: []string{backupName}})
+
+	body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prF" + generateRandomString(5),
+		PullRequestName: "Needs help",
+		AuthorId:        author.UserID,
+	})
+	var createResp CreatePRResponseWrapper
+	if err := json.Unmarshal(body, &createResp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+
+	pr := createResp.Pr
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != helper.UserID {
+		t.Fatalf("Expected backup reviewer %s, got %v", helper.UserID, pr.AssignedReviewers)
+	}
+	if len(pr.FallbackReviewers) != 1 || pr.FallbackReviewers[0].TeamName != backupName {
+		t.Fatalf("Expected fallback reviewer from %s, got %+v", backupName, pr.FallbackReviewers)
+	}
+}
+
+func TestPRAssignmentForAuthorInSeveralTeams(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authM" + generateRandomString(4), Username: "AuthM", IsActive: true}
+	frontend := TeamMember{UserID: "frontM" + generateRandomString(4), Username: "FrontM", IsActive: true}
+	backend := TeamMember{UserID: "backM" + generateRandomString(4), Username: "BackM", IsActive: true}
+
+	frontName := "FrontTeam" + generateRandomString(4)
+	backName := "BackTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, frontName, []TeamMember{author, frontend})
+	createTeamHelper(t, ctx, backName, []TeamMember{author, backend})
+
+	status, body := postJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prM" + generateRandomString(5),
+		PullRequestName: "Which team?",
+		AuthorId:        author.UserID,
+	})
+	if status != http.StatusConflict {
+		t.Fatalf("Expected 409 without team_name, got %d: %s", status, body)
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code != "TEAM_AMBIGUOUS" {
+		t.Fatalf("Expected TEAM_AMBIGUOUS, got %s", body)
+	}
+
+	body = mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prM" + generateRandomString(5),
+		PullRequestName: "Backend change",
+		AuthorId:        author.UserID,
+		TeamName:        backName,
+	})
+	var createResp CreatePRResponseWrapper
+	if err := json.Unmarshal(body, &createResp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+	if len(createResp.Pr.AssignedReviewers) != 1 || createResp.Pr.AssignedReviewers[0] != backend.UserID {
+		t.Fatalf("Expected reviewer %s from %s, got %v", backend.UserID, backName, createResp.Pr.AssignedReviewers)
+	}
+	if createResp.Pr.TeamName != backName {
+		t.Fatalf("Expected PR team %s, got %q", backName, createResp.Pr.TeamName)
+	}
+
+	status, body = postJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prM" + generateRandomString(5),
+		PullRequestName: "Wrong team",
+		AuthorId:        frontend.UserID,
+		TeamName:        backName,
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for non-member author, got %d: %s", status, body)
+	}
+
+	body = mustPostJSON(t, ctx, "/users/setIsActive", SetActiveRequest{UserId: author.UserID, IsActive: true})
+	var userResp SetActiveResponse
+	if err := json.Unmarshal(body, &userResp); err != nil {
+		t.Fatalf("Failed to unmarshal user: %v", err)
+	}
+	if len(userResp.User.Teams) != 2 {
+		t.Fatalf("Expected author to list 2 teams, got %v", userResp.User.Teams)
+	}
+}
+
+func TestSetInactiveReassignsOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authI" + generateRandomString(4), Username: "AuthI", IsActive: true}
+	leaving := TeamMember{UserID: "leavI" + generateRandomString(4), Username: "LeavI", IsActive: true}
+	staying := TeamMember{UserID: "stayI" + generateRandomString(4), Username: "StayI", IsActive: false}
+
+	teamName := "InactiveTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, leaving, staying})
+
+	prID := "prI" + generateRandomString(5)
+	mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   prID,
+		PullRequestName: "Handover",
+		AuthorId:        author.UserID,
+	})
+
+	mustPostJSON(t, ctx, "/users/setIsActive", SetActiveRequest{UserId: staying.UserID, IsActive: true})
+
+	body := mustPostJSON(t, ctx, "/users/setIsActive", SetActiveRequest{
+		UserId:              leaving.UserID,
+		IsActive:            false,
+		ReassignOpenReviews: true,
+	})
+	var resp SetActiveResponse
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("Failed to unmarshal response: %v", err)
+	}
+
+	if resp.User.IsActive {
+		t.Fatalf("Expected %s to be inactive", leaving.UserID)
+	}
+	if len(resp.Reassigned) != 1 || resp.Reassigned[0].PullRequestId != prID || resp.Reassigned[0].NewUserId != staying.UserID {
+		t.Fatalf("Expected %s to be handed over to %s, got %+v", prID, staying.UserID, resp.Reassigned)
+	}
+}
+
+func TestPRDraftCloseReopenLifecycle(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authL" + generateRandomString(4), Username: "AuthL", IsActive: true}
+	reviewer := TeamMember{UserID: "revL" + generateRandomString(4), Username: "RevL", IsActive: true}
+
+	teamName := "LifecycleTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, reviewer})
+
+	prID := "prL" + generateRandomString(5)
+	pr := lifecycleStep(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   prID,
+		PullRequestName: "Work in progress",
+		AuthorId:        author.UserID,
+		Draft:           true,
+	}, "DRAFT")
+	if len(pr.AssignedReviewers) != 0 {
+		t.Fatalf("Expected no reviewers on a draft, got %v", pr.AssignedReviewers)
+	}
+
+	status, body := postJSON(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prID})
+	if status != http.StatusConflict {
+		t.Fatalf("Expected 409 when merging a draft, got %d: %s", status, body)
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code != "INVALID_TRANSITION" {
+		t.Fatalf("Expected INVALID_TRANSITION, got %s", body)
+	}
+
+	pr = lifecycleStep(t, ctx, "/pullRequest/ready", MergePRRequest{PullRequestId: prID}, "OPEN")
+	if len(pr.AssignedReviewers) != 1 || pr.AssignedReviewers[0] != reviewer.UserID {
+		t.Fatalf("Expected reviewer %s once ready, got %v", reviewer.UserID, pr.AssignedReviewers)
+	}
+
+	pr = lifecycleStep(t, ctx, "/pullRequest/close", MergePRRequest{PullRequestId: prID}, "CLOSED")
+	if pr.ClosedAt == nil {
+		t.Fatal("Expected closedAt to be set")
+	}
+
+	status, body = postJSON(t, ctx, "/pullRequest/reassign", ReassignRequest{PullRequestId: prID, OldUserId: reviewer.UserID})
+	if status != http.StatusConflict {
+		t.Fatalf("Expected 409 when reassigning on a closed PR, got %d: %s", status, body)
+	}
+
+	pr = lifecycleStep(t, ctx, "/pullRequest/reopen", MergePRRequest{PullRequestId: prID}, "OPEN")
+	if pr.ClosedAt != nil {
+		t.Fatalf("Expected closedAt to be cleared, got %v", pr.ClosedAt)
+	}
+
+	lifecycleStep(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prID}, "MERGED")
+
+	status, body = postJSON(t, ctx, "/pullRequest/close", MergePRRequest{PullRequestId: prID})
+	if status != http.StatusConflict {
+		t.Fatalf("Expected 409 when closing a merged PR, got %d: %s", status, body)
+	}
+}
+
+func lifecycleStep(t *testing.T, ctx context.Context, path string, req interface{}, wantStatus string) PullRequest {
+	t.Helper()
+
+	var resp CreatePRResponseWrapper
+	if err := json.Unmarshal(mustPostJSON(t, ctx, path, req), &resp); err != nil {
+		t.Fatalf("Failed to unmarshal %s response: %v", path, err)
+	}
+	if resp.Pr.Status != wantStatus {
+		t.Fatalf("Expected status %s after %s, got %s", wantStatus, path, resp.Pr.Status)
+	}
+	return resp.Pr
+}
+
+func TestPRReviewDecisions(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authR" + generateRandomString(4), Username: "AuthR", IsActive: true}
+	first := TeamMember{UserID: "revR1" + generateRandomString(4), Username: "RevR1", IsActive: true}
+	second := TeamMember{UserID: "revR2" + generateRandomString(4), Username: "RevR2", IsActive: true}
+
+	teamName := "ReviewTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, first, second})
+
+	prID := "prR" + generateRandomString(5)
+	mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   prID,
+		PullRequestName: "Needs eyes",
+		AuthorId:        author.UserID,
+	})
+
+	if !awaitingAction(t, ctx, first.UserID, prID) {
+		t.Fatalf("Expected %s to await %s before reviewing", prID, first.UserID)
+	}
+
+	status, body := postJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: first.UserID, Decision: "LGTM"})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for unknown decision, got %d: %s", status, body)
+	}
+
+	status, body = postJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: author.UserID, Decision: "APPROVED"})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a user who is not a reviewer, got %d: %s", status, body)
+	}
+
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: first.UserID, Decision: "COMMENTED"})
+	body = mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: first.UserID, Decision: "APPROVED"})
+	var resp CreatePRResponseWrapper
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("Failed to unmarshal reviewed PR: %v", err)
+	}
+	if len(resp.Pr.Reviews) != 2 {
+		t.Fatalf("Expected 2 reviews, got %+v", resp.Pr.Reviews)
+	}
+	for _, r := range resp.Pr.Reviews {
+		switch r.UserId {
+		case first.UserID:
+			if r.Decision != "APPROVED" || r.DecidedAt == nil {
+				t.Fatalf("Expected APPROVED decision with timestamp, got %+v", r)
+			}
+		case second.UserID:
+			if r.Decision != "" {
+				t.Fatalf("Expected no decision from %s, got %s", second.UserID, r.Decision)
+			}
+		default:
+			t.Fatalf("Unexpected reviewer %s", r.UserId)
+		}
+	}
+
+	if awaitingAction(t, ctx, first.UserID, prID) {
+		t.Fatalf("Expected %s not to await %s after approval", prID, first.UserID)
+	}
+	if !awaitingAction(t, ctx, second.UserID, prID) {
+		t.Fatalf("Expected %s to still await %s", prID, second.UserID)
+	}
+
+	mustPostJSON(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prID})
+	status, body = postJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: second.UserID, Decision: "APPROVED"})
+	if status != http.StatusConflict {
+		t.Fatalf("Expected 409 when reviewing a merged PR, got %d: %s", status, body)
+	}
+}
+
+func awaitingAction(t *testing.T, ctx context.Context, userID, prID string) bool {
+	t.Helper()
+
+	var reviews UserReviews
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/users/getReview?user_id="+userID), &reviews); err != nil {
+		t.Fatalf("Failed to unmarshal reviews of %s: %v", userID, err)
+	}
+	for _, pr := range reviews.PullRequests {
+		if pr.PullRequestId == prID {
+			return pr.AwaitingAction
+		}
+	}
+	t.Fatalf("PR %s not found in reviews of %s", prID, userID)
+	return false
+}
+
+func TestPRMergeRequiresApprovals(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authG" + generateRandomString(4), Username: "AuthG", IsActive: true}
+	first := TeamMember{UserID: "revG1" + generateRandomString(4), Username: "RevG1", IsActive: true}
+	second := TeamMember{UserID: "revG2" + generateRandomString(4), Username: "RevG2", IsActive: true}
+
+	teamName := "GatedTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, first, second})
+
+	required := 2
+	mustPostJSON(t, ctx, "/team/settings", TeamSettings{TeamName: teamName, RequiredApprovals: &required})
+
+	prID := "prG" + generateRandomString(5)
+	mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   prID,
+		PullRequestName: "Gated change",
+		AuthorId:        author.UserID,
+	})
+
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: first.UserID, Decision: "APPROVED"})
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: second.UserID, Decision: "CHANGES_REQUESTED"})
+
+	report := getMergeability(t, ctx, prID)
+	if report.Mergeable || report.Approvals != 1 || report.RequiredApprovals != 2 {
+		t.Fatalf("Expected 1 of 2 approvals and not mergeable, got %+v", report)
+	}
+	if len(report.ChangesRequestedBy) != 1 || report.ChangesRequestedBy[0] != second.UserID {
+		t.Fatalf("Expected changes requested by %s, got %v", second.UserID, report.ChangesRequestedBy)
+	}
+	if len(report.UnmetConditions) != 2 {
+		t.Fatalf("Expected 2 unmet conditions, got %v", report.UnmetConditions)
+	}
+
+	status, body := postJSON(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prID})
+	if status != http.StatusConflict {
+		t.Fatalf("Expected 409 when merging without approvals, got %d: %s", status, body)
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code != "MERGE_BLOCKED" {
+		t.Fatalf("Expected MERGE_BLOCKED, got %s", body)
+	}
+
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: second.UserID, Decision: "APPROVED"})
+	if report := getMergeability(t, ctx, prID); !report.Mergeable || len(report.UnmetConditions) != 0 {
+		t.Fatalf("Expected PR to be mergeable after both approvals, got %+v", report)
+	}
+	lifecycleStep(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prID}, "MERGED")
+
+	forcedID := "prG" + generateRandomString(5)
+	mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   forcedID,
+		PullRequestName: "Hotfix",
+		AuthorId:        author.UserID,
+	})
+	status, body = postJSON(t, ctx, "/pullRequest/merge", MergePRRequest{
+		PullRequestId: forcedID,
+		Force:         true,
+		Reason:        "production incident",
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a forced merge without forced_by, got %d: %s", status, body)
+	}
+	lifecycleStep(t, ctx, "/pullRequest/merge", MergePRRequest{
+		PullRequestId: forcedID,
+		Force:         true,
+		ForcedBy:      author.UserID,
+		Reason:        "production incident",
+	}, "MERGED")
+
+	report = getMergeability(t, ctx, forcedID)
+	if len(report.Overrides) != 1 {
+		t.Fatalf("Expected the forced merge to be recorded, got %+v", report.Overrides)
+	}
+	if o := report.Overrides[0]; o.ForcedBy != author.UserID || o.Reason != "production incident" || len(o.UnmetConditions) == 0 {
+		t.Fatalf("Unexpected override record %+v", o)
+	}
+}
+
+func getMergeability(t *testing.T, ctx context.Context, prID string) Mergeability {
+	t.Helper()
+
+	var report Mergeability
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/pullRequest/mergeability?pull_request_id="+prID), &report); err != nil {
+		t.Fatalf("Failed to unmarshal mergeability of %s: %v", prID, err)
+	}
+	return report
+}
@@ -0,0 +1,82 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"testing"
+)
+
+// TestReviewReminders backdates the review of its own pull request, so that
+// only it is due for a reminder, and relies on docker-compose.test.yml
+// looking for due reminders every second.
+func TestReviewReminders(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "rmA" + generateRandomString(4), Username: "RmA", IsActive: true}
+	members := []TeamMember{author}
+	for i := 0; i < 2; i++ {
+		members = append(members, TeamMember{UserID: "rmR" + generateRandomString(5), Username: "RmR", IsActive: true})
+	}
+	createTeamHelper(t, ctx, "ReminderTeam"+generateRandomString(4), members)
+
+	prID := "prRm" + generateRandomString(5)
+	title := "Forgotten " + generateRandomString(6)
+	secret := "secret-" + generateRandomString(8)
+	subscriber, url := startEventSubscriber(t, secret, prID)
+	mustPostJSON(t, ctx, "/webhooks/subscriptions", map[string]any{
+		"url":    url,
+		"secret": secret,
+		"events": []string{"pull_request.review_reminder"},
+	})
+
+	var createResp CreatePRResponseWrapper
+	body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   prID,
+		PullRequestName: title,
+		AuthorId:        author.UserID,
+	})
+	if err := json.Unmarshal(body, &createResp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+	reviewers := createResp.Pr.AssignedReviewers
+	if len(reviewers) != 2 {
+		t.Fatalf("Expected 2 reviewers, got %+v", createResp.Pr)
+	}
+
+	// The reviewer who approves is reminded no more; the other one is
+	// reminded again and again.
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{PullRequestId: prID, UserId: reviewers[0], Decision: "APPROVED"})
+
+	db := openDB(t, ctx)
+	backdate := func(query string) {
+		t.Helper()
+		if _, err := db.Exec(ctx, query, title); err != nil {
+			t.Fatalf("Failed to backdate the review: %v", err)
+		}
+	}
+	backdate(`UPDATE pull_request_reviewers SET assigned_at = assigned_at - interval '2 days'
+		WHERE pull_request_id = (SELECT id FROM pull_requests WHERE title = $1)`)
+	subscriber.waitFor(t, 1)
+	backdate(`UPDATE review_reminders SET reminded_at = reminded_at - interval '2 days'
+		WHERE pull_request_id = (SELECT id FROM pull_requests WHERE title = $1)`)
+
+	events := subscriber.waitFor(t, 2)
+	for _, event := range events {
+		if event.Type != "pull_request.review_reminder" || event.Data.ReviewerId != reviewers[1] ||
+			event.Data.WaitingHours <= 0 {
+			t.Fatalf("Expected reminders to %s only, got %+v", reviewers[1], events)
+		}
+	}
+	var numbers []int
+	for _, event := range events {
+		numbers = append(numbers, event.Data.Reminder)
+	}
+	// The first delivery is retried, so the reminders may arrive out of order.
+	if !slices.Contains(numbers, 1) || slices.Max(numbers) < 2 {
+		t.Fatalf("Expected numbered repeated reminders, got %v", numbers)
+	}
+
+	mustPostJSON(t, ctx, "/pullRequest/close", MergePRRequest{PullRequestId: prID})
+}
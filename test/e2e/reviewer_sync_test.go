@@ -0,0 +1,154 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// githubStubAddr is where docker-compose.test.yml points GITHUB_API_URL.
+const githubStubAddr = ":18091"
+
+type reviewerRequest struct {
+	Method    string
+	Path      string
+	Reviewers []string
+}
+
+// githubStub records requested_reviewers calls for one repository and fails
+// the first one with 502 to exercise the retries. Calls for other
+// repositories, made by tests running in parallel, are accepted and dropped.
+type githubStub struct {
+	repository string
+
+	mu       sync.Mutex
+	failed   bool
+	requests []reviewerRequest
+}
+
+func startGitHubStub(t *testing.T, repository string) *githubStub {
+	t.Helper()
+
+	stub := &githubStub{repository: repository}
+	ln, err := net.Listen("tcp", githubStubAddr)
+	if err != nil {
+		t.Fatalf("Failed to listen on %s: %v", githubStubAddr, err)
+	}
+	srv := &http.Server{Handler: stub}
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+	return stub
+}
+
+func (s *githubStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") != "Bearer e2e-github-token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !strings.HasPrefix(r.URL.Path, "/repos/"+s.repository+"/") {
+		w.WriteHeader(http.StatusCreated)
+		return
+	}
+
+	var body struct {
+		Reviewers []string `json:"reviewers"`
+	}
+	_ = json.NewDecoder(r.Body).Decode(&body)
+	sort.Strings(body.Reviewers)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.failed {
+		s.failed = true
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	s.requests = append(s.requests, reviewerRequest{Method: r.Method, Path: r.URL.Path, Reviewers: body.Reviewers})
+	w.WriteHeader(http.StatusCreated)
+}
+
+// waitFor returns the recorded requests once there are at least n of them.
+func (s *githubStub) waitFor(t *testing.T, n int) []reviewerRequest {
+	t.Helper()
+
+	var got []reviewerRequest
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		got = append([]reviewerRequest(nil), s.requests...)
+		s.mu.Unlock()
+		if len(got) >= n {
+			return got
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("Expected %d write-back requests, got %+v", n, got)
+	return nil
+}
+
+func TestReviewersWrittenBackToGitHub(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	repository := "acme/writeback-" + generateRandomString(6)
+	stub := startGitHubStub(t, repository)
+
+	author := TeamMember{UserID: "wbA" + generateRandomString(4), Username: "wb-author-" + generateRandomString(6), IsActive: true}
+	members := []TeamMember{author}
+	for i := 0; i < 3; i++ {
+		members = append(members, TeamMember{
+			UserID:   "wbR" + generateRandomString(5),
+			Username: "wb-reviewer-" + generateRandomString(6),
+			IsActive: true,
+		})
+	}
+	createTeamHelper(t, ctx, "WriteBackTeam"+generateRandomString(4), members)
+
+	usernames := make(map[string]string, len(members))
+	for _, m := range members {
+		usernames[m.UserID] = m.Username
+	}
+
+	fixture := strings.NewReplacer("LOGIN", author.Username, "REPOSITORY", repository)
+	result := mustDeliverGitHub(t, ctx, "pull_request", loadGitHubFixture(t, fixture, "pull_request.opened.json"))
+	mustDeliverGitHub(t, ctx, "pull_request", loadGitHubFixture(t, fixture, "pull_request.ready_for_review.json"))
+
+	path := "/repos/" + repository + "/pulls/42/requested_reviewers"
+	requests := stub.waitFor(t, 1)
+	if requests[0].Method != http.MethodPost || requests[0].Path != path || len(requests[0].Reviewers) != 2 {
+		t.Fatalf("Expected both reviewers to be requested after a retry, got %+v", requests[0])
+	}
+
+	var assigned []string
+	for _, login := range requests[0].Reviewers {
+		for id, username := range usernames {
+			if username == login {
+				assigned = append(assigned, id)
+			}
+		}
+	}
+	if len(assigned) != 2 {
+		t.Fatalf("Expected requested logins to be reviewer usernames, got %v", requests[0].Reviewers)
+	}
+
+	mustPostJSON(t, ctx, "/pullRequest/reassign", ReassignRequest{PullRequestId: result.PullRequestId, OldUserId: assigned[0]})
+
+	requests = stub.waitFor(t, 3)
+	removed, added := requests[1], requests[2]
+	if removed.Method != http.MethodDelete || len(removed.Reviewers) != 1 || removed.Reviewers[0] != usernames[assigned[0]] {
+		t.Fatalf("Expected the replaced reviewer to be removed, got %+v", removed)
+	}
+	if added.Method != http.MethodPost || len(added.Reviewers) != 2 {
+		t.Fatalf("Expected the new reviewer set to be requested, got %+v", added)
+	}
+}
@@ -0,0 +1,211 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"slices"
+	"testing"
+	"time"
+)
+
+// roundTheClock makes every second a working one, so that a tiny SLA runs out
+// while the test waits.
+var roundTheClock = TeamReviewSLA{
+	FirstDecisionHours: 0.001,
+	WorkStart:          "00:00",
+	WorkEnd:            "24:00",
+	WorkDays:           []int{1, 2, 3, 4, 5, 6, 7},
+	Timezone:           "UTC",
+}
+
+func createSLATeam(t *testing.T, ctx context.Context, prefix string) (string, []TeamMember) {
+	t.Helper()
+
+	members := []TeamMember{{UserID: prefix + "A" + generateRandomString(5), Username: "SlaA", IsActive: true}}
+	for i := 0; i < 3; i++ {
+		members = append(members, TeamMember{
+			UserID:   prefix + "R" + generateRandomString(5),
+			Username: "SlaR" + generateRandomString(4),
+			IsActive: true,
+		})
+	}
+	teamName := prefix + "Team" + generateRandomString(5)
+	createTeamHelper(t, ctx, teamName, members)
+	return teamName, members
+}
+
+func createSLAPR(t *testing.T, ctx context.Context, authorID string) PullRequest {
+	t.Helper()
+
+	var resp CreatePRResponseWrapper
+	body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prSla" + generateRandomString(6),
+		PullRequestName: "Slow review " + generateRandomString(6),
+		AuthorId:        authorID,
+	})
+	if err := json.Unmarshal(body, &resp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+	if len(resp.Pr.AssignedReviewers) != 2 {
+		t.Fatalf("Expected 2 reviewers, got %+v", resp.Pr)
+	}
+	return resp.Pr
+}
+
+func overdueReviewers(t *testing.T, ctx context.Context, teamName, prID string) []string {
+	t.Helper()
+
+	var list OverdueReviewList
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/pullRequest/overdue?team_name="+teamName), &list); err != nil {
+		t.Fatalf("Failed to unmarshal overdue reviews: %v", err)
+	}
+	var reviewers []string
+	for _, r := range list.Reviews {
+		if r.PullRequestId != prID {
+			continue
+		}
+		if r.TeamName != teamName || r.SlaHours != roundTheClock.FirstDecisionHours ||
+			r.WorkingHours < r.SlaHours || r.Severity == "" {
+			t.Fatalf("Expected an overdue review past the team SLA, got %+v", r)
+		}
+		reviewers = append(reviewers, r.ReviewerId)
+	}
+	slices.Sort(reviewers)
+	return reviewers
+}
+
+func TestReviewSLA(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	teamName, members := createSLATeam(t, ctx, "sla")
+
+	status, body := postJSON(t, ctx, "/team/sla", map[string]any{"team_name": teamName, "first_decision_hours": 0})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a zero SLA, got %d: %s", status, body)
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code != "INVALID_SLA" {
+		t.Fatalf("Expected INVALID_SLA, got %s", body)
+	}
+	status, body = postJSON(t, ctx, "/team/sla", map[string]any{
+		"team_name": teamName, "first_decision_hours": 8, "work_start": "18:00", "work_end": "09:00",
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a working day ending before it starts, got %d: %s", status, body)
+	}
+	status, _ = postJSON(t, ctx, "/team/sla", map[string]any{"team_name": "NoSuchTeam", "first_decision_hours": 8})
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown team, got %d", status)
+	}
+
+	var sla TeamReviewSLA
+	if err := json.Unmarshal(mustPostJSON(t, ctx, "/team/sla", map[string]any{
+		"team_name": teamName, "first_decision_hours": 8,
+	}), &sla); err != nil {
+		t.Fatalf("Failed to unmarshal SLA: %v", err)
+	}
+	if sla.WorkStart != "09:00" || sla.WorkEnd != "18:00" || !slices.Equal(sla.WorkDays, []int{1, 2, 3, 4, 5}) ||
+		sla.Timezone != "UTC" || sla.AutoReassign {
+		t.Fatalf("Expected default working hours, got %+v", sla)
+	}
+
+	update := roundTheClock
+	update.TeamName = teamName
+	mustPostJSON(t, ctx, "/team/sla", update)
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/team/sla?team_name="+teamName), &sla); err != nil {
+		t.Fatalf("Failed to unmarshal SLA: %v", err)
+	}
+	if sla.FirstDecisionHours != update.FirstDecisionHours || sla.WorkEnd != "24:00" || len(sla.WorkDays) != 7 {
+		t.Fatalf("Expected the SLA to be replaced, got %+v", sla)
+	}
+
+	pr := createSLAPR(t, ctx, members[0].UserID)
+	expected := slices.Clone(pr.AssignedReviewers)
+	slices.Sort(expected)
+
+	var reviewers []string
+	deadline := time.Now().Add(15 * time.Second)
+	for time.Now().Before(deadline) {
+		if reviewers = overdueReviewers(t, ctx, teamName, pr.PullRequestId); len(reviewers) == 2 {
+			break
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	if !slices.Equal(reviewers, expected) {
+		t.Fatalf("Expected both reviewers to be overdue, got %v", reviewers)
+	}
+
+	// Without auto-reassignment the reviewers stay in place.
+	var prResp CreatePRResponseWrapper
+	body = mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{
+		PullRequestId: pr.PullRequestId, UserId: expected[0], Decision: "APPROVED",
+	})
+	if err := json.Unmarshal(body, &prResp); err != nil {
+		t.Fatalf("Failed to unmarshal reviewed PR: %v", err)
+	}
+	if !slices.Contains(prResp.Pr.AssignedReviewers, expected[1]) {
+		t.Fatalf("Expected the overdue reviewer to keep the review, got %+v", prResp.Pr)
+	}
+	if reviewers = overdueReviewers(t, ctx, teamName, pr.PullRequestId); !slices.Equal(reviewers, expected[1:]) {
+		t.Fatalf("Expected only the reviewer who has not decided to be overdue, got %v", reviewers)
+	}
+
+	status, _ = postJSON(t, ctx, "/team/sla/delete", map[string]string{"team_name": teamName})
+	if status != http.StatusNoContent {
+		t.Fatalf("Expected 204 on SLA removal, got %d", status)
+	}
+	if reviewers = overdueReviewers(t, ctx, teamName, pr.PullRequestId); len(reviewers) != 0 {
+		t.Fatalf("Expected no overdue reviews without an SLA, got %v", reviewers)
+	}
+	status, _ = postJSON(t, ctx, "/team/sla/delete", map[string]string{"team_name": teamName})
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 on removing a missing SLA, got %d", status)
+	}
+}
+
+func TestReviewSLAAutoReassign(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	teamName, members := createSLATeam(t, ctx, "slaR")
+	update := roundTheClock
+	update.TeamName = teamName
+	update.AutoReassign = true
+	mustPostJSON(t, ctx, "/team/sla", update)
+
+	pr := createSLAPR(t, ctx, members[0].UserID)
+	var spare string
+	for _, m := range members[1:] {
+		if !slices.Contains(pr.AssignedReviewers, m.UserID) {
+			spare = m.UserID
+		}
+	}
+
+	reassigned := false
+	deadline := time.Now().Add(15 * time.Second)
+	for !reassigned && time.Now().Before(deadline) {
+		var reviews UserReviews
+		if err := json.Unmarshal(mustGetJSON(t, ctx, "/users/getReview?user_id="+spare), &reviews); err != nil {
+			t.Fatalf("Failed to unmarshal reviews: %v", err)
+		}
+		for _, p := range reviews.PullRequests {
+			if p.PullRequestId == pr.PullRequestId {
+				reassigned = true
+			}
+		}
+		time.Sleep(500 * time.Millisecond)
+	}
+	if !reassigned {
+		t.Fatalf("Expected an overdue review to be reassigned to %s", spare)
+	}
+
+	// Every reviewer goes overdue in turn; closing the PR stops the cycle.
+	mustPostJSON(t, ctx, "/pullRequest/close", MergePRRequest{PullRequestId: pr.PullRequestId})
+
+	if stats := getStats(t, ctx, url.Values{"team_name": {teamName}}); stats.Reassignments.Total < 1 {
+		t.Fatalf("Expected the SLA reassignment to be counted, got %+v", stats.Reassignments)
+	}
+}
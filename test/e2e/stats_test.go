@@ -0,0 +1,377 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func getStats(t *testing.T, ctx context.Context, query url.Values) StatsResponse {
+	t.Helper()
+
+	var stats StatsResponse
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/stats?"+query.Encode()), &stats); err != nil {
+		t.Fatalf("Failed to unmarshal stats: %v", err)
+	}
+	return stats
+}
+
+func TestStatisticsWindow(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	start := time.Now().Add(-time.Minute)
+	author := TeamMember{UserID: "stA" + generateRandomString(4), Username: "StA", IsActive: true}
+	members := []TeamMember{author}
+	for i := 0; i < 3; i++ {
+		members = append(members, TeamMember{UserID: "stR" + generateRandomString(5), Username: "StR", IsActive: true})
+	}
+	teamName := "StatsTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, members)
+
+	createPR := func() PullRequest {
+		var resp CreatePRResponseWrapper
+		body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+			PullRequestId:   "prSt" + generateRandomString(6),
+			PullRequestName: "Counted",
+			AuthorId:        author.UserID,
+		})
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("Failed to unmarshal created PR: %v", err)
+		}
+		return resp.Pr
+	}
+
+	merged := createPR()
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{
+		PullRequestId: merged.PullRequestId, UserId: merged.AssignedReviewers[0], Decision: "COMMENTED",
+	})
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{
+		PullRequestId: merged.PullRequestId, UserId: merged.AssignedReviewers[0], Decision: "APPROVED",
+	})
+	mustPostJSON(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: merged.PullRequestId})
+
+	reassigned := createPR()
+	mustPostJSON(t, ctx, "/pullRequest/reassign", ReassignRequest{
+		PullRequestId: reassigned.PullRequestId, OldUserId: reassigned.AssignedReviewers[0],
+	})
+	closed := createPR()
+	mustPostJSON(t, ctx, "/pullRequest/close", MergePRRequest{PullRequestId: closed.PullRequestId})
+
+	stats := getStats(t, ctx, url.Values{"team_name": {teamName}})
+	if stats.TeamName != teamName || stats.TotalPullRequests != 3 || stats.DraftPullRequests != 0 ||
+		stats.OpenPullRequests != 1 || stats.MergedPullRequests != 1 || stats.ClosedPullRequests != 1 {
+		t.Fatalf("Expected 3 PRs of the team, 1 open, 1 merged and 1 closed, got %+v", stats)
+	}
+	first := stats.TimeToFirstReview
+	if first.Count != 1 || first.P50Hours == nil || *first.P50Hours < 0 || *first.P99Hours < *first.P50Hours {
+		t.Fatalf("Expected the time to first review of the reviewed PR, got %+v", first)
+	}
+	if stats.TimeToMerge.Count != 1 || stats.TimeToMerge.P90Hours == nil || *stats.TimeToMerge.P90Hours < *first.P50Hours {
+		t.Fatalf("Expected the time to merge of the merged PR, got %+v", stats.TimeToMerge)
+	}
+	if stats.Reassignments.Total != 1 || stats.Reassignments.PullRequests != 1 {
+		t.Fatalf("Expected 1 reassignment, got %+v", stats.Reassignments)
+	}
+	opened, mergedDaily := 0, 0
+	for _, day := range stats.Throughput {
+		if _, err := time.Parse(time.DateOnly, day.Date); err != nil {
+			t.Fatalf("Expected throughput per date, got %+v", stats.Throughput)
+		}
+		opened += day.Opened
+		mergedDaily += day.Merged
+	}
+	if opened != 3 || mergedDaily != 1 {
+		t.Fatalf("Expected 3 PRs opened and 1 merged over the days, got %+v", stats.Throughput)
+	}
+	assigned := 0
+	for _, r := range stats.ReviewerStats {
+		assigned += r.AssignedCount
+	}
+	if assigned != 6 {
+		t.Fatalf("Expected the 6 reviews of the team, got %+v", stats.ReviewerStats)
+	}
+
+	later := time.Now().Add(time.Hour)
+	stats = getStats(t, ctx, url.Values{
+		"team_name": {teamName},
+		"from":      {later.Format(time.RFC3339)},
+		"to":        {later.Add(time.Hour).Format(time.RFC3339)},
+	})
+	if stats.TotalPullRequests != 0 || stats.TimeToMerge.Count != 0 || stats.TimeToMerge.P50Hours != nil ||
+		stats.Reassignments.Total != 0 || len(stats.ReviewerStats) != 0 {
+		t.Fatalf("Expected nothing in a later period, got %+v", stats)
+	}
+
+	stats = getStats(t, ctx, url.Values{
+		"team_name": {teamName},
+		"from":      {start.Format(time.RFC3339)},
+		"to":        {later.Format(time.RFC3339)},
+	})
+	if stats.TotalPullRequests != 3 || stats.MergedPullRequests != 1 {
+		t.Fatalf("Expected the team's PRs in the period around them, got %+v", stats)
+	}
+
+	query := url.Values{"from": {later.Format(time.RFC3339)}, "to": {start.Format(time.RFC3339)}}
+	status, body := getJSON(t, ctx, "/stats?"+query.Encode())
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a period ending before it starts, got %d: %s", status, body)
+	}
+	var errResp ErrorResponse
+	if err := json.Unmarshal(body, &errResp); err != nil || errResp.Error.Code != "INVALID_PERIOD" {
+		t.Fatalf("Expected INVALID_PERIOD, got %s", body)
+	}
+	status, _ = getJSON(t, ctx, "/stats?team_name=NoSuchTeam"+generateRandomString(6))
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown team, got %d", status)
+	}
+	status, _ = getJSON(t, ctx, "/stats?from=yesterday")
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a malformed period, got %d", status)
+	}
+}
+
+func TestReviewerWorkload(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "wlA" + generateRandomString(4), Username: "WlA", IsActive: true}
+	members := []TeamMember{author}
+	for i := 0; i < 3; i++ {
+		members = append(members, TeamMember{UserID: "wlR" + generateRandomString(5), Username: "WlR", IsActive: true})
+	}
+	teamName := "WorkloadTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, members)
+
+	var prs []PullRequest
+	for i := 0; i < 3; i++ {
+		var resp CreatePRResponseWrapper
+		body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+			PullRequestId:   "prWl" + generateRandomString(6),
+			PullRequestName: "Loaded",
+			AuthorId:        author.UserID,
+		})
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("Failed to unmarshal created PR: %v", err)
+		}
+		prs = append(prs, resp.Pr)
+	}
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{
+		PullRequestId: prs[0].PullRequestId, UserId: prs[0].AssignedReviewers[0], Decision: "APPROVED",
+	})
+	mustPostJSON(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prs[0].PullRequestId})
+
+	var teams struct {
+		Teams []TeamWorkload `json:"teams"`
+	}
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/stats/teams?team_name="+teamName), &teams); err != nil {
+		t.Fatalf("Failed to unmarshal team workloads: %v", err)
+	}
+	if len(teams.Teams) != 1 || teams.Teams[0].TeamName != teamName || len(teams.Teams[0].Reviewers) != len(members) {
+		t.Fatalf("Expected the workload of every member of the team, got %+v", teams)
+	}
+	team := teams.Teams[0]
+	assigned, merged, open := 0, 0, 0
+	for _, r := range team.Reviewers {
+		assigned += r.AssignedReviews
+		merged += r.MergedReviews
+		open += r.OpenReviews
+		if (r.OpenReviews > 0) != (r.AverageOpenReviewAgeHours != nil) {
+			t.Fatalf("Expected the average age of open reviews only for reviewers with some, got %+v", r)
+		}
+	}
+	wantAssigned := 0
+	for _, pr := range prs {
+		wantAssigned += len(pr.AssignedReviewers)
+	}
+	wantMerged := len(prs[0].AssignedReviewers)
+	if assigned != wantAssigned || merged != wantMerged || open != wantAssigned-wantMerged {
+		t.Fatalf("Expected %d reviews, %d of them merged, got %+v", wantAssigned, wantMerged, team.Reviewers)
+	}
+	if team.AssignmentsGini <= 0 || team.AssignmentsGini >= 1 {
+		t.Fatalf("Expected an uneven split since the author reviews nothing, got gini %v", team.AssignmentsGini)
+	}
+
+	reviewerID := prs[0].AssignedReviewers[0]
+	var workload ReviewerWorkload
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/stats/reviewers/"+reviewerID), &workload); err != nil {
+		t.Fatalf("Failed to unmarshal reviewer workload: %v", err)
+	}
+	if workload.ReviewerId != reviewerID || len(workload.Teams) != 1 || workload.Teams[0].TeamName != teamName {
+		t.Fatalf("Expected the reviewer's workload in the team, got %+v", workload)
+	}
+	share := workload.Teams[0].AssignmentShare
+	if workload.MergedReviews != 1 || workload.AssignedReviews != workload.Teams[0].AssignedReviews ||
+		share != float64(workload.AssignedReviews)/float64(wantAssigned) {
+		t.Fatalf("Expected 1 merged review and the reviewer's share of %d, got %+v", wantAssigned, workload)
+	}
+
+	status, _ := getJSON(t, ctx, "/stats/reviewers/NoSuchUser"+generateRandomString(6))
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown reviewer, got %d", status)
+	}
+	status, _ = getJSON(t, ctx, "/stats/teams?team_name=NoSuchTeam"+generateRandomString(6))
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown team, got %d", status)
+	}
+}
+
+func getExport(t *testing.T, ctx context.Context, path, accept string) (int, http.Header, []byte) {
+	t.Helper()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, BaseURL+path, nil)
+	if err != nil {
+		t.Fatalf("Failed to create GET request: %v", err)
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	client := &http.Client{Timeout: 5 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute GET request to %s: %v", path, err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("Failed to read response body: %v", err)
+	}
+
+	return resp.StatusCode, resp.Header, respBody
+}
+
+func decodeNDJSON[T any](t *testing.T, body []byte) []T {
+	t.Helper()
+
+	var rows []T
+	dec := json.NewDecoder(bytes.NewReader(body))
+	for {
+		var row T
+		err := dec.Decode(&row)
+		if errors.Is(err, io.EOF) {
+			return rows
+		}
+		if err != nil {
+			t.Fatalf("Failed to decode NDJSON row: %v in %s", err, body)
+		}
+		rows = append(rows, row)
+	}
+}
+
+func TestStatisticsExport(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "exA" + generateRandomString(4), Username: "ExA", IsActive: true}
+	members := []TeamMember{author}
+	for i := 0; i < 3; i++ {
+		members = append(members, TeamMember{UserID: "exR" + generateRandomString(5), Username: "ExR", IsActive: true})
+	}
+	teamName := "ExportTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, members)
+
+	var prs []PullRequest
+	for _, name := range []string{"Exported, \"quoted\"", "=HYPERLINK(\"http://example.com\")"} {
+		var resp CreatePRResponseWrapper
+		body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+			PullRequestId:   "prEx" + generateRandomString(6),
+			PullRequestName: name,
+			AuthorId:        author.UserID,
+		})
+		if err := json.Unmarshal(body, &resp); err != nil {
+			t.Fatalf("Failed to unmarshal created PR: %v", err)
+		}
+		prs = append(prs, resp.Pr)
+	}
+	mustPostJSON(t, ctx, "/pullRequest/review", ReviewRequest{
+		PullRequestId: prs[0].PullRequestId, UserId: prs[0].AssignedReviewers[0], Decision: "APPROVED",
+	})
+	mustPostJSON(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prs[0].PullRequestId})
+	oldReviewer := prs[1].AssignedReviewers[0]
+	var reassigned ReassignResponse
+	body := mustPostJSON(t, ctx, "/pullRequest/reassign", ReassignRequest{
+		PullRequestId: prs[1].PullRequestId, OldUserId: oldReviewer,
+	})
+	if err := json.Unmarshal(body, &reassigned); err != nil {
+		t.Fatalf("Failed to unmarshal reassignment: %v", err)
+	}
+
+	query := "?team_name=" + teamName
+	status, header, body := getExport(t, ctx, "/stats/export/pullRequests"+query, "application/x-ndjson")
+	if status != http.StatusOK || !strings.HasPrefix(header.Get("Content-Type"), "application/x-ndjson") {
+		t.Fatalf("Expected NDJSON, got %d %s: %s", status, header.Get("Content-Type"), body)
+	}
+	exported := decodeNDJSON[PullRequestExport](t, body)
+	if len(exported) != 2 || exported[0].PullRequestId != prs[0].PullRequestId ||
+		exported[1].PullRequestId != prs[1].PullRequestId {
+		t.Fatalf("Expected the team's PRs oldest first, got %+v", exported)
+	}
+	if exported[0].Status != "MERGED" || exported[0].MergedAt == nil || exported[0].AuthorId != author.UserID ||
+		exported[0].TeamName != teamName || exported[0].Reassignments != 0 {
+		t.Fatalf("Expected the merged PR, got %+v", exported[0])
+	}
+	if exported[1].Status != "OPEN" || exported[1].Reassignments != 1 ||
+		len(exported[1].Reviewers) != len(prs[1].AssignedReviewers) {
+		t.Fatalf("Expected the reassigned PR with its current reviewers, got %+v", exported[1])
+	}
+	for _, r := range exported[1].Reviewers {
+		if r == oldReviewer {
+			t.Fatalf("Expected the replaced reviewer not to be listed, got %+v", exported[1])
+		}
+	}
+
+	status, header, body = getExport(t, ctx, "/stats/export/pullRequests"+query, "text/csv")
+	if status != http.StatusOK || !strings.HasPrefix(header.Get("Content-Type"), "text/csv") {
+		t.Fatalf("Expected CSV, got %d %s: %s", status, header.Get("Content-Type"), body)
+	}
+	records, err := csv.NewReader(bytes.NewReader(body)).ReadAll()
+	if err != nil {
+		t.Fatalf("Failed to parse CSV: %v", err)
+	}
+	if len(records) != 3 || records[0][0] != "pull_request_id" || records[1][0] != prs[0].PullRequestId ||
+		records[1][1] != "Exported, \"quoted\"" {
+		t.Fatalf("Expected a header and the 2 PRs, got %q", records)
+	}
+	if records[2][1] != "'=HYPERLINK(\"http://example.com\")" {
+		t.Fatalf("Expected a formula-like name to be escaped, got %q", records[2][1])
+	}
+
+	status, _, body = getExport(t, ctx, "/stats/export/assignments"+query, "")
+	if status != http.StatusOK {
+		t.Fatalf("Expected the assignments as NDJSON by default, got %d: %s", status, body)
+	}
+	assignments := decodeNDJSON[ReviewAssignmentExport](t, body)
+	if len(assignments) != len(prs[0].AssignedReviewers)+len(prs[1].AssignedReviewers)+1 {
+		t.Fatalf("Expected the current and the replaced reviews, got %+v", assignments)
+	}
+	var replaced, approved bool
+	for _, a := range assignments {
+		replaced = replaced || a.ReviewerId == oldReviewer && a.ReplacedBy == reassigned.ReplacedBy
+		approved = approved || a.PullRequestId == prs[0].PullRequestId && a.Decision == "APPROVED"
+	}
+	if !replaced || !approved {
+		t.Fatalf("Expected the replaced and the approved reviews, got %+v", assignments)
+	}
+
+	status, _, body = getExport(t, ctx, "/stats/export/assignments"+query, "application/json")
+	var errResp ErrorResponse
+	if status != http.StatusNotAcceptable || json.Unmarshal(body, &errResp) != nil ||
+		errResp.Error.Code != "NOT_ACCEPTABLE" {
+		t.Fatalf("Expected NOT_ACCEPTABLE for JSON, got %d: %s", status, body)
+	}
+	status, _, _ = getExport(t, ctx, "/stats/export/pullRequests?team_name=NoSuchTeam"+generateRandomString(6), "text/csv")
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown team, got %d", status)
+	}
+}
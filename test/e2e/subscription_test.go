@@ -0,0 +1,309 @@
+package e2e
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type WebhookSubscription struct {
+	Id        string    `json:"id"`
+	Url       string    `json:"url"`
+	Events    []string  `json:"events"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type WebhookDelivery struct {
+	Id             string `json:"id"`
+	SubscriptionId string `json:"subscription_id"`
+	Event          string `json:"event"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	ResponseStatus *int   `json:"response_status"`
+	LastError      string `json:"last_error"`
+}
+
+type receivedEvent struct {
+	Delivery string
+	Type     string `json:"type"`
+	Data     struct {
+		PullRequest struct {
+			PullRequestId     string   `json:"pull_request_id"`
+			Status            string   `json:"status"`
+			AssignedReviewers []string `json:"assigned_reviewers"`
+		} `json:"pull_request"`
+		PullRequestId string  `json:"pull_request_id"`
+		OldUserId     string  `json:"old_user_id"`
+		NewUserId     string  `json:"new_user_id"`
+		ReviewerId    string  `json:"reviewer_id"`
+		Reminder      int     `json:"reminder"`
+		WaitingHours  float64 `json:"waiting_hours"`
+	} `json:"data"`
+}
+
+// eventSubscriber receives outbound events for the given pull requests. It checks
+// every signature and fails the first matching delivery with 500 to exercise
+// the retries. Events of other pull requests, published by tests running in
+// parallel, are accepted and dropped.
+type eventSubscriber struct {
+	secret string
+	prIDs  map[string]bool
+
+	mu       sync.Mutex
+	failed   bool
+	badSig   bool
+	received []receivedEvent
+}
+
+func startEventSubscriber(t *testing.T, secret string, prIDs ...string) (*eventSubscriber, string) {
+	t.Helper()
+
+	sub := &eventSubscriber{secret: secret, prIDs: make(map[string]bool)}
+	for _, id := range prIDs {
+		sub.prIDs[id] = true
+	}
+	ln, err := net.Listen("tcp", ":0")
+	if err != nil {
+		t.Fatalf("Failed to listen: %v", err)
+	}
+	srv := &http.Server{Handler: sub}
+	go func() {
+		_ = srv.Serve(ln)
+	}()
+	t.Cleanup(func() {
+		_ = srv.Close()
+	})
+	return sub, fmt.Sprintf("http://host.docker.internal:%d/events", ln.Addr().(*net.TCPAddr).Port)
+}
+
+func (s *eventSubscriber) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := io.ReadAll(r.Body)
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write(body)
+	signed := r.Header.Get("X-Inator-Signature-256") == "sha256="+hex.EncodeToString(mac.Sum(nil))
+
+	var event receivedEvent
+	_ = json.Unmarshal(body, &event)
+	event.Delivery = r.Header.Get("X-Inator-Delivery")
+	if !s.prIDs[event.Data.PullRequest.PullRequestId] && !s.prIDs[event.Data.PullRequestId] {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !signed || event.Type != r.Header.Get("X-Inator-Event") {
+		s.badSig = true
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	if !s.failed {
+		s.failed = true
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	s.received = append(s.received, event)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// waitFor returns the received events once there are at least n of them.
+func (s *eventSubscriber) waitFor(t *testing.T, n int) []receivedEvent {
+	t.Helper()
+
+	var got []receivedEvent
+	deadline := time.Now().Add(10 * time.Second)
+	for time.Now().Before(deadline) {
+		s.mu.Lock()
+		got = append([]receivedEvent(nil), s.received...)
+		badSig := s.badSig
+		s.mu.Unlock()
+		if badSig {
+			t.Fatalf("Received an event with a bad signature")
+		}
+		if len(got) >= n {
+			return got
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+	t.Fatalf("Expected %d events, got %+v", n, got)
+	return nil
+}
+
+func TestOutboundWebhookDeliveries(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "evA" + generateRandomString(4), Username: "EvA", IsActive: true}
+	members := []TeamMember{author}
+	for i := 0; i < 3; i++ {
+		members = append(members, TeamMember{UserID: "evR" + generateRandomString(5), Username: "EvR", IsActive: true})
+	}
+	createTeamHelper(t, ctx, "EventTeam"+generateRandomString(4), members)
+
+	prID := "prEv" + generateRandomString(5)
+	secret := "secret-" + generateRandomString(8)
+	subscriber, url := startEventSubscriber(t, secret, prID)
+
+	status, body := postJSON(t, ctx, "/webhooks/subscriptions", map[string]any{
+		"url":    url,
+		"secret": secret,
+		"events": []string{"pull_request.created", "no.such_event"},
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for an unknown event, got %d: %s", status, body)
+	}
+
+	for _, internal := range []string{"http://127.0.0.1:8080/events", "http://169.254.169.254/latest/meta-data"} {
+		status, body = postJSON(t, ctx, "/webhooks/subscriptions", map[string]any{
+			"url":    internal,
+			"secret": secret,
+		})
+		if status != http.StatusBadRequest {
+			t.Fatalf("Expected 400 for internal URL %s, got %d: %s", internal, status, body)
+		}
+	}
+
+	status, body = postJSON(t, ctx, "/webhooks/subscriptions", map[string]any{
+		"url":    url,
+		"secret": strings.Repeat("s", 1025),
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for a secret over 1024 characters, got %d: %s", status, body)
+	}
+
+	var created struct {
+		Subscription WebhookSubscription `json:"subscription"`
+	}
+	body = mustPostJSON(t, ctx, "/webhooks/subscriptions", map[string]any{
+		"url":    url,
+		"secret": secret,
+		"events": []string{"pull_request.reviewers_assigned", "pull_request.reviewer_reassigned", "pull_request.merged"},
+	})
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatalf("Failed to unmarshal subscription: %v", err)
+	}
+	subscriptionID := created.Subscription.Id
+
+	var createResp CreatePRResponseWrapper
+	body = mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   prID,
+		PullRequestName: "Evented",
+		AuthorId:        author.UserID,
+	})
+	if err := json.Unmarshal(body, &createResp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+
+	assigned := subscriber.waitFor(t, 1)[0]
+	if assigned.Type != "pull_request.reviewers_assigned" || len(assigned.Data.PullRequest.AssignedReviewers) != 2 {
+		t.Fatalf("Expected reviewers_assigned with 2 reviewers after a retry, got %+v", assigned)
+	}
+
+	oldReviewer := createResp.Pr.AssignedReviewers[0]
+	mustPostJSON(t, ctx, "/pullRequest/reassign", ReassignRequest{PullRequestId: prID, OldUserId: oldReviewer})
+	reassigned := subscriber.waitFor(t, 2)[1]
+	if reassigned.Type != "pull_request.reviewer_reassigned" || reassigned.Data.OldUserId != oldReviewer {
+		t.Fatalf("Expected reviewer_reassigned for %s, got %+v", oldReviewer, reassigned)
+	}
+
+	mustPostJSON(t, ctx, "/pullRequest/merge", MergePRRequest{PullRequestId: prID})
+	merged := subscriber.waitFor(t, 3)[2]
+	if merged.Type != "pull_request.merged" || merged.Data.PullRequest.Status != "MERGED" {
+		t.Fatalf("Expected pull_request.merged, got %+v", merged)
+	}
+
+	var log struct {
+		Deliveries []WebhookDelivery `json:"deliveries"`
+	}
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/webhooks/deliveries?subscription_id="+subscriptionID), &log); err != nil {
+		t.Fatalf("Failed to unmarshal deliveries: %v", err)
+	}
+	var first *WebhookDelivery
+	for i, d := range log.Deliveries {
+		if d.Id == assigned.Delivery {
+			first = &log.Deliveries[i]
+		}
+	}
+	if first == nil || first.Status != "delivered" || first.Attempts != 2 {
+		t.Fatalf("Expected delivery %s to succeed on the second attempt, got %+v", assigned.Delivery, first)
+	}
+
+	var redelivered struct {
+		Delivery WebhookDelivery `json:"delivery"`
+	}
+	body = mustPostJSON(t, ctx, "/webhooks/deliveries/redeliver", map[string]string{"delivery_id": first.Id})
+	if err := json.Unmarshal(body, &redelivered); err != nil {
+		t.Fatalf("Failed to unmarshal redelivery: %v", err)
+	}
+	if redelivered.Delivery.Status != "delivered" || redelivered.Delivery.Attempts != 3 {
+		t.Fatalf("Expected redelivery to succeed as a third attempt, got %+v", redelivered.Delivery)
+	}
+	if again := subscriber.waitFor(t, 4)[3]; again.Delivery != first.Id || again.Type != assigned.Type {
+		t.Fatalf("Expected the same delivery to arrive again, got %+v", again)
+	}
+
+	status, body = postJSON(t, ctx, "/webhooks/subscriptions/delete", map[string]string{"subscription_id": subscriptionID})
+	if status != http.StatusNoContent {
+		t.Fatalf("Expected 204 on delete, got %d: %s", status, body)
+	}
+	status, body = postJSON(t, ctx, "/webhooks/deliveries/redeliver", map[string]string{"delivery_id": first.Id})
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for a delivery of a deleted subscription, got %d: %s", status, body)
+	}
+}
+
+func TestOutboxSkipsRolledBackChanges(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	loner := TeamMember{UserID: "obL" + generateRandomString(4), Username: "ObL", IsActive: true}
+	createTeamHelper(t, ctx, "OutboxSolo"+generateRandomString(4), []TeamMember{loner})
+	author := TeamMember{UserID: "obA" + generateRandomString(4), Username: "ObA", IsActive: true}
+	reviewer := TeamMember{UserID: "obR" + generateRandomString(4), Username: "ObR", IsActive: true}
+	createTeamHelper(t, ctx, "OutboxPair"+generateRandomString(4), []TeamMember{author, reviewer})
+
+	failedID := "prObF" + generateRandomString(5)
+	createdID := "prObC" + generateRandomString(5)
+	secret := "secret-" + generateRandomString(8)
+	subscriber, url := startEventSubscriber(t, secret, failedID, createdID)
+	mustPostJSON(t, ctx, "/webhooks/subscriptions", map[string]any{
+		"url":    url,
+		"secret": secret,
+		"events": []string{"pull_request.created"},
+	})
+
+	status, body := postJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   failedID,
+		PullRequestName: "Nobody to review",
+		AuthorId:        loner.UserID,
+	})
+	if status < 400 {
+		t.Fatalf("Expected PR without candidates to be rejected, got %d: %s", status, body)
+	}
+	mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   createdID,
+		PullRequestName: "Reviewed",
+		AuthorId:        author.UserID,
+	})
+
+	// The outbox hands events over in order, so an event of the rejected PR
+	// would be out by now; the pause covers a retry of its first delivery.
+	events := subscriber.waitFor(t, 1)
+	time.Sleep(500 * time.Millisecond)
+	events = subscriber.waitFor(t, len(events))
+	for _, event := range events {
+		if event.Data.PullRequest.PullRequestId != createdID {
+			t.Fatalf("Expected only %s to be announced, got %+v", createdID, event)
+		}
+	}
+}
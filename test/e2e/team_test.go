@@ -3,6 +3,7 @@ package e2e
 import (
 	"context"
 	"encoding/json"
+	"net/http"
 	"testing"
 )
 
@@ -79,3 +80,110 @@ func TestTeamAndUserLifecycle(t *testing.T) {
 		t.Fatalf("Expected 1 active member (A) after deactivating B, got %d", activeCount)
 	}
 }
+
+func TestTeamReviewerStrategySettings(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	teamName := "StrategyTeam" + generateRandomString(4)
+	userA := TeamMember{UserID: "uS" + generateRandomString(4), Username: "Sam", IsActive: true}
+	createTeamHelper(t, ctx, teamName, []TeamMember{userA})
+
+	t.Log("1. Checking default strategy...")
+	var settings TeamSettings
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/team/settings?team_name="+teamName), &settings); err != nil {
+		t.Fatalf("Failed to unmarshal team settings: %v", err)
+	}
+	if settings.ReviewerStrategy != "least_loaded" {
+		t.Fatalf("Expected default strategy least_loaded, got %s", settings.ReviewerStrategy)
+	}
+
+	t.Log("2. Switching to round_robin...")
+	body := mustPostJSON(t, ctx, "/team/settings", TeamSettings{TeamName: teamName, ReviewerStrategy: "round_robin"})
+	if err := json.Unmarshal(body, &settings); err != nil {
+		t.Fatalf("Failed to unmarshal team settings: %v", err)
+	}
+	if settings.ReviewerStrategy != "round_robin" {
+		t.Fatalf("Expected strategy round_robin, got %s", settings.ReviewerStrategy)
+	}
+
+	t.Log("3. Rejecting unknown strategy...")
+	if status, _ := postJSON(t, ctx, "/team/settings", TeamSettings{TeamName: teamName, ReviewerStrategy: "alphabetical"}); status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 Bad Request, got %d", status)
+	}
+
+	t.Log("4. Rejecting a min above the max...")
+	three, one := 3, 1
+	if status, _ := postJSON(t, ctx, "/team/settings", TeamSettings{TeamName: teamName, MinReviewers: &three, MaxReviewers: &one}); status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 Bad Request for min 3 and max 1, got %d", status)
+	}
+
+	t.Log("5. Resetting the limits with null...")
+	body = mustPostJSON(t, ctx, "/team/settings", TeamSettings{TeamName: teamName, MinReviewers: &three, MaxReviewers: &three})
+	if err := json.Unmarshal(body, &settings); err != nil {
+		t.Fatalf("Failed to unmarshal team settings: %v", err)
+	}
+	if *settings.MinReviewers != 3 || *settings.MaxReviewers != 3 {
+		t.Fatalf("Expected limits 3..3, got %d..%d", *settings.MinReviewers, *settings.MaxReviewers)
+	}
+	body = mustPostJSON(t, ctx, "/team/settings", map[string]any{"team_name": teamName, "min_reviewers": nil, "max_reviewers": nil})
+	if err := json.Unmarshal(body, &settings); err != nil {
+		t.Fatalf("Failed to unmarshal team settings: %v", err)
+	}
+	if *settings.MinReviewers != 1 || *settings.MaxReviewers != 2 {
+		t.Fatalf("Expected the default limits 1..2 after reset, got %d..%d", *settings.MinReviewers, *settings.MaxReviewers)
+	}
+}
+
+func TestTeamDeactivateReassignsOpenReviews(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authD" + generateRandomString(4), Username: "AuthD", IsActive: true}
+	first := TeamMember{UserID: "revD1" + generateRandomString(4), Username: "RevD1", IsActive: true}
+	second := TeamMember{UserID: "revD2" + generateRandomString(4), Username: "RevD2", IsActive: true}
+	third := TeamMember{UserID: "revD3" + generateRandomString(4), Username: "RevD3", IsActive: true}
+
+	teamName := "OffsiteTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, first, second, third})
+
+	body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prD" + generateRandomString(5),
+		PullRequestName: "Before offsite",
+		AuthorId:        author.UserID,
+	})
+	var createResp CreatePRResponseWrapper
+	if err := json.Unmarshal(body, &createResp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+	leaving := createResp.Pr.AssignedReviewers
+	if len(leaving) != 2 {
+		t.Fatalf("Expected 2 reviewers, got %v", leaving)
+	}
+
+	body = mustPostJSON(t, ctx, "/team/deactivate", DeactivateRequest{TeamName: teamName, UserIds: leaving})
+	var report DeactivationReport
+	if err := json.Unmarshal(body, &report); err != nil {
+		t.Fatalf("Failed to unmarshal deactivation report: %v", err)
+	}
+
+	if len(report.DeactivatedUsers) != 2 {
+		t.Fatalf("Expected 2 deactivated users, got %v", report.DeactivatedUsers)
+	}
+	if len(report.Reassigned) != 1 || len(report.Unassigned) != 1 {
+		t.Fatalf("Expected 1 reassigned and 1 unassigned review, got %+v", report)
+	}
+	if newID := report.Reassigned[0].NewUserId; newID == author.UserID || newID == leaving[0] || newID == leaving[1] {
+		t.Fatalf("Review reassigned to an ineligible user %s", newID)
+	}
+
+	var team Team
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/team/get?team_name="+teamName), &team); err != nil {
+		t.Fatalf("Failed to unmarshal team: %v", err)
+	}
+	for _, m := range team.Members {
+		if (m.UserID == leaving[0] || m.UserID == leaving[1]) && m.IsActive {
+			t.Fatalf("Expected %s to be inactive", m.UserID)
+		}
+	}
+}
@@ -0,0 +1,253 @@
+package e2e
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestUnavailableUserIsNotAssigned(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "authV" + generateRandomString(4), Username: "AuthV", IsActive: true}
+	away := TeamMember{UserID: "awayV" + generateRandomString(4), Username: "AwayV", IsActive: true}
+	present := TeamMember{UserID: "presV" + generateRandomString(4), Username: "PresV", IsActive: true}
+
+	teamName := "VacationTeam" + generateRandomString(4)
+	createTeamHelper(t, ctx, teamName, []TeamMember{author, away, present})
+
+	now := time.Now().UTC()
+	mustPostJSON(t, ctx, "/users/unavailability", UnavailabilityRequest{
+		UserId:   away.UserID,
+		StartsAt: now.Add(-time.Hour),
+		EndsAt:   now.Add(7 * 24 * time.Hour),
+		Reason:   "Vacation",
+	})
+
+	body := mustPostJSON(t, ctx, "/pullRequest/create", CreatePRRequest{
+		PullRequestId:   "prV" + generateRandomString(5),
+		PullRequestName: "While away",
+		AuthorId:        author.UserID,
+	})
+	var createResp CreatePRResponseWrapper
+	if err := json.Unmarshal(body, &createResp); err != nil {
+		t.Fatalf("Failed to unmarshal created PR: %v", err)
+	}
+	if len(createResp.Pr.AssignedReviewers) != 1 || createResp.Pr.AssignedReviewers[0] != present.UserID {
+		t.Fatalf("Expected only %s to be assigned, got %v", present.UserID, createResp.Pr.AssignedReviewers)
+	}
+
+	var list UnavailabilityList
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/users/unavailability?user_id="+away.UserID), &list); err != nil {
+		t.Fatalf("Failed to unmarshal unavailability list: %v", err)
+	}
+	if len(list.Periods) != 1 || list.Periods[0].Source != "manual" {
+		t.Fatalf("Expected 1 manual period, got %+v", list.Periods)
+	}
+
+	status, respBody := postJSON(t, ctx, "/users/unavailability/delete", map[string]string{
+		"user_id": away.UserID,
+		"id":      list.Periods[0].Id,
+	})
+	if status != http.StatusNoContent {
+		t.Fatalf("Expected 204 on delete, got %d: %s", status, respBody)
+	}
+
+	status, respBody = postJSON(t, ctx, "/users/unavailability", UnavailabilityRequest{
+		UserId:   away.UserID,
+		StartsAt: now,
+		EndsAt:   now.Add(-time.Hour),
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for inverted period, got %d: %s", status, respBody)
+	}
+}
+
+func TestUnavailabilityCalendarImport(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	user := TeamMember{UserID: "icsU" + generateRandomString(4), Username: "IcsU", IsActive: true}
+	createTeamHelper(t, ctx, "CalendarTeam"+generateRandomString(4), []TeamMember{user})
+
+	start := time.Now().UTC().AddDate(0, 0, 10)
+	calendar := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"BEGIN:VEVENT",
+		"UID:trip-1@example.com",
+		"SUMMARY:Conference",
+		"DTSTART;VALUE=DATE:" + start.Format("20060102"),
+		"DTEND;VALUE=DATE:" + start.AddDate(0, 0, 3).Format("20060102"),
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:old-1@example.com",
+		"DTSTART:20200101T090000Z",
+		"DTEND:20200101T170000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:standup-1@example.com",
+		"DTSTART:" + start.Format("20060102") + "T090000Z",
+		"DURATION:PT1H",
+		"RRULE:FREQ=DAILY;COUNT=3",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DURATION:PT15M",
+		"END:VALARM",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")
+
+	result := importCalendar(t, ctx, user.UserID, calendar)
+	if result.Imported != 4 {
+		t.Fatalf("Expected 4 imported periods, got %+v", result)
+	}
+
+	result = importCalendar(t, ctx, user.UserID, "BEGIN:VCALENDAR\r\nEND:VCALENDAR\r\n")
+	if result.Imported != 0 || result.Removed != 4 {
+		t.Fatalf("Expected the previously imported period to be removed, got %+v", result)
+	}
+}
+
+func importCalendar(t *testing.T, ctx context.Context, userID, calendar string) CalendarImportResult {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost,
+		BaseURL+"/users/unavailability/import?user_id="+userID, strings.NewReader(calendar))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "text/calendar")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute import request: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200 on import, got %d: %s", resp.StatusCode, body)
+	}
+
+	var result CalendarImportResult
+	if err := json.Unmarshal(body, &result); err != nil {
+		t.Fatalf("Failed to unmarshal import result: %v", err)
+	}
+	return result
+}
+
+func TestUserIdentityMapping(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "idA" + generateRandomString(4), Username: "id-author-" + generateRandomString(6), IsActive: true}
+	reviewer := TeamMember{UserID: "idR" + generateRandomString(4), Username: "id-reviewer-" + generateRandomString(6), IsActive: true}
+	other := TeamMember{UserID: "idO" + generateRandomString(4), Username: "id-other-" + generateRandomString(6), IsActive: true}
+	createTeamHelper(t, ctx, "IdentityTeam"+generateRandomString(4), []TeamMember{author, reviewer, other})
+
+	login := "Octo-" + generateRandomString(6)
+	fixture := strings.NewReplacer(
+		"LOGIN", login,
+		"MERGER", login,
+		"REPOSITORY", "acme/identity-"+generateRandomString(6),
+	)
+	opened := loadGitHubFixture(t, fixture, "pull_request.opened.json")
+
+	status, body := deliverGitHub(t, ctx, "pull_request", opened, signGitHub(opened))
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unmapped login, got %d: %s", status, body)
+	}
+
+	var created struct {
+		Identity UserIdentity `json:"identity"`
+	}
+	body = mustPostJSON(t, ctx, "/users/identities", UserIdentityRequest{
+		UserId:     author.UserID,
+		Provider:   "github",
+		ExternalId: login,
+	})
+	if err := json.Unmarshal(body, &created); err != nil {
+		t.Fatalf("Failed to unmarshal identity: %v", err)
+	}
+	if created.Identity.ExternalId != strings.ToLower(login) {
+		t.Fatalf("Expected the GitHub login to be stored lowercased, got %+v", created.Identity)
+	}
+
+	status, body = postJSON(t, ctx, "/users/identities", UserIdentityRequest{
+		UserId:     other.UserID,
+		Provider:   "github",
+		ExternalId: strings.ToUpper(login),
+	})
+	if status != http.StatusConflict {
+		t.Fatalf("Expected 409 for a login owned by another user, got %d: %s", status, body)
+	}
+
+	status, body = postJSON(t, ctx, "/users/identities", UserIdentityRequest{
+		UserId:     other.UserID,
+		Provider:   "email",
+		ExternalId: "not an address",
+	})
+	if status != http.StatusBadRequest {
+		t.Fatalf("Expected 400 for an invalid email, got %d: %s", status, body)
+	}
+
+	mustPostJSON(t, ctx, "/users/identities", UserIdentityRequest{
+		UserId:     author.UserID,
+		Provider:   "slack",
+		ExternalId: "U" + generateRandomString(8),
+	})
+
+	var list UserIdentityList
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/users/identities?user_id="+author.UserID), &list); err != nil {
+		t.Fatalf("Failed to unmarshal identity list: %v", err)
+	}
+	if len(list.Identities) != 2 {
+		t.Fatalf("Expected 2 identities, got %+v", list.Identities)
+	}
+
+	var found UserIdentity
+	if err := json.Unmarshal(mustGetJSON(t, ctx, "/users/identities/lookup?provider=github&external_id="+login), &found); err != nil {
+		t.Fatalf("Failed to unmarshal identity: %v", err)
+	}
+	if found.UserId != author.UserID {
+		t.Fatalf("Expected %s to own %s, got %+v", author.UserID, login, found)
+	}
+
+	result := mustDeliverGitHub(t, ctx, "pull_request", opened)
+	if result.Outcome != "processed" {
+		t.Fatalf("Expected the mapped login to open a PR, got %+v", result)
+	}
+	if awaitingAction(t, ctx, author.UserID, result.PullRequestId) {
+		t.Fatalf("Expected %s to be the author of %s, not a reviewer", author.UserID, result.PullRequestId)
+	}
+
+	status, body = postJSON(t, ctx, "/users/identities/delete", map[string]string{
+		"user_id":  author.UserID,
+		"provider": "github",
+	})
+	if status != http.StatusNoContent {
+		t.Fatalf("Expected 204 on delete, got %d: %s", status, body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet,
+		BaseURL+"/users/identities/lookup?provider=github&external_id="+login, nil)
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute lookup request: %v", err)
+	}
+	_ = resp.Body.Close()
+	if resp.StatusCode != http.StatusNotFound {
+		t.Fatalf("Expected 404 after delete, got %d", resp.StatusCode)
+	}
+}
@@ -0,0 +1,250 @@
+package e2e
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"testing"
+)
+
+const (
+	githubWebhookSecret = "e2e-github-secret"
+	gitlabWebhookToken  = "e2e-gitlab-token"
+)
+
+type WebhookResult struct {
+	Event         string `json:"event"`
+	Action        string `json:"action"`
+	Outcome       string `json:"outcome"`
+	PullRequestId string `json:"pull_request_id"`
+	Reason        string `json:"reason"`
+}
+
+func TestGitHubWebhookDrivesPullRequest(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	author := TeamMember{UserID: "ghA" + generateRandomString(4), Username: "gh-author-" + generateRandomString(6), IsActive: true}
+	merger := TeamMember{UserID: "ghM" + generateRandomString(4), Username: "gh-merger-" + generateRandomString(6), IsActive: true}
+	reviewer := TeamMember{UserID: "ghR" + generateRandomString(4), Username: "gh-reviewer-" + generateRandomString(6), IsActive: true}
+	createTeamHelper(t, ctx, "GitHubTeam"+generateRandomString(4), []TeamMember{author, merger, reviewer})
+
+	fixture := strings.NewReplacer(
+		"LOGIN", author.Username,
+		"MERGER", merger.Username,
+		"REPOSITORY", "acme/service-"+generateRandomString(6),
+	)
+
+	ping := loadGitHubFixture(t, fixture, "ping.json")
+	if result := mustDeliverGitHub(t, ctx, "ping", ping); result.Outcome != "ignored" {
+		t.Fatalf("Expected ping to be ignored, got %+v", result)
+	}
+
+	opened := loadGitHubFixture(t, fixture, "pull_request.opened.json")
+	status, body := deliverGitHub(t, ctx, "pull_request", opened, "sha256="+strings.Repeat("0", 64))
+	if status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a bad signature, got %d: %s", status, body)
+	}
+	status, body = deliverGitHub(t, ctx, "pull_request", opened, "")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a missing signature, got %d: %s", status, body)
+	}
+
+	result := mustDeliverGitHub(t, ctx, "pull_request", opened)
+	if result.Outcome != "processed" || result.PullRequestId == "" {
+		t.Fatalf("Expected opened event to create a PR, got %+v", result)
+	}
+	prID := result.PullRequestId
+	if report := getMergeability(t, ctx, prID); report.Status != "DRAFT" {
+		t.Fatalf("Expected draft PR, got %s", report.Status)
+	}
+
+	if redelivered := mustDeliverGitHub(t, ctx, "pull_request", opened); redelivered.PullRequestId != prID {
+		t.Fatalf("Expected redelivery to resolve to %s, got %+v", prID, redelivered)
+	}
+
+	mustDeliverGitHub(t, ctx, "pull_request", loadGitHubFixture(t, fixture, "pull_request.ready_for_review.json"))
+	if report := getMergeability(t, ctx, prID); report.Status != "OPEN" {
+		t.Fatalf("Expected PR to be OPEN after ready_for_review, got %s", report.Status)
+	}
+	if !awaitingAction(t, ctx, reviewer.UserID, prID) {
+		t.Fatalf("Expected %s to be assigned to review %s", reviewer.UserID, prID)
+	}
+
+	closed := loadGitHubFixture(t, fixture, "pull_request.closed.json")
+	if result := mustDeliverGitHub(t, ctx, "pull_request", closed); result.Outcome != "processed" {
+		t.Fatalf("Expected merge to be processed, got %+v", result)
+	}
+	if report := getMergeability(t, ctx, prID); report.Status != "MERGED" {
+		t.Fatalf("Expected PR to be MERGED, got %s", report.Status)
+	}
+	if result := mustDeliverGitHub(t, ctx, "pull_request", closed); result.Outcome != "processed" {
+		t.Fatalf("Expected merge redelivery to be accepted, got %+v", result)
+	}
+
+	stranger := strings.NewReplacer("LOGIN", "gh-unknown-"+generateRandomString(6), "REPOSITORY", "acme/other")
+	status, body = deliverGitHub(t, ctx, "pull_request", loadGitHubFixture(t, stranger, "pull_request.opened.json"),
+		signGitHub(loadGitHubFixture(t, stranger, "pull_request.opened.json")))
+	if status != http.StatusNotFound {
+		t.Fatalf("Expected 404 for an unknown author, got %d: %s", status, body)
+	}
+}
+
+func TestGitLabWebhookDrivesMergeRequest(t *testing.T) {
+	ctx := context.Background()
+	t.Parallel()
+
+	// glJane is mapped from the GitLab username jane.gitlab in docker-compose.test.yml.
+	author := TeamMember{UserID: "glJane", Username: "Jane" + generateRandomString(6), IsActive: true}
+	merger := TeamMember{UserID: "glM" + generateRandomString(4), Username: "gl-merger-" + generateRandomString(6), IsActive: true}
+	reviewer := TeamMember{UserID: "glR" + generateRandomString(4), Username: "gl-reviewer-" + generateRandomString(6), IsActive: true}
+	createTeamHelper(t, ctx, "GitLabTeam"+generateRandomString(4), []TeamMember{author, merger, reviewer})
+
+	fixture := strings.NewReplacer(
+		"USERNAME", "jane.gitlab",
+		"MERGER", merger.Username,
+		"PROJECT", "platform/service-"+generateRandomString(6),
+	)
+	open := loadFixture(t, fixture, "gitlab/merge_request.open.json")
+
+	status, body := deliverGitLab(t, ctx, "Merge Request Hook", open, "wrong-token")
+	if status != http.StatusUnauthorized {
+		t.Fatalf("Expected 401 for a wrong token, got %d: %s", status, body)
+	}
+
+	if result := mustDeliverGitLab(t, ctx, "Push Hook", []byte(`{"object_kind":"push"}`)); result.Outcome != "ignored" {
+		t.Fatalf("Expected push event to be ignored, got %+v", result)
+	}
+
+	result := mustDeliverGitLab(t, ctx, "Merge Request Hook", open)
+	if result.Outcome != "processed" || result.PullRequestId == "" {
+		t.Fatalf("Expected open event to create a PR, got %+v", result)
+	}
+	prID := result.PullRequestId
+
+	steps := []struct {
+		fixture    string
+		wantStatus string
+	}{
+		{"gitlab/merge_request.update.json", "OPEN"},
+		{"gitlab/merge_request.redraft.json", "DRAFT"},
+		{"gitlab/merge_request.update.json", "OPEN"},
+		{"gitlab/merge_request.close.json", "CLOSED"},
+		{"gitlab/merge_request.reopen.json", "OPEN"},
+		{"gitlab/merge_request.merge.json", "MERGED"},
+	}
+	for _, step := range steps {
+		if result := mustDeliverGitLab(t, ctx, "Merge Request Hook", loadFixture(t, fixture, step.fixture)); result.Outcome != "processed" {
+			t.Fatalf("Expected %s to be processed, got %+v", step.fixture, result)
+		}
+		if report := getMergeability(t, ctx, prID); report.Status != step.wantStatus {
+			t.Fatalf("Expected %s after %s, got %s", step.wantStatus, step.fixture, report.Status)
+		}
+	}
+}
+
+func loadGitHubFixture(t *testing.T, fixture *strings.Replacer, name string) []byte {
+	t.Helper()
+	return loadFixture(t, fixture, "github/"+name)
+}
+
+func loadFixture(t *testing.T, fixture *strings.Replacer, name string) []byte {
+	t.Helper()
+
+	raw, err := os.ReadFile("testdata/" + name)
+	if err != nil {
+		t.Fatalf("Failed to read fixture %s: %v", name, err)
+	}
+	return []byte(fixture.Replace(string(raw)))
+}
+
+func signGitHub(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(githubWebhookSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func mustDeliverGitHub(t *testing.T, ctx context.Context, event string, body []byte) WebhookResult {
+	t.Helper()
+
+	status, respBody := deliverGitHub(t, ctx, event, body, signGitHub(body))
+	if status != http.StatusOK {
+		t.Fatalf("Expected 200 for %s delivery, got %d: %s", event, status, respBody)
+	}
+
+	var result WebhookResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		t.Fatalf("Failed to unmarshal webhook result: %v", err)
+	}
+	return result
+}
+
+func deliverGitHub(t *testing.T, ctx context.Context, event string, body []byte, signature string) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, BaseURL+"/webhooks/github", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	if signature != "" {
+		req.Header.Set("X-Hub-Signature-256", signature)
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute webhook request: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, respBody
+}
+
+func mustDeliverGitLab(t *testing.T, ctx context.Context, event string, body []byte) WebhookResult {
+	t.Helper()
+
+	status, respBody := deliverGitLab(t, ctx, event, body, gitlabWebhookToken)
+	if status != http.StatusOK {
+		t.Fatalf("Expected 200 for %s delivery, got %d: %s", event, status, respBody)
+	}
+
+	var result WebhookResult
+	if err := json.Unmarshal(respBody, &result); err != nil {
+		t.Fatalf("Failed to unmarshal webhook result: %v", err)
+	}
+	return result
+}
+
+func deliverGitLab(t *testing.T, ctx context.Context, event string, body []byte, token string) (int, []byte) {
+	t.Helper()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, BaseURL+"/webhooks/gitlab", bytes.NewReader(body))
+	if err != nil {
+		t.Fatalf("Failed to create request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Gitlab-Event", event)
+	req.Header.Set("X-Gitlab-Token", token)
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("Failed to execute webhook request: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	respBody, _ := io.ReadAll(resp.Body)
+	return resp.StatusCode, respBody
+}